@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,11 +14,17 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/internal/app/infrastructure/messaging"
 	"github.com/sh1ro/todo-api/internal/app/infrastructure/persistence"
 	"github.com/sh1ro/todo-api/internal/app/interfaces/api"
 	customMiddleware "github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/migrations"
 	"github.com/sh1ro/todo-api/pkg/config"
+	"github.com/sh1ro/todo-api/pkg/health"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/ratelimit"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 func main() {
@@ -36,39 +43,108 @@ func main() {
 		log.Fatal("Failed to load configuration", "error", err)
 	}
 
+	// The API version is also the version of the service in traces
+	apiVersion := os.Getenv("API_VERSION")
+	if apiVersion == "" {
+		apiVersion = "v1"
+	}
+
+	// Initialize tracing
+	tracerProvider, err := tracing.NewProvider(context.Background(), tracing.Options{
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.OTLPEndpoint,
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: apiVersion,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize tracing", "error", err)
+	}
+
 	// Initialize database connection
-	db, err := persistence.NewPostgresDB(cfg.Database)
+	db, err := persistence.NewPostgresDB(context.Background(), cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database", "error", err)
 	}
 	defer db.Close()
 
+	// Create the store of rate limit buckets
+	rateLimitStore, err := ratelimit.NewStore(cfg.RateLimit.Store, cfg.RateLimit.RedisURL)
+	if err != nil {
+		log.Fatal("Failed to create rate limit store", "error", err)
+	}
+	defer rateLimitStore.Close()
+
+	// Register health checks
+	schemaVersion, err := migrations.LatestVersion()
+	if err != nil {
+		log.Fatal("Failed to read migrations", "error", err)
+	}
+	healthChecks := health.NewRegistry(cfg.Health.CheckTimeout)
+	healthChecks.Critical("database", health.PingCheck(db.Ping))
+	healthChecks.Critical("migrations", health.MigrationCheck(db.MigrationVersion, schemaVersion))
+	healthChecks.Optional("disk", health.DiskCheck(cfg.Health.DiskPath, cfg.Health.DiskMinFree))
+
 	// Create Echo instance
 	e := echo.New()
 	
 	// Configure Echo
 	e.HideBanner = true
 	e.HidePort = true
+
+	// Find client IPs behind trusted proxies
+	e.IPExtractor = customMiddleware.IPExtractor(cfg.TrustedProxies)
+
+	// Turn errors into error responses, hiding internal detail in production
+	e.HTTPErrorHandler = api.NewHTTPErrorHandler(log, cfg.Env != "production")
 	
 	// Add middleware
 	e.Use(middleware.Recover())
+	e.Use(customMiddleware.Tracing())
 	e.Use(customMiddleware.RequestID(log))
+	e.Use(customMiddleware.ProblemDetails(cfg.ErrorFormat))
 	e.Use(customMiddleware.Logger(log))
 	e.Use(customMiddleware.CORS(cfg.CORS))
 
 	// Setup API routes
-	apiVersion := os.Getenv("API_VERSION")
-	if apiVersion == "" {
-		apiVersion = "v1"
+	apiGroup := e.Group(fmt.Sprintf("/api/%s", apiVersion))
+	wellKnownGroup := e.Group("/.well-known")
+	if err := api.RegisterRoutes(apiGroup, wellKnownGroup, db, rateLimitStore, healthChecks, log, cfg); err != nil {
+		log.Fatal("Failed to register routes", "error", err)
 	}
 
-	apiGroup := e.Group(fmt.Sprintf("/api/%s", apiVersion))
-	api.RegisterRoutes(apiGroup, db, log, cfg)
+	// Start background schedulers
+	schedulerCtx, stopSchedulers := context.WithCancel(context.Background())
+	defer stopSchedulers()
+
+	snoozeScheduler := service.NewSnoozeScheduler(
+		persistence.NewPostgresTodoRepository(db),
+		messaging.NewLogPublisher(log),
+		cfg.Scheduler.SnoozeInterval,
+		log,
+	)
+	go snoozeScheduler.Start(schedulerCtx)
+
+	accountPurgeScheduler := service.NewAccountPurgeScheduler(
+		persistence.NewPostgresUserRepository(db),
+		persistence.NewPostgresTodoRepository(db),
+		persistence.NewPostgresTxManager(db, cfg.Database),
+		cfg.Scheduler.AccountPurgeInterval,
+		log,
+	)
+	go accountPurgeScheduler.Start(schedulerCtx)
+
+	// Requests derive their context from this one, so it can cancel their queries on shutdown
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
 
 	// Start server
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Port),
 		Handler: e,
+		BaseContext: func(net.Listener) context.Context {
+			return requestCtx
+		},
 	}
 
 	// Graceful shutdown
@@ -86,13 +162,28 @@ func main() {
 
 	log.Info("Shutting down server...")
 
+	// Fail readiness first, so traffic is routed elsewhere before the listener closes
+	healthChecks.Shutdown()
+	time.Sleep(cfg.Health.ShutdownDelay)
+
+	// Stop background schedulers
+	stopSchedulers()
+
 	// Create shutdown context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := e.Shutdown(ctx); err != nil {
+		// Cancel the requests still running, so their queries stop before the database is closed
+		cancelRequests()
+		db.Close()
 		log.Fatal("Server forced to shutdown", "error", err)
 	}
 
+	// Export the spans still buffered
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		log.Error("Failed to shut down tracing", "error", err)
+	}
+
 	log.Info("Server exiting")
 }
@@ -0,0 +1,50 @@
+// internal/app/application/command/add_blocker_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// AddBlockerCommand represents a command to mark a todo as blocked by another todo
+type AddBlockerCommand struct {
+	UserID    uuid.UUID `json:"-"`
+	TodoID    uuid.UUID `json:"-"`
+	BlockerID uuid.UUID `json:"blocker_id" validate:"required"`
+}
+
+// AddBlockerHandler handles the AddBlockerCommand
+type AddBlockerHandler struct {
+	dependencyService *service.DependencyService
+	logger            *logger.Logger
+}
+
+// NewAddBlockerHandler creates a new AddBlockerHandler
+func NewAddBlockerHandler(dependencyService *service.DependencyService, logger *logger.Logger) *AddBlockerHandler {
+	return &AddBlockerHandler{
+		dependencyService: dependencyService,
+		logger:            logger,
+	}
+}
+
+// Handle handles the AddBlockerCommand
+func (h *AddBlockerHandler) Handle(c echo.Context, cmd AddBlockerCommand) (_ *model.TodoDependency, err error) {
+	end := tracing.StartEcho(c, "AddBlockerHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Adding blocker", "userID", cmd.UserID, "todoID", cmd.TodoID, "blockerID", cmd.BlockerID)
+
+	dependency, err := h.dependencyService.AddBlocker(c.Request().Context(), cmd.UserID, cmd.TodoID, cmd.BlockerID)
+	if err != nil {
+		log.Error("Failed to add blocker", "error", err)
+		return nil, err
+	}
+
+	return dependency, nil
+}
@@ -0,0 +1,62 @@
+// internal/app/application/command/add_time_entry_command.go
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// AddTimeEntryCommand represents a command to record time spent on a todo without a timer
+type AddTimeEntryCommand struct {
+	UserID    uuid.UUID `json:"-"`
+	TodoID    uuid.UUID `json:"-"`
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" validate:"required"`
+	Note      string    `json:"note" validate:"max=500"`
+}
+
+// AddTimeEntryHandler handles the AddTimeEntryCommand
+type AddTimeEntryHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewAddTimeEntryHandler creates a new AddTimeEntryHandler
+func NewAddTimeEntryHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *AddTimeEntryHandler {
+	return &AddTimeEntryHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the AddTimeEntryCommand
+func (h *AddTimeEntryHandler) Handle(c echo.Context, cmd AddTimeEntryCommand) (_ *model.TimeEntry, err error) {
+	end := tracing.StartEcho(c, "AddTimeEntryHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Adding time entry", "userID", cmd.UserID, "todoID", cmd.TodoID)
+
+	entry, err := h.timeTrackingService.AddManualEntry(
+		c.Request().Context(),
+		cmd.UserID,
+		cmd.TodoID,
+		cmd.StartedAt,
+		cmd.EndedAt,
+		cmd.Note,
+	)
+
+	if err != nil {
+		log.Error("Failed to add time entry", "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,53 @@
+// internal/app/application/command/authorize_oauth_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// AuthorizeOAuthCommand represents a command to start a login with an identity provider
+type AuthorizeOAuthCommand struct {
+	Provider string `json:"-" validate:"required"`
+}
+
+// AuthorizationResult represents where to send the user to log in with an identity provider.
+// The state hash is kept by the browser, binding the login to it.
+type AuthorizationResult struct {
+	AuthorizationURL string `json:"authorization_url"`
+	StateHash        string `json:"-"`
+}
+
+// AuthorizeOAuthHandler handles the AuthorizeOAuthCommand
+type AuthorizeOAuthHandler struct {
+	oauthService *service.OAuthService
+	logger       *logger.Logger
+}
+
+// NewAuthorizeOAuthHandler creates a new AuthorizeOAuthHandler
+func NewAuthorizeOAuthHandler(oauthService *service.OAuthService, logger *logger.Logger) *AuthorizeOAuthHandler {
+	return &AuthorizeOAuthHandler{
+		oauthService: oauthService,
+		logger:       logger,
+	}
+}
+
+// Handle handles the AuthorizeOAuthCommand
+func (h *AuthorizeOAuthHandler) Handle(c echo.Context, cmd AuthorizeOAuthCommand) (_ *AuthorizationResult, err error) {
+	end := tracing.StartEcho(c, "AuthorizeOAuthHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Starting OAuth login", "provider", cmd.Provider)
+
+	url, stateHash, err := h.oauthService.Authorize(c.Request().Context(), cmd.Provider)
+	if err != nil {
+		log.Error("Failed to start OAuth login", "error", err)
+		return nil, err
+	}
+
+	return &AuthorizationResult{AuthorizationURL: url, StateHash: stateHash}, nil
+}
@@ -0,0 +1,48 @@
+// internal/app/application/command/cancel_account_deletion_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// CancelAccountDeletionCommand represents a command to cancel the scheduled deletion of the current user's account
+type CancelAccountDeletionCommand struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// CancelAccountDeletionHandler handles the CancelAccountDeletionCommand
+type CancelAccountDeletionHandler struct {
+	profileService *service.ProfileService
+	logger         *logger.Logger
+}
+
+// NewCancelAccountDeletionHandler creates a new CancelAccountDeletionHandler
+func NewCancelAccountDeletionHandler(profileService *service.ProfileService, logger *logger.Logger) *CancelAccountDeletionHandler {
+	return &CancelAccountDeletionHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the CancelAccountDeletionCommand
+func (h *CancelAccountDeletionHandler) Handle(c echo.Context, cmd CancelAccountDeletionCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "CancelAccountDeletionHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Cancelling account deletion", "userID", cmd.UserID)
+
+	user, err := h.profileService.CancelDeletion(c.Request().Context(), cmd.UserID)
+	if err != nil {
+		log.Error("Failed to cancel account deletion", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,51 @@
+// internal/app/application/command/change_password_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ChangePasswordCommand represents a command to change the current user's password
+type ChangePasswordCommand struct {
+	UserID          uuid.UUID        `json:"-"`
+	CurrentPassword string           `json:"current_password" validate:"required"`
+	NewPassword     string           `json:"new_password" validate:"required,password"`
+	Client          model.ClientInfo `json:"-"`
+}
+
+// ChangePasswordHandler handles the ChangePasswordCommand
+type ChangePasswordHandler struct {
+	profileService *service.ProfileService
+	logger         *logger.Logger
+}
+
+// NewChangePasswordHandler creates a new ChangePasswordHandler
+func NewChangePasswordHandler(profileService *service.ProfileService, logger *logger.Logger) *ChangePasswordHandler {
+	return &ChangePasswordHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the ChangePasswordCommand. Other sessions are signed out, so the result carries a new token.
+func (h *ChangePasswordHandler) Handle(c echo.Context, cmd ChangePasswordCommand) (_ *LoginResult, err error) {
+	end := tracing.StartEcho(c, "ChangePasswordHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Changing password", "userID", cmd.UserID)
+
+	user, token, err := h.profileService.ChangePassword(c.Request().Context(), cmd.UserID, cmd.CurrentPassword, cmd.NewPassword, cmd.Client)
+	if err != nil {
+		log.Error("Failed to change password", "error", err)
+		return nil, err
+	}
+
+	return &LoginResult{Token: token, User: user}, nil
+}
@@ -0,0 +1,53 @@
+// internal/app/application/command/confirm_mfa_enrollment_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ConfirmMFAEnrollmentCommand represents a command to enable 2FA with a code from the enrolled authenticator
+type ConfirmMFAEnrollmentCommand struct {
+	UserID uuid.UUID `json:"-"`
+	Code   string    `json:"code" validate:"required,len=6,numeric"`
+}
+
+// RecoveryCodesResult represents the recovery codes shown to a user once
+type RecoveryCodesResult struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ConfirmMFAEnrollmentHandler handles the ConfirmMFAEnrollmentCommand
+type ConfirmMFAEnrollmentHandler struct {
+	mfaService *service.MFAService
+	logger     *logger.Logger
+}
+
+// NewConfirmMFAEnrollmentHandler creates a new ConfirmMFAEnrollmentHandler
+func NewConfirmMFAEnrollmentHandler(mfaService *service.MFAService, logger *logger.Logger) *ConfirmMFAEnrollmentHandler {
+	return &ConfirmMFAEnrollmentHandler{
+		mfaService: mfaService,
+		logger:     logger,
+	}
+}
+
+// Handle handles the ConfirmMFAEnrollmentCommand
+func (h *ConfirmMFAEnrollmentHandler) Handle(c echo.Context, cmd ConfirmMFAEnrollmentCommand) (_ *RecoveryCodesResult, err error) {
+	end := tracing.StartEcho(c, "ConfirmMFAEnrollmentHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Confirming MFA enrollment", "userID", cmd.UserID)
+
+	codes, err := h.mfaService.ConfirmEnrollment(c.Request().Context(), cmd.UserID, cmd.Code)
+	if err != nil {
+		log.Error("Failed to confirm MFA enrollment", "error", err)
+		return nil, err
+	}
+
+	return &RecoveryCodesResult{RecoveryCodes: codes}, nil
+}
@@ -0,0 +1,59 @@
+// internal/app/application/command/create_access_token_command.go
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// CreateAccessTokenCommand represents a command to create a personal access token
+type CreateAccessTokenCommand struct {
+	UserID    uuid.UUID          `json:"-"`
+	Name      string             `json:"name" validate:"required,max=100"`
+	Scopes    []model.TokenScope `json:"scopes" validate:"required,min=1,dive,oneof=todos:read todos:write"`
+	ExpiresAt *time.Time         `json:"expires_at"`
+}
+
+// CreatedAccessToken represents a new personal access token together with its raw value, which is only shown once
+type CreatedAccessToken struct {
+	*model.PersonalAccessToken
+	Token string `json:"token"`
+}
+
+// CreateAccessTokenHandler handles the CreateAccessTokenCommand
+type CreateAccessTokenHandler struct {
+	accessTokenService *service.AccessTokenService
+	logger             *logger.Logger
+}
+
+// NewCreateAccessTokenHandler creates a new CreateAccessTokenHandler
+func NewCreateAccessTokenHandler(accessTokenService *service.AccessTokenService, logger *logger.Logger) *CreateAccessTokenHandler {
+	return &CreateAccessTokenHandler{
+		accessTokenService: accessTokenService,
+		logger:             logger,
+	}
+}
+
+// Handle handles the CreateAccessTokenCommand
+func (h *CreateAccessTokenHandler) Handle(c echo.Context, cmd CreateAccessTokenCommand) (_ *CreatedAccessToken, err error) {
+	end := tracing.StartEcho(c, "CreateAccessTokenHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Creating personal access token", "userID", cmd.UserID, "name", cmd.Name)
+
+	token, raw, err := h.accessTokenService.CreateToken(c.Request().Context(), cmd.UserID, cmd.Name, cmd.Scopes, cmd.ExpiresAt)
+	if err != nil {
+		log.Error("Failed to create personal access token", "error", err)
+		return nil, err
+	}
+
+	return &CreatedAccessToken{PersonalAccessToken: token, Token: raw}, nil
+}
@@ -9,15 +9,17 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // CreateTodoCommand represents a command to create a todo
 type CreateTodoCommand struct {
-	UserID      uuid.UUID         `json:"-"`
-	Title       string            `json:"title" validate:"required,min=1,max=255"`
-	Description string            `json:"description"`
-	Priority    model.TodoPriority `json:"priority" validate:"required,oneof=low medium high"`
-	DueDate     *time.Time        `json:"due_date"`
+	UserID          uuid.UUID          `json:"-"`
+	Title           string             `json:"title" validate:"required,min=1,max=255"`
+	Description     string             `json:"description"`
+	Priority        model.TodoPriority `json:"priority" validate:"required,oneof=low medium high"`
+	DueDate         *time.Time         `json:"due_date"`
+	EstimateMinutes *int               `json:"estimate_minutes" validate:"omitempty,min=0"`
 }
 
 // CreateTodoHandler handles the CreateTodoCommand
@@ -35,7 +37,10 @@ func NewCreateTodoHandler(todoService *service.TodoService, logger *logger.Logge
 }
 
 // Handle handles the CreateTodoCommand
-func (h *CreateTodoHandler) Handle(c echo.Context, cmd CreateTodoCommand) (*model.Todo, error) {
+func (h *CreateTodoHandler) Handle(c echo.Context, cmd CreateTodoCommand) (_ *model.Todo, err error) {
+	end := tracing.StartEcho(c, "CreateTodoHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Creating todo", "userID", cmd.UserID, "title", cmd.Title)
@@ -47,6 +52,7 @@ func (h *CreateTodoHandler) Handle(c echo.Context, cmd CreateTodoCommand) (*mode
 		cmd.Description,
 		cmd.Priority,
 		cmd.DueDate,
+		cmd.EstimateMinutes,
 	)
 
 	if err != nil {
@@ -0,0 +1,48 @@
+// internal/app/application/command/delete_access_token_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// DeleteAccessTokenCommand represents a command to revoke a personal access token
+type DeleteAccessTokenCommand struct {
+	UserID  uuid.UUID `json:"-"`
+	TokenID uuid.UUID `json:"-"`
+}
+
+// DeleteAccessTokenHandler handles the DeleteAccessTokenCommand
+type DeleteAccessTokenHandler struct {
+	accessTokenService *service.AccessTokenService
+	logger             *logger.Logger
+}
+
+// NewDeleteAccessTokenHandler creates a new DeleteAccessTokenHandler
+func NewDeleteAccessTokenHandler(accessTokenService *service.AccessTokenService, logger *logger.Logger) *DeleteAccessTokenHandler {
+	return &DeleteAccessTokenHandler{
+		accessTokenService: accessTokenService,
+		logger:             logger,
+	}
+}
+
+// Handle handles the DeleteAccessTokenCommand
+func (h *DeleteAccessTokenHandler) Handle(c echo.Context, cmd DeleteAccessTokenCommand) (err error) {
+	end := tracing.StartEcho(c, "DeleteAccessTokenHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Revoking personal access token", "userID", cmd.UserID, "tokenID", cmd.TokenID)
+
+	err = h.accessTokenService.RevokeToken(c.Request().Context(), cmd.UserID, cmd.TokenID)
+	if err != nil {
+		log.Error("Failed to revoke personal access token", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// internal/app/application/command/delete_account_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// DeleteAccountCommand represents a command to schedule the deletion of the current user's account
+type DeleteAccountCommand struct {
+	UserID   uuid.UUID `json:"-"`
+	Password string    `json:"password" validate:"required"`
+}
+
+// DeleteAccountHandler handles the DeleteAccountCommand
+type DeleteAccountHandler struct {
+	profileService *service.ProfileService
+	logger         *logger.Logger
+}
+
+// NewDeleteAccountHandler creates a new DeleteAccountHandler
+func NewDeleteAccountHandler(profileService *service.ProfileService, logger *logger.Logger) *DeleteAccountHandler {
+	return &DeleteAccountHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the DeleteAccountCommand
+func (h *DeleteAccountHandler) Handle(c echo.Context, cmd DeleteAccountCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "DeleteAccountHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Scheduling account deletion", "userID", cmd.UserID)
+
+	user, err := h.profileService.ScheduleDeletion(c.Request().Context(), cmd.UserID, cmd.Password)
+	if err != nil {
+		log.Error("Failed to schedule account deletion", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
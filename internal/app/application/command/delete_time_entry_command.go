@@ -0,0 +1,48 @@
+// internal/app/application/command/delete_time_entry_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// DeleteTimeEntryCommand represents a command to delete a time entry
+type DeleteTimeEntryCommand struct {
+	UserID uuid.UUID `json:"-"`
+	ID     uuid.UUID `json:"-"`
+}
+
+// DeleteTimeEntryHandler handles the DeleteTimeEntryCommand
+type DeleteTimeEntryHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewDeleteTimeEntryHandler creates a new DeleteTimeEntryHandler
+func NewDeleteTimeEntryHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *DeleteTimeEntryHandler {
+	return &DeleteTimeEntryHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the DeleteTimeEntryCommand
+func (h *DeleteTimeEntryHandler) Handle(c echo.Context, cmd DeleteTimeEntryCommand) (err error) {
+	end := tracing.StartEcho(c, "DeleteTimeEntryHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Deleting time entry", "userID", cmd.UserID, "entryID", cmd.ID)
+
+	err = h.timeTrackingService.DeleteEntry(c.Request().Context(), cmd.UserID, cmd.ID)
+	if err != nil {
+		log.Error("Failed to delete time entry", "error", err)
+		return err
+	}
+
+	return nil
+}
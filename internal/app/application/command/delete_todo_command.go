@@ -6,6 +6,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // DeleteTodoCommand represents a command to delete a todo
@@ -29,12 +30,15 @@ func NewDeleteTodoHandler(todoService *service.TodoService, logger *logger.Logge
 }
 
 // Handle handles the DeleteTodoCommand
-func (h *DeleteTodoHandler) Handle(c echo.Context, cmd DeleteTodoCommand) error {
+func (h *DeleteTodoHandler) Handle(c echo.Context, cmd DeleteTodoCommand) (err error) {
+	end := tracing.StartEcho(c, "DeleteTodoHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Deleting todo", "userID", cmd.UserID, "todoID", cmd.ID)
 
-	err := h.todoService.DeleteTodo(c.Request().Context(), cmd.UserID, cmd.ID)
+	err = h.todoService.DeleteTodo(c.Request().Context(), cmd.UserID, cmd.ID)
 	if err != nil {
 		log.Error("Failed to delete todo", "error", err)
 		return err
@@ -0,0 +1,49 @@
+// internal/app/application/command/disable_mfa_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// DisableMFACommand represents a command to turn 2FA off for the current user
+type DisableMFACommand struct {
+	UserID   uuid.UUID `json:"-"`
+	Password string    `json:"password" validate:"required"`
+	Code     string    `json:"code" validate:"required,max=32"`
+}
+
+// DisableMFAHandler handles the DisableMFACommand
+type DisableMFAHandler struct {
+	mfaService *service.MFAService
+	logger     *logger.Logger
+}
+
+// NewDisableMFAHandler creates a new DisableMFAHandler
+func NewDisableMFAHandler(mfaService *service.MFAService, logger *logger.Logger) *DisableMFAHandler {
+	return &DisableMFAHandler{
+		mfaService: mfaService,
+		logger:     logger,
+	}
+}
+
+// Handle handles the DisableMFACommand
+func (h *DisableMFAHandler) Handle(c echo.Context, cmd DisableMFACommand) (err error) {
+	end := tracing.StartEcho(c, "DisableMFAHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Disabling MFA", "userID", cmd.UserID)
+
+	err = h.mfaService.Disable(c.Request().Context(), cmd.UserID, cmd.Password, cmd.Code)
+	if err != nil {
+		log.Error("Failed to disable MFA", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// internal/app/application/command/forgot_password_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ForgotPasswordCommand represents a command to request a password reset link
+type ForgotPasswordCommand struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ForgotPasswordHandler handles the ForgotPasswordCommand
+type ForgotPasswordHandler struct {
+	accountService *service.AccountService
+	logger         *logger.Logger
+}
+
+// NewForgotPasswordHandler creates a new ForgotPasswordHandler
+func NewForgotPasswordHandler(accountService *service.AccountService, logger *logger.Logger) *ForgotPasswordHandler {
+	return &ForgotPasswordHandler{
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the ForgotPasswordCommand
+func (h *ForgotPasswordHandler) Handle(c echo.Context, cmd ForgotPasswordCommand) (err error) {
+	end := tracing.StartEcho(c, "ForgotPasswordHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Requesting password reset")
+
+	err = h.accountService.ForgotPassword(c.Request().Context(), cmd.Email)
+	if err != nil {
+		log.Error("Failed to request password reset", "error", err)
+		return err
+	}
+
+	return nil
+}
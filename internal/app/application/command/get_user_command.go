@@ -5,6 +5,7 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // GetUserCommand represents a command to get the current user
@@ -27,7 +28,10 @@ func NewGetUserHandler(authService *service.AuthService, logger *logger.Logger)
 }
 
 // Handle handles the GetUserCommand
-func (h *GetUserHandler) Handle(c echo.Context, cmd GetUserCommand) (*model.User, error) {
+func (h *GetUserHandler) Handle(c echo.Context, cmd GetUserCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "GetUserHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Getting current user details")
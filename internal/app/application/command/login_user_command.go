@@ -6,18 +6,23 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // LoginUserCommand represents a command to login a user
 type LoginUserCommand struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email    string           `json:"email" validate:"required,email"`
+	Password string           `json:"password" validate:"required"`
+	Client   model.ClientInfo `json:"-"`
 }
 
-// LoginResult represents the result of a login
+// LoginResult represents the result of a login. When 2FA is enabled it only carries
+// an MFA challenge token, to be exchanged at /auth/mfa/verify.
 type LoginResult struct {
-	Token string `json:"token"`
-	User  *model.User `json:"user"`
+	Token       string      `json:"token,omitempty"`
+	User        *model.User `json:"user,omitempty"`
+	MFARequired bool        `json:"mfa_required"`
+	MFAToken    string      `json:"mfa_token,omitempty"`
 }
 
 // LoginUserHandler handles the LoginUserCommand
@@ -35,16 +40,23 @@ func NewLoginUserHandler(authService *service.AuthService, logger *logger.Logger
 }
 
 // Handle handles the LoginUserCommand
-func (h *LoginUserHandler) Handle(c echo.Context, cmd LoginUserCommand) (*LoginResult, error) {
+func (h *LoginUserHandler) Handle(c echo.Context, cmd LoginUserCommand) (_ *LoginResult, err error) {
+	end := tracing.StartEcho(c, "LoginUserHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Logging in user", "email", cmd.Email)
 
-	user, token, err := h.authService.Login(c.Request().Context(), cmd.Email, cmd.Password)
+	result, err := h.authService.Login(c.Request().Context(), cmd.Email, cmd.Password, cmd.Client)
 	if err != nil {
 		log.Error("Failed to login user", "error", err)
 		return nil, err
 	}
 
-	return &LoginResult{Token: token, User: user}, nil
+	if result.MFARequired() {
+		return &LoginResult{MFARequired: true, MFAToken: result.MFAToken}, nil
+	}
+
+	return &LoginResult{Token: result.Token, User: result.User}, nil
 }
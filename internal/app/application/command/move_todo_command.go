@@ -0,0 +1,60 @@
+// internal/app/application/command/move_todo_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// MoveTodoCommand represents a command to move a todo on the board
+type MoveTodoCommand struct {
+	UserID uuid.UUID        `json:"-"`
+	TodoID uuid.UUID        `json:"-"`
+	Status model.TodoStatus `json:"status" validate:"required,max=20"`
+	PrevID *uuid.UUID       `json:"prev_id"`
+	NextID *uuid.UUID       `json:"next_id"`
+}
+
+// MoveTodoHandler handles the MoveTodoCommand
+type MoveTodoHandler struct {
+	todoService *service.TodoService
+	logger      *logger.Logger
+}
+
+// NewMoveTodoHandler creates a new MoveTodoHandler
+func NewMoveTodoHandler(todoService *service.TodoService, logger *logger.Logger) *MoveTodoHandler {
+	return &MoveTodoHandler{
+		todoService: todoService,
+		logger:      logger,
+	}
+}
+
+// Handle handles the MoveTodoCommand
+func (h *MoveTodoHandler) Handle(c echo.Context, cmd MoveTodoCommand) (_ *model.Todo, err error) {
+	end := tracing.StartEcho(c, "MoveTodoHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Moving todo", "userID", cmd.UserID, "todoID", cmd.TodoID, "status", cmd.Status)
+
+	todo, err := h.todoService.MoveTodo(
+		c.Request().Context(),
+		cmd.UserID,
+		cmd.TodoID,
+		cmd.Status,
+		cmd.PrevID,
+		cmd.NextID,
+	)
+
+	if err != nil {
+		log.Error("Failed to move todo", "error", err)
+		return nil, err
+	}
+
+	return todo, nil
+}
@@ -0,0 +1,56 @@
+// internal/app/application/command/oauth_callback_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// OAuthCallbackCommand represents a command to complete a login with the code an identity provider redirected back with.
+// The bound state is the state hash the browser kept when it started the login.
+type OAuthCallbackCommand struct {
+	Provider   string           `json:"-" validate:"required"`
+	Code       string           `json:"code" validate:"required,max=2048"`
+	State      string           `json:"state" validate:"required,max=256"`
+	BoundState string           `json:"-"`
+	Client     model.ClientInfo `json:"-"`
+}
+
+// OAuthCallbackHandler handles the OAuthCallbackCommand
+type OAuthCallbackHandler struct {
+	oauthService *service.OAuthService
+	logger       *logger.Logger
+}
+
+// NewOAuthCallbackHandler creates a new OAuthCallbackHandler
+func NewOAuthCallbackHandler(oauthService *service.OAuthService, logger *logger.Logger) *OAuthCallbackHandler {
+	return &OAuthCallbackHandler{
+		oauthService: oauthService,
+		logger:       logger,
+	}
+}
+
+// Handle handles the OAuthCallbackCommand
+func (h *OAuthCallbackHandler) Handle(c echo.Context, cmd OAuthCallbackCommand) (_ *LoginResult, err error) {
+	end := tracing.StartEcho(c, "OAuthCallbackHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Completing OAuth login", "provider", cmd.Provider)
+
+	result, err := h.oauthService.Callback(c.Request().Context(), cmd.Provider, cmd.Code, cmd.State, cmd.BoundState, cmd.Client)
+	if err != nil {
+		log.Error("Failed to complete OAuth login", "error", err)
+		return nil, err
+	}
+
+	if result.MFARequired() {
+		return &LoginResult{MFARequired: true, MFAToken: result.MFAToken}, nil
+	}
+
+	return &LoginResult{Token: result.Token, User: result.User}, nil
+}
@@ -6,31 +6,37 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // RegisterUserCommand represents a command to register a user
 type RegisterUserCommand struct {
 	Fullname string `json:"fullname" validate:"required,min=3,max=50"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" validate:"required,password=Email Fullname"`
 }
 
 // RegisterUserHandler handles the RegisterUserCommand
 type RegisterUserHandler struct {
-	authService *service.AuthService
-	logger      *logger.Logger
+	authService    *service.AuthService
+	accountService *service.AccountService
+	logger         *logger.Logger
 }
 
 // NewRegisterUserHandler creates a new RegisterUserHandler
-func NewRegisterUserHandler(authService *service.AuthService, logger *logger.Logger) *RegisterUserHandler {
+func NewRegisterUserHandler(authService *service.AuthService, accountService *service.AccountService, logger *logger.Logger) *RegisterUserHandler {
 	return &RegisterUserHandler{
-		authService: authService,
-		logger:      logger,
+		authService:    authService,
+		accountService: accountService,
+		logger:         logger,
 	}
 }
 
 // Handle handles the RegisterUserCommand
-func (h *RegisterUserHandler) Handle(c echo.Context, cmd RegisterUserCommand) (*model.User, error) {
+func (h *RegisterUserHandler) Handle(c echo.Context, cmd RegisterUserCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "RegisterUserHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Registering user", "fullname", cmd.Fullname, "email", cmd.Email)
@@ -41,5 +47,10 @@ func (h *RegisterUserHandler) Handle(c echo.Context, cmd RegisterUserCommand) (*
 		return nil, err
 	}
 
+	// The user can ask for a new link later, so a failed email does not fail the registration
+	if err := h.accountService.SendVerificationEmail(c.Request().Context(), user); err != nil {
+		log.Error("Failed to send verification email", "error", err)
+	}
+
 	return user, nil
 }
@@ -0,0 +1,49 @@
+// internal/app/application/command/remove_blocker_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// RemoveBlockerCommand represents a command to remove a blocker from a todo
+type RemoveBlockerCommand struct {
+	UserID    uuid.UUID `json:"-"`
+	TodoID    uuid.UUID `json:"-"`
+	BlockerID uuid.UUID `json:"-"`
+}
+
+// RemoveBlockerHandler handles the RemoveBlockerCommand
+type RemoveBlockerHandler struct {
+	dependencyService *service.DependencyService
+	logger            *logger.Logger
+}
+
+// NewRemoveBlockerHandler creates a new RemoveBlockerHandler
+func NewRemoveBlockerHandler(dependencyService *service.DependencyService, logger *logger.Logger) *RemoveBlockerHandler {
+	return &RemoveBlockerHandler{
+		dependencyService: dependencyService,
+		logger:            logger,
+	}
+}
+
+// Handle handles the RemoveBlockerCommand
+func (h *RemoveBlockerHandler) Handle(c echo.Context, cmd RemoveBlockerCommand) (err error) {
+	end := tracing.StartEcho(c, "RemoveBlockerHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Removing blocker", "userID", cmd.UserID, "todoID", cmd.TodoID, "blockerID", cmd.BlockerID)
+
+	err = h.dependencyService.RemoveBlocker(c.Request().Context(), cmd.UserID, cmd.TodoID, cmd.BlockerID)
+	if err != nil {
+		log.Error("Failed to remove blocker", "error", err)
+		return err
+	}
+
+	return nil
+}
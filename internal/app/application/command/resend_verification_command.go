@@ -0,0 +1,47 @@
+// internal/app/application/command/resend_verification_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ResendVerificationCommand represents a command to send a new email verification link
+type ResendVerificationCommand struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// ResendVerificationHandler handles the ResendVerificationCommand
+type ResendVerificationHandler struct {
+	accountService *service.AccountService
+	logger         *logger.Logger
+}
+
+// NewResendVerificationHandler creates a new ResendVerificationHandler
+func NewResendVerificationHandler(accountService *service.AccountService, logger *logger.Logger) *ResendVerificationHandler {
+	return &ResendVerificationHandler{
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the ResendVerificationCommand
+func (h *ResendVerificationHandler) Handle(c echo.Context, cmd ResendVerificationCommand) (err error) {
+	end := tracing.StartEcho(c, "ResendVerificationHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Resending verification email", "userID", cmd.UserID)
+
+	err = h.accountService.ResendVerificationEmail(c.Request().Context(), cmd.UserID)
+	if err != nil {
+		log.Error("Failed to resend verification email", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// internal/app/application/command/reset_password_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ResetPasswordCommand represents a command to set a new password with a reset token
+type ResetPasswordCommand struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+// ResetPasswordHandler handles the ResetPasswordCommand
+type ResetPasswordHandler struct {
+	accountService *service.AccountService
+	logger         *logger.Logger
+}
+
+// NewResetPasswordHandler creates a new ResetPasswordHandler
+func NewResetPasswordHandler(accountService *service.AccountService, logger *logger.Logger) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the ResetPasswordCommand
+func (h *ResetPasswordHandler) Handle(c echo.Context, cmd ResetPasswordCommand) (err error) {
+	end := tracing.StartEcho(c, "ResetPasswordHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Resetting password")
+
+	err = h.accountService.ResetPassword(c.Request().Context(), cmd.Token, cmd.Password)
+	if err != nil {
+		log.Error("Failed to reset password", "error", err)
+		return err
+	}
+
+	return nil
+}
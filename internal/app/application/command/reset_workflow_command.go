@@ -0,0 +1,48 @@
+// internal/app/application/command/reset_workflow_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ResetWorkflowCommand represents a command to restore the default status workflow
+type ResetWorkflowCommand struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// ResetWorkflowHandler handles the ResetWorkflowCommand
+type ResetWorkflowHandler struct {
+	workflowService *service.WorkflowService
+	logger          *logger.Logger
+}
+
+// NewResetWorkflowHandler creates a new ResetWorkflowHandler
+func NewResetWorkflowHandler(workflowService *service.WorkflowService, logger *logger.Logger) *ResetWorkflowHandler {
+	return &ResetWorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// Handle handles the ResetWorkflowCommand
+func (h *ResetWorkflowHandler) Handle(c echo.Context, cmd ResetWorkflowCommand) (_ *model.Workflow, err error) {
+	end := tracing.StartEcho(c, "ResetWorkflowHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Resetting workflow", "userID", cmd.UserID)
+
+	workflow, err := h.workflowService.ResetWorkflow(c.Request().Context(), cmd.UserID)
+	if err != nil {
+		log.Error("Failed to reset workflow", "error", err)
+		return nil, err
+	}
+
+	return workflow, nil
+}
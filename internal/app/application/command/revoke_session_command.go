@@ -0,0 +1,48 @@
+// internal/app/application/command/revoke_session_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// RevokeSessionCommand represents a command to sign one of the current user's sessions out
+type RevokeSessionCommand struct {
+	UserID    uuid.UUID `json:"-"`
+	SessionID uuid.UUID `json:"-"`
+}
+
+// RevokeSessionHandler handles the RevokeSessionCommand
+type RevokeSessionHandler struct {
+	sessionService *service.SessionService
+	logger         *logger.Logger
+}
+
+// NewRevokeSessionHandler creates a new RevokeSessionHandler
+func NewRevokeSessionHandler(sessionService *service.SessionService, logger *logger.Logger) *RevokeSessionHandler {
+	return &RevokeSessionHandler{
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the RevokeSessionCommand
+func (h *RevokeSessionHandler) Handle(c echo.Context, cmd RevokeSessionCommand) (err error) {
+	end := tracing.StartEcho(c, "RevokeSessionHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Revoking session", "userID", cmd.UserID, "sessionID", cmd.SessionID)
+
+	err = h.sessionService.RevokeSession(c.Request().Context(), cmd.UserID, cmd.SessionID)
+	if err != nil {
+		log.Error("Failed to revoke session", "error", err)
+		return err
+	}
+
+	return nil
+}
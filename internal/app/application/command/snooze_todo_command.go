@@ -0,0 +1,53 @@
+// internal/app/application/command/snooze_todo_command.go
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// SnoozeTodoCommand represents a command to snooze a todo
+type SnoozeTodoCommand struct {
+	UserID uuid.UUID          `json:"-"`
+	TodoID uuid.UUID          `json:"-"`
+	Preset model.SnoozePreset `json:"preset" validate:"required,oneof=tomorrow next_week custom"`
+	Until  *time.Time         `json:"until" validate:"required_if=Preset custom"`
+}
+
+// SnoozeTodoHandler handles the SnoozeTodoCommand
+type SnoozeTodoHandler struct {
+	todoService *service.TodoService
+	logger      *logger.Logger
+}
+
+// NewSnoozeTodoHandler creates a new SnoozeTodoHandler
+func NewSnoozeTodoHandler(todoService *service.TodoService, logger *logger.Logger) *SnoozeTodoHandler {
+	return &SnoozeTodoHandler{
+		todoService: todoService,
+		logger:      logger,
+	}
+}
+
+// Handle handles the SnoozeTodoCommand
+func (h *SnoozeTodoHandler) Handle(c echo.Context, cmd SnoozeTodoCommand) (_ *model.Todo, err error) {
+	end := tracing.StartEcho(c, "SnoozeTodoHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Snoozing todo", "userID", cmd.UserID, "todoID", cmd.TodoID, "preset", cmd.Preset)
+
+	todo, err := h.todoService.SnoozeTodo(c.Request().Context(), cmd.UserID, cmd.TodoID, cmd.Preset, cmd.Until)
+	if err != nil {
+		log.Error("Failed to snooze todo", "error", err)
+		return nil, err
+	}
+
+	return todo, nil
+}
@@ -0,0 +1,47 @@
+// internal/app/application/command/start_mfa_enrollment_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// StartMFAEnrollmentCommand represents a command to start enrolling the current user in 2FA
+type StartMFAEnrollmentCommand struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// StartMFAEnrollmentHandler handles the StartMFAEnrollmentCommand
+type StartMFAEnrollmentHandler struct {
+	mfaService *service.MFAService
+	logger     *logger.Logger
+}
+
+// NewStartMFAEnrollmentHandler creates a new StartMFAEnrollmentHandler
+func NewStartMFAEnrollmentHandler(mfaService *service.MFAService, logger *logger.Logger) *StartMFAEnrollmentHandler {
+	return &StartMFAEnrollmentHandler{
+		mfaService: mfaService,
+		logger:     logger,
+	}
+}
+
+// Handle handles the StartMFAEnrollmentCommand
+func (h *StartMFAEnrollmentHandler) Handle(c echo.Context, cmd StartMFAEnrollmentCommand) (_ *service.MFAEnrollment, err error) {
+	end := tracing.StartEcho(c, "StartMFAEnrollmentHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Starting MFA enrollment", "userID", cmd.UserID)
+
+	enrollment, err := h.mfaService.StartEnrollment(c.Request().Context(), cmd.UserID)
+	if err != nil {
+		log.Error("Failed to start MFA enrollment", "error", err)
+		return nil, err
+	}
+
+	return enrollment, nil
+}
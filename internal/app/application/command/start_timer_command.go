@@ -0,0 +1,50 @@
+// internal/app/application/command/start_timer_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// StartTimerCommand represents a command to start a timer on a todo
+type StartTimerCommand struct {
+	UserID uuid.UUID `json:"-"`
+	TodoID uuid.UUID `json:"-"`
+	Note   string    `json:"note" validate:"max=500"`
+}
+
+// StartTimerHandler handles the StartTimerCommand
+type StartTimerHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewStartTimerHandler creates a new StartTimerHandler
+func NewStartTimerHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *StartTimerHandler {
+	return &StartTimerHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the StartTimerCommand
+func (h *StartTimerHandler) Handle(c echo.Context, cmd StartTimerCommand) (_ *model.TimeEntry, err error) {
+	end := tracing.StartEcho(c, "StartTimerHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Starting timer", "userID", cmd.UserID, "todoID", cmd.TodoID)
+
+	entry, err := h.timeTrackingService.StartTimer(c.Request().Context(), cmd.UserID, cmd.TodoID, cmd.Note)
+	if err != nil {
+		log.Error("Failed to start timer", "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
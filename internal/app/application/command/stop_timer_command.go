@@ -0,0 +1,48 @@
+// internal/app/application/command/stop_timer_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// StopTimerCommand represents a command to stop the user's running timer
+type StopTimerCommand struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// StopTimerHandler handles the StopTimerCommand
+type StopTimerHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewStopTimerHandler creates a new StopTimerHandler
+func NewStopTimerHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *StopTimerHandler {
+	return &StopTimerHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the StopTimerCommand
+func (h *StopTimerHandler) Handle(c echo.Context, cmd StopTimerCommand) (_ *model.TimeEntry, err error) {
+	end := tracing.StartEcho(c, "StopTimerHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Stopping timer", "userID", cmd.UserID)
+
+	entry, err := h.timeTrackingService.StopTimer(c.Request().Context(), cmd.UserID)
+	if err != nil {
+		log.Error("Failed to stop timer", "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
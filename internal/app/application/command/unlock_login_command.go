@@ -0,0 +1,46 @@
+// internal/app/application/command/unlock_login_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// UnlockLoginCommand represents a command to lift the login lockout of an email or client IP
+type UnlockLoginCommand struct {
+	Email string `json:"email" validate:"required_without=IP,omitempty,email"`
+	IP    string `json:"ip" validate:"required_without=Email,omitempty,ip"`
+}
+
+// UnlockLoginHandler handles the UnlockLoginCommand
+type UnlockLoginHandler struct {
+	loginThrottleService *service.LoginThrottleService
+	logger               *logger.Logger
+}
+
+// NewUnlockLoginHandler creates a new UnlockLoginHandler
+func NewUnlockLoginHandler(loginThrottleService *service.LoginThrottleService, logger *logger.Logger) *UnlockLoginHandler {
+	return &UnlockLoginHandler{
+		loginThrottleService: loginThrottleService,
+		logger:               logger,
+	}
+}
+
+// Handle handles the UnlockLoginCommand
+func (h *UnlockLoginHandler) Handle(c echo.Context, cmd UnlockLoginCommand) (err error) {
+	end := tracing.StartEcho(c, "UnlockLoginHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Unlocking login", "email", cmd.Email, "ip", cmd.IP)
+
+	if err := h.loginThrottleService.Unlock(c.Request().Context(), cmd.Email, cmd.IP); err != nil {
+		log.Error("Failed to unlock login", "error", err)
+		return err
+	}
+
+	return nil
+}
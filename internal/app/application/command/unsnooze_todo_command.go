@@ -0,0 +1,49 @@
+// internal/app/application/command/unsnooze_todo_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// UnsnoozeTodoCommand represents a command to make a snoozed todo active again
+type UnsnoozeTodoCommand struct {
+	UserID uuid.UUID `json:"-"`
+	TodoID uuid.UUID `json:"-"`
+}
+
+// UnsnoozeTodoHandler handles the UnsnoozeTodoCommand
+type UnsnoozeTodoHandler struct {
+	todoService *service.TodoService
+	logger      *logger.Logger
+}
+
+// NewUnsnoozeTodoHandler creates a new UnsnoozeTodoHandler
+func NewUnsnoozeTodoHandler(todoService *service.TodoService, logger *logger.Logger) *UnsnoozeTodoHandler {
+	return &UnsnoozeTodoHandler{
+		todoService: todoService,
+		logger:      logger,
+	}
+}
+
+// Handle handles the UnsnoozeTodoCommand
+func (h *UnsnoozeTodoHandler) Handle(c echo.Context, cmd UnsnoozeTodoCommand) (_ *model.Todo, err error) {
+	end := tracing.StartEcho(c, "UnsnoozeTodoHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Unsnoozing todo", "userID", cmd.UserID, "todoID", cmd.TodoID)
+
+	todo, err := h.todoService.UnsnoozeTodo(c.Request().Context(), cmd.UserID, cmd.TodoID)
+	if err != nil {
+		log.Error("Failed to unsnooze todo", "error", err)
+		return nil, err
+	}
+
+	return todo, nil
+}
@@ -0,0 +1,50 @@
+// internal/app/application/command/update_preferences_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// UpdatePreferencesCommand represents a command to update the current user's preferences
+type UpdatePreferencesCommand struct {
+	UserID   uuid.UUID `json:"-"`
+	Timezone *string   `json:"timezone" validate:"omitempty,max=64"`
+	Locale   *string   `json:"locale" validate:"omitempty,max=16"`
+}
+
+// UpdatePreferencesHandler handles the UpdatePreferencesCommand
+type UpdatePreferencesHandler struct {
+	profileService *service.ProfileService
+	logger         *logger.Logger
+}
+
+// NewUpdatePreferencesHandler creates a new UpdatePreferencesHandler
+func NewUpdatePreferencesHandler(profileService *service.ProfileService, logger *logger.Logger) *UpdatePreferencesHandler {
+	return &UpdatePreferencesHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the UpdatePreferencesCommand
+func (h *UpdatePreferencesHandler) Handle(c echo.Context, cmd UpdatePreferencesCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "UpdatePreferencesHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Updating preferences", "userID", cmd.UserID)
+
+	user, err := h.profileService.UpdatePreferences(c.Request().Context(), cmd.UserID, cmd.Timezone, cmd.Locale)
+	if err != nil {
+		log.Error("Failed to update preferences", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,52 @@
+// internal/app/application/command/update_profile_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// UpdateProfileCommand represents a command to update the current user's name and email.
+// Changing the email has to be confirmed with the current password.
+type UpdateProfileCommand struct {
+	UserID          uuid.UUID `json:"-"`
+	Fullname        *string   `json:"fullname" validate:"omitempty,min=3,max=50"`
+	Email           *string   `json:"email" validate:"omitempty,email"`
+	CurrentPassword string    `json:"current_password"`
+}
+
+// UpdateProfileHandler handles the UpdateProfileCommand
+type UpdateProfileHandler struct {
+	profileService *service.ProfileService
+	logger         *logger.Logger
+}
+
+// NewUpdateProfileHandler creates a new UpdateProfileHandler
+func NewUpdateProfileHandler(profileService *service.ProfileService, logger *logger.Logger) *UpdateProfileHandler {
+	return &UpdateProfileHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the UpdateProfileCommand
+func (h *UpdateProfileHandler) Handle(c echo.Context, cmd UpdateProfileCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "UpdateProfileHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Updating profile", "userID", cmd.UserID)
+
+	user, err := h.profileService.UpdateProfile(c.Request().Context(), cmd.UserID, cmd.Fullname, cmd.Email, cmd.CurrentPassword)
+	if err != nil {
+		log.Error("Failed to update profile", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
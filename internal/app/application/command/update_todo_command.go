@@ -9,17 +9,19 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // UpdateTodoCommand represents a command to update a todo
 type UpdateTodoCommand struct {
-	UserID      uuid.UUID          `json:"-"`
-	TodoID      uuid.UUID          `json:"-"`
-	Title       *string            `json:"title" validate:"omitempty,min=1,max=255"`
-	Description *string            `json:"description"`
-	Status      *model.TodoStatus  `json:"status" validate:"omitempty,oneof=pending in_progress completed cancelled"`
-	Priority    *model.TodoPriority `json:"priority" validate:"omitempty,oneof=low medium high"`
-	DueDate     *time.Time         `json:"due_date"`
+	UserID          uuid.UUID           `json:"-"`
+	TodoID          uuid.UUID           `json:"-"`
+	Title           *string             `json:"title" validate:"omitempty,min=1,max=255"`
+	Description     *string             `json:"description"`
+	Status          *model.TodoStatus   `json:"status" validate:"omitempty,max=20"`
+	Priority        *model.TodoPriority `json:"priority" validate:"omitempty,oneof=low medium high"`
+	DueDate         *time.Time          `json:"due_date"`
+	EstimateMinutes *int                `json:"estimate_minutes" validate:"omitempty,min=0"`
 }
 
 // UpdateTodoHandler handles the UpdateTodoCommand
@@ -37,7 +39,10 @@ func NewUpdateTodoHandler(todoService *service.TodoService, logger *logger.Logge
 }
 
 // Handle handles the UpdateTodoCommand
-func (h *UpdateTodoHandler) Handle(c echo.Context, cmd UpdateTodoCommand) (*model.Todo, error) {
+func (h *UpdateTodoHandler) Handle(c echo.Context, cmd UpdateTodoCommand) (_ *model.Todo, err error) {
+	end := tracing.StartEcho(c, "UpdateTodoHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Updating todo", "userID", cmd.UserID, "todoID", cmd.TodoID)
@@ -51,6 +56,7 @@ func (h *UpdateTodoHandler) Handle(c echo.Context, cmd UpdateTodoCommand) (*mode
 		cmd.Status,
 		cmd.Priority,
 		cmd.DueDate,
+		cmd.EstimateMinutes,
 	)
 
 	if err != nil {
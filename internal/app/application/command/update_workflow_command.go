@@ -0,0 +1,60 @@
+// internal/app/application/command/update_workflow_command.go
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// UpdateWorkflowCommand represents a command to replace the user's status workflow
+type UpdateWorkflowCommand struct {
+	UserID        uuid.UUID                               `json:"-"`
+	Statuses      []model.TodoStatus                      `json:"statuses" validate:"required,min=1,dive,required,max=20"`
+	InitialStatus model.TodoStatus                        `json:"initial_status" validate:"required,max=20"`
+	DoneStatuses  []model.TodoStatus                      `json:"done_statuses" validate:"dive,required,max=20"`
+	Transitions   map[model.TodoStatus][]model.TodoStatus `json:"transitions"`
+}
+
+// UpdateWorkflowHandler handles the UpdateWorkflowCommand
+type UpdateWorkflowHandler struct {
+	workflowService *service.WorkflowService
+	logger          *logger.Logger
+}
+
+// NewUpdateWorkflowHandler creates a new UpdateWorkflowHandler
+func NewUpdateWorkflowHandler(workflowService *service.WorkflowService, logger *logger.Logger) *UpdateWorkflowHandler {
+	return &UpdateWorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// Handle handles the UpdateWorkflowCommand
+func (h *UpdateWorkflowHandler) Handle(c echo.Context, cmd UpdateWorkflowCommand) (_ *model.Workflow, err error) {
+	end := tracing.StartEcho(c, "UpdateWorkflowHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Updating workflow", "userID", cmd.UserID)
+
+	workflow, err := h.workflowService.UpdateWorkflow(
+		c.Request().Context(),
+		cmd.UserID,
+		cmd.Statuses,
+		cmd.InitialStatus,
+		cmd.DoneStatuses,
+		cmd.Transitions,
+	)
+
+	if err != nil {
+		log.Error("Failed to update workflow", "error", err)
+		return nil, err
+	}
+
+	return workflow, nil
+}
@@ -0,0 +1,47 @@
+// internal/app/application/command/verify_email_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// VerifyEmailCommand represents a command to verify an email with a verification token
+type VerifyEmailCommand struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// VerifyEmailHandler handles the VerifyEmailCommand
+type VerifyEmailHandler struct {
+	accountService *service.AccountService
+	logger         *logger.Logger
+}
+
+// NewVerifyEmailHandler creates a new VerifyEmailHandler
+func NewVerifyEmailHandler(accountService *service.AccountService, logger *logger.Logger) *VerifyEmailHandler {
+	return &VerifyEmailHandler{
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the VerifyEmailCommand
+func (h *VerifyEmailHandler) Handle(c echo.Context, cmd VerifyEmailCommand) (_ *model.User, err error) {
+	end := tracing.StartEcho(c, "VerifyEmailHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Verifying email")
+
+	user, err := h.accountService.VerifyEmail(c.Request().Context(), cmd.Token)
+	if err != nil {
+		log.Error("Failed to verify email", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,49 @@
+// internal/app/application/command/verify_mfa_command.go
+package command
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// VerifyMFACommand represents a command to complete a login with a TOTP or recovery code
+type VerifyMFACommand struct {
+	MFAToken string           `json:"mfa_token" validate:"required"`
+	Code     string           `json:"code" validate:"required,max=32"`
+	Client   model.ClientInfo `json:"-"`
+}
+
+// VerifyMFAHandler handles the VerifyMFACommand
+type VerifyMFAHandler struct {
+	mfaService *service.MFAService
+	logger     *logger.Logger
+}
+
+// NewVerifyMFAHandler creates a new VerifyMFAHandler
+func NewVerifyMFAHandler(mfaService *service.MFAService, logger *logger.Logger) *VerifyMFAHandler {
+	return &VerifyMFAHandler{
+		mfaService: mfaService,
+		logger:     logger,
+	}
+}
+
+// Handle handles the VerifyMFACommand
+func (h *VerifyMFAHandler) Handle(c echo.Context, cmd VerifyMFACommand) (_ *LoginResult, err error) {
+	end := tracing.StartEcho(c, "VerifyMFAHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Verifying MFA login")
+
+	user, token, err := h.mfaService.VerifyLogin(c.Request().Context(), cmd.MFAToken, cmd.Code, cmd.Client)
+	if err != nil {
+		log.Error("Failed to verify MFA login", "error", err)
+		return nil, err
+	}
+
+	return &LoginResult{Token: token, User: user}, nil
+}
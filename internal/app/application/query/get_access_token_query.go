@@ -0,0 +1,49 @@
+// internal/app/application/query/get_access_token_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetAccessTokenQuery represents a query to get one of the current user's personal access tokens
+type GetAccessTokenQuery struct {
+	UserID  uuid.UUID `json:"-"`
+	TokenID uuid.UUID `json:"-"`
+}
+
+// GetAccessTokenHandler handles the GetAccessTokenQuery
+type GetAccessTokenHandler struct {
+	accessTokenService *service.AccessTokenService
+	logger             *logger.Logger
+}
+
+// NewGetAccessTokenHandler creates a new GetAccessTokenHandler
+func NewGetAccessTokenHandler(accessTokenService *service.AccessTokenService, logger *logger.Logger) *GetAccessTokenHandler {
+	return &GetAccessTokenHandler{
+		accessTokenService: accessTokenService,
+		logger:             logger,
+	}
+}
+
+// Handle handles the GetAccessTokenQuery
+func (h *GetAccessTokenHandler) Handle(c echo.Context, query GetAccessTokenQuery) (_ *model.PersonalAccessToken, err error) {
+	end := tracing.StartEcho(c, "GetAccessTokenHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Getting personal access token", "userID", query.UserID, "tokenID", query.TokenID)
+
+	token, err := h.accessTokenService.GetToken(c.Request().Context(), query.UserID, query.TokenID)
+	if err != nil {
+		log.Error("Failed to get personal access token", "error", err)
+		return nil, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,53 @@
+// internal/app/application/query/get_board_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetBoardQuery represents a query to get the todo board
+type GetBoardQuery struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// BoardResult represents the todo board grouped by status
+type BoardResult struct {
+	Columns []*model.BoardColumn `json:"columns"`
+}
+
+// GetBoardHandler handles the GetBoardQuery
+type GetBoardHandler struct {
+	todoService *service.TodoService
+	logger      *logger.Logger
+}
+
+// NewGetBoardHandler creates a new GetBoardHandler
+func NewGetBoardHandler(todoService *service.TodoService, logger *logger.Logger) *GetBoardHandler {
+	return &GetBoardHandler{
+		todoService: todoService,
+		logger:      logger,
+	}
+}
+
+// Handle handles the GetBoardQuery
+func (h *GetBoardHandler) Handle(c echo.Context, query GetBoardQuery) (_ *BoardResult, err error) {
+	end := tracing.StartEcho(c, "GetBoardHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Getting todo board", "userID", query.UserID)
+
+	columns, err := h.todoService.GetBoard(c.Request().Context(), query.UserID)
+	if err != nil {
+		log.Error("Failed to get todo board", "error", err)
+		return nil, err
+	}
+
+	return &BoardResult{Columns: columns}, nil
+}
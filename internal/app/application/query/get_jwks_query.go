@@ -0,0 +1,40 @@
+// internal/app/application/query/get_jwks_query.go
+package query
+
+import (
+	"github.com/go-jose/go-jose/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetJWKSQuery represents a query for the public keys tokens can be verified with
+type GetJWKSQuery struct{}
+
+// KeyPublisher provides the public keys tokens can be verified with, such as the token service
+// of the infrastructure layer
+type KeyPublisher interface {
+	PublicKeys() jose.JSONWebKeySet
+}
+
+// GetJWKSHandler handles the GetJWKSQuery
+type GetJWKSHandler struct {
+	keys   KeyPublisher
+	logger *logger.Logger
+}
+
+// NewGetJWKSHandler creates a new GetJWKSHandler
+func NewGetJWKSHandler(keys KeyPublisher, logger *logger.Logger) *GetJWKSHandler {
+	return &GetJWKSHandler{
+		keys:   keys,
+		logger: logger,
+	}
+}
+
+// Handle handles the GetJWKSQuery
+func (h *GetJWKSHandler) Handle(c echo.Context, query GetJWKSQuery) (_ jose.JSONWebKeySet, err error) {
+	end := tracing.StartEcho(c, "GetJWKSHandler.Handle")
+	defer func() { end(err) }()
+
+	return h.keys.PublicKeys(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // GetOverdueTodosQuery represents a query to get overdue todos
@@ -29,7 +30,10 @@ func NewGetOverdueTodosHandler(todoService *service.TodoService, logger *logger.
 }
 
 // Handle handles the GetOverdueTodosQuery
-func (h *GetOverdueTodosHandler) Handle(c echo.Context, query GetOverdueTodosQuery) ([]*model.Todo, error) {
+func (h *GetOverdueTodosHandler) Handle(c echo.Context, query GetOverdueTodosQuery) (_ []*model.Todo, err error) {
+	end := tracing.StartEcho(c, "GetOverdueTodosHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Getting overdue todos", "userID", query.UserID)
@@ -0,0 +1,48 @@
+// internal/app/application/query/get_running_timer_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetRunningTimerQuery represents a query to get the user's running timer
+type GetRunningTimerQuery struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// GetRunningTimerHandler handles the GetRunningTimerQuery
+type GetRunningTimerHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewGetRunningTimerHandler creates a new GetRunningTimerHandler
+func NewGetRunningTimerHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *GetRunningTimerHandler {
+	return &GetRunningTimerHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the GetRunningTimerQuery
+func (h *GetRunningTimerHandler) Handle(c echo.Context, query GetRunningTimerQuery) (_ *model.TimeEntry, err error) {
+	end := tracing.StartEcho(c, "GetRunningTimerHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Getting running timer", "userID", query.UserID)
+
+	entry, err := h.timeTrackingService.GetRunningTimer(c.Request().Context(), query.UserID)
+	if err != nil {
+		log.Error("Failed to get running timer", "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,52 @@
+// internal/app/application/query/get_timesheet_query.go
+package query
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetTimesheetQuery represents a query to get the user's timesheet for a date range
+type GetTimesheetQuery struct {
+	UserID uuid.UUID `json:"-"`
+	From   time.Time `json:"-"`
+	To     time.Time `json:"-"`
+}
+
+// GetTimesheetHandler handles the GetTimesheetQuery
+type GetTimesheetHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewGetTimesheetHandler creates a new GetTimesheetHandler
+func NewGetTimesheetHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *GetTimesheetHandler {
+	return &GetTimesheetHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the GetTimesheetQuery
+func (h *GetTimesheetHandler) Handle(c echo.Context, query GetTimesheetQuery) (_ []*model.TimesheetRow, err error) {
+	end := tracing.StartEcho(c, "GetTimesheetHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Getting timesheet", "userID", query.UserID, "from", query.From, "to", query.To)
+
+	rows, err := h.timeTrackingService.GetTimesheet(c.Request().Context(), query.UserID, query.From, query.To)
+	if err != nil {
+		log.Error("Failed to get timesheet", "error", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // GetTodoQuery represents a query to get a todo
@@ -30,7 +31,10 @@ func NewGetTodoHandler(todoService *service.TodoService, logger *logger.Logger)
 }
 
 // Handle handles the GetTodoQuery
-func (h *GetTodoHandler) Handle(c echo.Context, query GetTodoQuery) (*model.Todo, error) {
+func (h *GetTodoHandler) Handle(c echo.Context, query GetTodoQuery) (_ *model.Todo, err error) {
+	end := tracing.StartEcho(c, "GetTodoHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Getting todo", "userID", query.UserID, "todoID", query.TodoID)
@@ -0,0 +1,49 @@
+// internal/app/application/query/get_todo_time_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetTodoTimeQuery represents a query to get the time tracked against a todo
+type GetTodoTimeQuery struct {
+	UserID uuid.UUID `json:"-"`
+	TodoID uuid.UUID `json:"-"`
+}
+
+// GetTodoTimeHandler handles the GetTodoTimeQuery
+type GetTodoTimeHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewGetTodoTimeHandler creates a new GetTodoTimeHandler
+func NewGetTodoTimeHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *GetTodoTimeHandler {
+	return &GetTodoTimeHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the GetTodoTimeQuery
+func (h *GetTodoTimeHandler) Handle(c echo.Context, query GetTodoTimeQuery) (_ *model.TodoTimeSummary, err error) {
+	end := tracing.StartEcho(c, "GetTodoTimeHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Getting todo time", "userID", query.UserID, "todoID", query.TodoID)
+
+	summary, err := h.timeTrackingService.GetTodoTime(c.Request().Context(), query.UserID, query.TodoID)
+	if err != nil {
+		log.Error("Failed to get todo time", "error", err)
+		return nil, err
+	}
+
+	return summary, nil
+}
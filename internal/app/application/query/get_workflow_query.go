@@ -0,0 +1,48 @@
+// internal/app/application/query/get_workflow_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// GetWorkflowQuery represents a query to get the user's status workflow
+type GetWorkflowQuery struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// GetWorkflowHandler handles the GetWorkflowQuery
+type GetWorkflowHandler struct {
+	workflowService *service.WorkflowService
+	logger          *logger.Logger
+}
+
+// NewGetWorkflowHandler creates a new GetWorkflowHandler
+func NewGetWorkflowHandler(workflowService *service.WorkflowService, logger *logger.Logger) *GetWorkflowHandler {
+	return &GetWorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// Handle handles the GetWorkflowQuery
+func (h *GetWorkflowHandler) Handle(c echo.Context, query GetWorkflowQuery) (_ *model.Workflow, err error) {
+	end := tracing.StartEcho(c, "GetWorkflowHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Getting workflow", "userID", query.UserID)
+
+	workflow, err := h.workflowService.GetWorkflow(c.Request().Context(), query.UserID)
+	if err != nil {
+		log.Error("Failed to get workflow", "error", err)
+		return nil, err
+	}
+
+	return workflow, nil
+}
@@ -0,0 +1,48 @@
+// internal/app/application/query/list_access_tokens_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ListAccessTokensQuery represents a query to list the current user's personal access tokens
+type ListAccessTokensQuery struct {
+	UserID uuid.UUID `json:"-"`
+}
+
+// ListAccessTokensHandler handles the ListAccessTokensQuery
+type ListAccessTokensHandler struct {
+	accessTokenService *service.AccessTokenService
+	logger             *logger.Logger
+}
+
+// NewListAccessTokensHandler creates a new ListAccessTokensHandler
+func NewListAccessTokensHandler(accessTokenService *service.AccessTokenService, logger *logger.Logger) *ListAccessTokensHandler {
+	return &ListAccessTokensHandler{
+		accessTokenService: accessTokenService,
+		logger:             logger,
+	}
+}
+
+// Handle handles the ListAccessTokensQuery
+func (h *ListAccessTokensHandler) Handle(c echo.Context, query ListAccessTokensQuery) (_ []*model.PersonalAccessToken, err error) {
+	end := tracing.StartEcho(c, "ListAccessTokensHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Listing personal access tokens", "userID", query.UserID)
+
+	tokens, err := h.accessTokenService.ListTokens(c.Request().Context(), query.UserID)
+	if err != nil {
+		log.Error("Failed to list personal access tokens", "error", err)
+		return nil, err
+	}
+
+	return tokens, nil
+}
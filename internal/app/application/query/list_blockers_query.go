@@ -0,0 +1,49 @@
+// internal/app/application/query/list_blockers_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ListBlockersQuery represents a query to list the blockers of a todo
+type ListBlockersQuery struct {
+	UserID uuid.UUID `json:"-"`
+	TodoID uuid.UUID `json:"-"`
+}
+
+// ListBlockersHandler handles the ListBlockersQuery
+type ListBlockersHandler struct {
+	dependencyService *service.DependencyService
+	logger            *logger.Logger
+}
+
+// NewListBlockersHandler creates a new ListBlockersHandler
+func NewListBlockersHandler(dependencyService *service.DependencyService, logger *logger.Logger) *ListBlockersHandler {
+	return &ListBlockersHandler{
+		dependencyService: dependencyService,
+		logger:            logger,
+	}
+}
+
+// Handle handles the ListBlockersQuery
+func (h *ListBlockersHandler) Handle(c echo.Context, query ListBlockersQuery) (_ []*model.Todo, err error) {
+	end := tracing.StartEcho(c, "ListBlockersHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Listing blockers", "userID", query.UserID, "todoID", query.TodoID)
+
+	blockers, err := h.dependencyService.ListBlockers(c.Request().Context(), query.UserID, query.TodoID)
+	if err != nil {
+		log.Error("Failed to list blockers", "error", err)
+		return nil, err
+	}
+
+	return blockers, nil
+}
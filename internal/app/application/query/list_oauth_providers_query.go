@@ -0,0 +1,34 @@
+// internal/app/application/query/list_oauth_providers_query.go
+package query
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ListOAuthProvidersQuery represents a query to list the identity providers users can log in with
+type ListOAuthProvidersQuery struct{}
+
+// ListOAuthProvidersHandler handles the ListOAuthProvidersQuery
+type ListOAuthProvidersHandler struct {
+	oauthService *service.OAuthService
+	logger       *logger.Logger
+}
+
+// NewListOAuthProvidersHandler creates a new ListOAuthProvidersHandler
+func NewListOAuthProvidersHandler(oauthService *service.OAuthService, logger *logger.Logger) *ListOAuthProvidersHandler {
+	return &ListOAuthProvidersHandler{
+		oauthService: oauthService,
+		logger:       logger,
+	}
+}
+
+// Handle handles the ListOAuthProvidersQuery
+func (h *ListOAuthProvidersHandler) Handle(c echo.Context, query ListOAuthProvidersQuery) (_ []string, err error) {
+	end := tracing.StartEcho(c, "ListOAuthProvidersHandler.Handle")
+	defer func() { end(err) }()
+
+	return h.oauthService.Providers(), nil
+}
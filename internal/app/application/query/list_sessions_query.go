@@ -0,0 +1,49 @@
+// internal/app/application/query/list_sessions_query.go
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ListSessionsQuery represents a query to list the current user's active sessions
+type ListSessionsQuery struct {
+	UserID    uuid.UUID `json:"-"`
+	SessionID uuid.UUID `json:"-"`
+}
+
+// ListSessionsHandler handles the ListSessionsQuery
+type ListSessionsHandler struct {
+	sessionService *service.SessionService
+	logger         *logger.Logger
+}
+
+// NewListSessionsHandler creates a new ListSessionsHandler
+func NewListSessionsHandler(sessionService *service.SessionService, logger *logger.Logger) *ListSessionsHandler {
+	return &ListSessionsHandler{
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+// Handle handles the ListSessionsQuery
+func (h *ListSessionsHandler) Handle(c echo.Context, query ListSessionsQuery) (_ []*model.Session, err error) {
+	end := tracing.StartEcho(c, "ListSessionsHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Listing sessions", "userID", query.UserID)
+
+	sessions, err := h.sessionService.ListSessions(c.Request().Context(), query.UserID, query.SessionID)
+	if err != nil {
+		log.Error("Failed to list sessions", "error", err)
+		return nil, err
+	}
+
+	return sessions, nil
+}
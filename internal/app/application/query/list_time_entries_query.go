@@ -0,0 +1,52 @@
+// internal/app/application/query/list_time_entries_query.go
+package query
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+)
+
+// ListTimeEntriesQuery represents a query to list the user's time entries within a date range
+type ListTimeEntriesQuery struct {
+	UserID uuid.UUID `json:"-"`
+	From   time.Time `json:"-"`
+	To     time.Time `json:"-"`
+}
+
+// ListTimeEntriesHandler handles the ListTimeEntriesQuery
+type ListTimeEntriesHandler struct {
+	timeTrackingService *service.TimeTrackingService
+	logger              *logger.Logger
+}
+
+// NewListTimeEntriesHandler creates a new ListTimeEntriesHandler
+func NewListTimeEntriesHandler(timeTrackingService *service.TimeTrackingService, logger *logger.Logger) *ListTimeEntriesHandler {
+	return &ListTimeEntriesHandler{
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
+	}
+}
+
+// Handle handles the ListTimeEntriesQuery
+func (h *ListTimeEntriesHandler) Handle(c echo.Context, query ListTimeEntriesQuery) (_ *model.TimeReport, err error) {
+	end := tracing.StartEcho(c, "ListTimeEntriesHandler.Handle")
+	defer func() { end(err) }()
+
+	// Get request-specific logger with request ID
+	log := logger.FromContext(c)
+	log.Info("Listing time entries", "userID", query.UserID, "from", query.From, "to", query.To)
+
+	report, err := h.timeTrackingService.GetTimeReport(c.Request().Context(), query.UserID, query.From, query.To)
+	if err != nil {
+		log.Error("Failed to list time entries", "error", err)
+		return nil, err
+	}
+
+	return report, nil
+}
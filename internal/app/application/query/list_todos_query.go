@@ -10,20 +10,23 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/repository"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
 // ListTodosQuery represents a query to list todos
 type ListTodosQuery struct {
-	UserID      uuid.UUID          `json:"-"`
-	Status      *model.TodoStatus  `json:"status"`
-	Priority    *model.TodoPriority `json:"priority"`
-	DueDateFrom *time.Time         `json:"due_date_from"`
-	DueDateTo   *time.Time         `json:"due_date_to"`
-	Search      *string            `json:"search"`
-	Page        int                `json:"page" validate:"min=1"`
-	PageSize    int                `json:"page_size" validate:"min=1,max=100"`
-	SortBy      string             `json:"sort_by"`
-	SortOrder   string             `json:"sort_order" validate:"omitempty,oneof=asc desc"`
+	UserID         uuid.UUID           `json:"-"`
+	Status         *model.TodoStatus   `json:"status" query:"status" validate:"omitempty,max=20"`
+	Priority       *model.TodoPriority `json:"priority" query:"priority" validate:"omitempty,oneof=low medium high"`
+	DueDateFrom    *time.Time          `json:"due_date_from" query:"due_date_from"`
+	DueDateTo      *time.Time          `json:"due_date_to" query:"due_date_to"`
+	Search         *string             `json:"search" query:"search"`
+	Actionable     bool                `json:"actionable" query:"actionable"`
+	IncludeSnoozed bool                `json:"include_snoozed" query:"include_snoozed"`
+	Page           int                 `json:"page" query:"page" validate:"min=1"`
+	PageSize       int                 `json:"page_size" query:"page_size" validate:"min=1,max=100"`
+	SortBy         string              `json:"sort_by" query:"sort_by" validate:"omitempty,oneof=created_at updated_at due_date priority title status position"`
+	SortOrder      string              `json:"sort_order" query:"sort_order" validate:"omitempty,oneof=asc desc"`
 }
 
 // TodosResult represents the result of listing todos
@@ -50,7 +53,10 @@ func NewListTodosHandler(todoService *service.TodoService, logger *logger.Logger
 }
 
 // Handle handles the ListTodosQuery
-func (h *ListTodosHandler) Handle(c echo.Context, query ListTodosQuery) (*TodosResult, error) {
+func (h *ListTodosHandler) Handle(c echo.Context, query ListTodosQuery) (_ *TodosResult, err error) {
+	end := tracing.StartEcho(c, "ListTodosHandler.Handle")
+	defer func() { end(err) }()
+
 	// Get request-specific logger with request ID
 	log := logger.FromContext(c)
 	log.Info("Listing todos", "userID", query.UserID)
@@ -71,16 +77,18 @@ func (h *ListTodosHandler) Handle(c echo.Context, query ListTodosQuery) (*TodosR
 
 	// Create filter
 	filter := repository.TodoFilter{
-		UserID:      &query.UserID,
-		Status:      query.Status,
-		Priority:    query.Priority,
-		DueDateFrom: query.DueDateFrom,
-		DueDateTo:   query.DueDateTo,
-		Search:      query.Search,
-		Limit:       pageSize,
-		Offset:      offset,
-		SortBy:      query.SortBy,
-		SortOrder:   query.SortOrder,
+		UserID:         &query.UserID,
+		Status:         query.Status,
+		Priority:       query.Priority,
+		DueDateFrom:    query.DueDateFrom,
+		DueDateTo:      query.DueDateTo,
+		Search:         query.Search,
+		OnlyActionable: query.Actionable,
+		IncludeSnoozed: query.IncludeSnoozed,
+		Limit:          pageSize,
+		Offset:         offset,
+		SortBy:         query.SortBy,
+		SortOrder:      query.SortOrder,
 	}
 
 	// Get todos and count
@@ -0,0 +1,17 @@
+package event
+
+import (
+	"context"
+)
+
+// Event represents something that happened in the domain
+type Event interface {
+	// Name returns the name of the event, e.g. todo.unsnoozed
+	Name() string
+}
+
+// Publisher defines the interface for publishing domain events
+type Publisher interface {
+	// Publish publishes events to their subscribers
+	Publish(ctx context.Context, events ...Event) error
+}
@@ -0,0 +1,21 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TodoUnsnoozed is emitted when a snoozed todo becomes active again
+type TodoUnsnoozed struct {
+	TodoID       uuid.UUID `json:"todo_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	Title        string    `json:"title"`
+	SnoozedUntil time.Time `json:"snoozed_until"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// Name returns the name of the event
+func (e TodoUnsnoozed) Name() string {
+	return "todo.unsnoozed"
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDependencyCycle is returned when a blocker would make a todo depend on itself
+	ErrDependencyCycle = NewValidationError("dependency_cycle", "blocker would create a dependency cycle")
+
+	// ErrDependencyNotFound is returned when a todo is not blocked by another todo
+	ErrDependencyNotFound = NewNotFoundError("dependency_not_found", "todo is not blocked by this todo")
+
+	// ErrDependencyExists is returned when a todo is already blocked by another todo
+	ErrDependencyExists = NewConflictError("dependency_exists", "todo is already blocked by this todo")
+
+	// ErrBlockerNotFound is returned when the blocker of a new dependency does not exist
+	ErrBlockerNotFound = NewNotFoundError("blocker_not_found", "blocker todo not found")
+)
+
+// TodoDependency represents a todo that is blocked by another todo
+type TodoDependency struct {
+	TodoID    uuid.UUID `json:"todo_id"`
+	BlockerID uuid.UUID `json:"blocker_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TodoBlockedError is returned when a todo cannot be started while blockers are still open
+type TodoBlockedError struct {
+	TodoID     uuid.UUID
+	BlockerIDs []uuid.UUID
+}
+
+// Error implements the error interface
+func (e *TodoBlockedError) Error() string {
+	return fmt.Sprintf("todo %s is blocked by %d open todo(s)", e.TodoID, len(e.BlockerIDs))
+}
+
+// ErrorCode returns the machine-readable code of the error
+func (e *TodoBlockedError) ErrorCode() string {
+	return "todo_blocked"
+}
+
+// Is makes the error a conflict
+func (e *TodoBlockedError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// NewTodoDependency creates a new dependency between two todos
+func NewTodoDependency(todoID, blockerID uuid.UUID) (*TodoDependency, error) {
+	if todoID == blockerID {
+		return nil, ErrDependencyCycle
+	}
+
+	return &TodoDependency{
+		TodoID:    todoID,
+		BlockerID: blockerID,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// WouldCreateCycle checks if blocking todoID by blockerID would close a cycle in the existing edges
+func WouldCreateCycle(edges []*TodoDependency, todoID, blockerID uuid.UUID) bool {
+	if todoID == blockerID {
+		return true
+	}
+
+	blockers := make(map[uuid.UUID][]uuid.UUID, len(edges))
+	for _, edge := range edges {
+		blockers[edge.TodoID] = append(blockers[edge.TodoID], edge.BlockerID)
+	}
+
+	// Walk everything the new blocker transitively depends on
+	visited := map[uuid.UUID]bool{blockerID: true}
+	queue := []uuid.UUID{blockerID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range blockers[current] {
+			if next == todoID {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false
+}
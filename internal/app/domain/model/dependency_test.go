@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestWouldCreateCycle(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	// a is blocked by b, b is blocked by c
+	edges := []*TodoDependency{
+		{TodoID: a, BlockerID: b},
+		{TodoID: b, BlockerID: c},
+	}
+
+	// Test self dependency
+	if !WouldCreateCycle(edges, a, a) {
+		t.Error("Expected self dependency to be a cycle")
+	}
+
+	// Test direct cycle
+	if !WouldCreateCycle(edges, b, a) {
+		t.Error("Expected b blocked by a to be a cycle")
+	}
+
+	// Test transitive cycle
+	if !WouldCreateCycle(edges, c, a) {
+		t.Error("Expected c blocked by a to be a cycle")
+	}
+
+	// Test valid dependencies
+	if WouldCreateCycle(edges, a, c) {
+		t.Error("Expected a blocked by c to not be a cycle")
+	}
+	if WouldCreateCycle(edges, c, d) {
+		t.Error("Expected c blocked by d to not be a cycle")
+	}
+}
+
+func TestNewTodoDependency(t *testing.T) {
+	id := uuid.New()
+
+	if _, err := NewTodoDependency(id, id); err != ErrDependencyCycle {
+		t.Errorf("Expected ErrDependencyCycle for self dependency, got %v", err)
+	}
+
+	blockerID := uuid.New()
+	dependency, err := NewTodoDependency(id, blockerID)
+	if err != nil {
+		t.Fatalf("Expected dependency to be created, got %v", err)
+	}
+
+	if dependency.TodoID != id || dependency.BlockerID != blockerID {
+		t.Errorf("Expected dependency %v -> %v, got %v -> %v", id, blockerID, dependency.TodoID, dependency.BlockerID)
+	}
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of domain errors. Every domain error matches one of them with errors.Is,
+// which is how the API picks the status code of the response.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// CodedError is a domain error with a stable machine-readable code, such as "todo_not_found"
+type CodedError interface {
+	error
+	ErrorCode() string
+}
+
+// Error is a domain error of a kind, with a code clients can rely on and a message for people
+type Error struct {
+	kind    error
+	code    string
+	message string
+	base    *Error
+}
+
+// NewNotFoundError creates an error for something that does not exist
+func NewNotFoundError(code, message string) *Error {
+	return &Error{kind: ErrNotFound, code: code, message: message}
+}
+
+// NewConflictError creates an error for a request that conflicts with the current state
+func NewConflictError(code, message string) *Error {
+	return &Error{kind: ErrConflict, code: code, message: message}
+}
+
+// NewValidationError creates an error for input that breaks a domain rule
+func NewValidationError(code, message string) *Error {
+	return &Error{kind: ErrValidation, code: code, message: message}
+}
+
+// NewForbiddenError creates an error for an action the user is not allowed to take
+func NewForbiddenError(code, message string) *Error {
+	return &Error{kind: ErrForbidden, code: code, message: message}
+}
+
+// NewUnauthorizedError creates an error for missing or wrong credentials
+func NewUnauthorizedError(code, message string) *Error {
+	return &Error{kind: ErrUnauthorized, code: code, message: message}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.message
+}
+
+// ErrorCode returns the machine-readable code of the error
+func (e *Error) ErrorCode() string {
+	return e.code
+}
+
+// Is matches the error's kind, and the error it was made from by Errorf
+func (e *Error) Is(target error) bool {
+	return target == e.kind || (e.base != nil && target == e.base)
+}
+
+// Errorf returns the error with the formatted detail appended to its message.
+// The result still matches the original error with errors.Is.
+func (e *Error) Errorf(format string, args ...interface{}) *Error {
+	base := e
+	if e.base != nil {
+		base = e.base
+	}
+
+	return &Error{
+		kind:    e.kind,
+		code:    e.code,
+		message: e.message + ": " + fmt.Sprintf(format, args...),
+		base:    base,
+	}
+}
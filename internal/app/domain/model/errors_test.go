@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+		code string
+	}{
+		{name: "not found", err: ErrTodoNotFound, kind: ErrNotFound, code: "todo_not_found"},
+		{name: "conflict", err: ErrEmailTaken, kind: ErrConflict, code: "email_taken"},
+		{name: "validation", err: ErrInvalidSnooze, kind: ErrValidation, code: "invalid_snooze"},
+		{name: "unauthorized", err: ErrSessionRevoked, kind: ErrUnauthorized, code: "session_revoked"},
+		{name: "wrapped", err: fmt.Errorf("failed to get todo: %w", ErrTodoNotFound), kind: ErrNotFound, code: "todo_not_found"},
+		{name: "status transition", err: &StatusTransitionError{From: TodoStatusCancelled, To: TodoStatusPending}, kind: ErrValidation, code: "invalid_status_transition"},
+		{name: "blocked", err: &TodoBlockedError{}, kind: ErrConflict, code: "todo_blocked"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.kind) {
+				t.Fatalf("expected %v to be of kind %v", tt.err, tt.kind)
+			}
+
+			var coded CodedError
+			if !errors.As(tt.err, &coded) {
+				t.Fatalf("expected %v to have an error code", tt.err)
+			}
+			if coded.ErrorCode() != tt.code {
+				t.Fatalf("expected code %q, got %q", tt.code, coded.ErrorCode())
+			}
+		})
+	}
+}
+
+func TestErrorErrorf(t *testing.T) {
+	err := ErrInvalidWorkflow.Errorf("unknown status %q", "archived")
+
+	if err.Error() != `invalid workflow: unknown status "archived"` {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, ErrInvalidWorkflow) || !errors.Is(err, ErrValidation) {
+		t.Fatal("expected the detailed error to match the original error and its kind")
+	}
+	if errors.Is(err, ErrTodoNotFound) {
+		t.Fatal("expected the detailed error not to match other errors")
+	}
+
+	// Details of details still match the original error
+	if !errors.Is(err.Errorf("again"), ErrInvalidWorkflow) {
+		t.Fatal("expected a twice detailed error to match the original error")
+	}
+}
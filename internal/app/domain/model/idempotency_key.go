@@ -0,0 +1,91 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key a client can send
+const MaxIdempotencyKeyLength = 255
+
+var (
+	// ErrIdempotencyKeyNotFound is returned when a user has not used an idempotency key, or it has expired
+	ErrIdempotencyKeyNotFound = NewNotFoundError("idempotency_key_not_found", "idempotency key not found")
+
+	// ErrIdempotencyKeyInProgress is returned for a repeat of a request that is still running
+	ErrIdempotencyKeyInProgress = NewConflictError("idempotency_key_in_progress", "a request with this idempotency key is still in progress")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent with a different request than it was first used for
+	ErrIdempotencyKeyReused = NewValidationError("idempotency_key_reused", "idempotency key was already used for a different request")
+
+	// ErrInvalidIdempotencyKey is returned for idempotency keys that are empty or too long
+	ErrInvalidIdempotencyKey = NewValidationError("invalid_idempotency_key", "idempotency key must be between 1 and 255 characters")
+)
+
+// IdempotencyKey records a request a user sent with an idempotency key, and once it has
+// completed, the response to replay for repeats of it
+type IdempotencyKey struct {
+	UserID       uuid.UUID
+	Key          string
+	Fingerprint  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	CompletedAt  *time.Time
+	ExpiresAt    time.Time
+}
+
+// NewIdempotencyKey creates a new idempotency key for a request that is starting, kept for ttl
+func NewIdempotencyKey(userID uuid.UUID, key, fingerprint string, ttl time.Duration) (*IdempotencyKey, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	now := time.Now().UTC()
+	return &IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// FingerprintRequest hashes what makes two requests the same: their method, path and body
+func FingerprintRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Complete records the response to the request
+func (k *IdempotencyKey) Complete(statusCode int, contentType string, body []byte) {
+	now := time.Now().UTC()
+	k.StatusCode = statusCode
+	k.ContentType = contentType
+	k.ResponseBody = body
+	k.CompletedAt = &now
+}
+
+// IsCompleted checks if the request has a response to replay
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.CompletedAt != nil
+}
+
+// IsExpired checks if the key is no longer kept at the given time
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
+
+// Matches checks if a repeat of the request has the same fingerprint
+func (k *IdempotencyKey) Matches(fingerprint string) bool {
+	return k.Fingerprint == fingerprint
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	userID := uuid.New()
+
+	key, err := NewIdempotencyKey(userID, "8e03978e-40d5-43e8-bc93-6894a57f9324", "fingerprint", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.UserID != userID || key.Fingerprint != "fingerprint" {
+		t.Errorf("unexpected idempotency key fields: %+v", key)
+	}
+	if key.IsCompleted() {
+		t.Error("expected a new idempotency key not to be completed")
+	}
+	if got := key.ExpiresAt.Sub(key.CreatedAt); got != time.Hour {
+		t.Errorf("expected the key to be kept for an hour, got %v", got)
+	}
+
+	for _, invalid := range []string{"", strings.Repeat("k", MaxIdempotencyKeyLength+1)} {
+		if _, err := NewIdempotencyKey(userID, invalid, "fingerprint", time.Hour); !errors.Is(err, ErrInvalidIdempotencyKey) {
+			t.Errorf("expected ErrInvalidIdempotencyKey for a key of %d characters, got %v", len(invalid), err)
+		}
+	}
+}
+
+func TestIdempotencyKeyComplete(t *testing.T) {
+	key, err := NewIdempotencyKey(uuid.New(), "key", "fingerprint", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key.Complete(201, "application/json", []byte(`{"status":"success"}`))
+
+	if !key.IsCompleted() {
+		t.Fatal("expected the key to be completed")
+	}
+	if key.StatusCode != 201 || key.ContentType != "application/json" || string(key.ResponseBody) != `{"status":"success"}` {
+		t.Errorf("unexpected response: %d %s %s", key.StatusCode, key.ContentType, key.ResponseBody)
+	}
+	if key.IsExpired(key.CreatedAt) || !key.IsExpired(key.ExpiresAt) {
+		t.Error("expected the key to be kept until it expires")
+	}
+}
+
+func TestFingerprintRequest(t *testing.T) {
+	fingerprint := FingerprintRequest("POST", "/api/v1/todos", []byte(`{"title":"a"}`))
+
+	if fingerprint != FingerprintRequest("POST", "/api/v1/todos", []byte(`{"title":"a"}`)) {
+		t.Error("expected the same request to have the same fingerprint")
+	}
+
+	others := []string{
+		FingerprintRequest("PATCH", "/api/v1/todos", []byte(`{"title":"a"}`)),
+		FingerprintRequest("POST", "/api/v1/time-entries/stop", []byte(`{"title":"a"}`)),
+		FingerprintRequest("POST", "/api/v1/todos", []byte(`{"title":"b"}`)),
+		// Parts cannot be shifted into each other
+		FingerprintRequest("POST", "/api/v1/todos{", []byte(`"title":"a"}`)),
+	}
+	for _, other := range others {
+		if other == fingerprint {
+			t.Error("expected a different request to have a different fingerprint")
+		}
+	}
+
+	if !(&IdempotencyKey{Fingerprint: fingerprint}).Matches(fingerprint) {
+		t.Error("expected the key to match its own fingerprint")
+	}
+}
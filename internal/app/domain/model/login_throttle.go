@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ErrLoginThrottleNotFound is returned when an email or client IP has no recent failed logins
+var ErrLoginThrottleNotFound = NewNotFoundError("login_throttle_not_found", "login throttle not found")
+
+// LoginThrottle counts the recent failed logins of an email or a client IP
+type LoginThrottle struct {
+	Key          string
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+// LoginThrottleKeyForEmail returns the throttle key of an email. Emails are counted whether or not
+// an account exists for them, so throttling does not reveal which emails are registered.
+func LoginThrottleKeyForEmail(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// LoginThrottleKeyForIP returns the throttle key of a client IP
+func LoginThrottleKeyForIP(ip string) string {
+	return "ip:" + ip
+}
+
+// LoginThrottlePolicy decides how long logins have to wait after failures. The first failures
+// are free, after that each failure doubles the delay up to MaxDelay, and at MaxFailures the key
+// is locked for LockoutDuration. Failures are forgotten after ResetAfter without any.
+type LoginThrottlePolicy struct {
+	FreeFailures    int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	MaxFailures     int
+	LockoutDuration time.Duration
+	ResetAfter      time.Duration
+}
+
+// RetryAfter returns how long until a login may be tried again, or zero if it may be tried now
+func (p LoginThrottlePolicy) RetryAfter(t *LoginThrottle, now time.Time) time.Duration {
+	if t == nil {
+		return 0
+	}
+
+	if t.LockedUntil != nil && now.Before(*t.LockedUntil) {
+		return t.LockedUntil.Sub(now)
+	}
+
+	if p.IsStale(t, now) {
+		return 0
+	}
+
+	if wait := t.LastFailedAt.Add(p.Delay(t.Failures)).Sub(now); wait > 0 {
+		return wait
+	}
+
+	return 0
+}
+
+// Delay returns the delay after the given number of consecutive failures
+func (p LoginThrottlePolicy) Delay(failures int) time.Duration {
+	if failures < p.FreeFailures {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeFailures; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// ShouldLock checks if a throttle has reached the failures that lock it
+func (p LoginThrottlePolicy) ShouldLock(t *LoginThrottle) bool {
+	return p.MaxFailures > 0 && t.Failures >= p.MaxFailures
+}
+
+// IsStale checks if a throttle's failures are old enough to be forgotten
+func (p LoginThrottlePolicy) IsStale(t *LoginThrottle, now time.Time) bool {
+	return now.Sub(t.LastFailedAt) >= p.ResetAfter && (t.LockedUntil == nil || !now.Before(*t.LockedUntil))
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func testLoginThrottlePolicy() LoginThrottlePolicy {
+	return LoginThrottlePolicy{
+		FreeFailures:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		MaxFailures:     10,
+		LockoutDuration: 15 * time.Minute,
+		ResetAfter:      time.Hour,
+	}
+}
+
+func TestLoginThrottlePolicyDelay(t *testing.T) {
+	policy := testLoginThrottlePolicy()
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{2, 0},
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{6, 8 * time.Second},
+		{8, 30 * time.Second},
+		{100, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Delay(tt.failures); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginThrottlePolicyRetryAfter(t *testing.T) {
+	policy := testLoginThrottlePolicy()
+	now := time.Now()
+
+	if wait := policy.RetryAfter(nil, now); wait != 0 {
+		t.Errorf("expected no wait without failures, got %v", wait)
+	}
+
+	throttle := &LoginThrottle{Failures: 4, LastFailedAt: now.Add(-time.Second)}
+	if wait := policy.RetryAfter(throttle, now); wait != time.Second {
+		t.Errorf("expected to wait out the rest of the delay, got %v", wait)
+	}
+
+	lockedUntil := now.Add(10 * time.Minute)
+	throttle.LockedUntil = &lockedUntil
+	if wait := policy.RetryAfter(throttle, now); wait != 10*time.Minute {
+		t.Errorf("expected to wait out the lockout, got %v", wait)
+	}
+
+	stale := &LoginThrottle{Failures: 9, LastFailedAt: now.Add(-2 * time.Hour)}
+	if wait := policy.RetryAfter(stale, now); wait != 0 {
+		t.Errorf("expected stale failures to be forgotten, got %v", wait)
+	}
+}
+
+func TestLoginThrottleKeyForEmail(t *testing.T) {
+	if LoginThrottleKeyForEmail(" Jane@Example.com") != LoginThrottleKeyForEmail("jane@example.com") {
+		t.Error("expected emails to be counted case-insensitively")
+	}
+}
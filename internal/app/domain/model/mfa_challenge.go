@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MFAChallengeMaxAttempts is how many codes can be tried for a challenge before it is invalidated
+const MFAChallengeMaxAttempts = 5
+
+// ErrMFAChallengeNotFound is returned when an MFA challenge does not exist, expired or has no attempts left
+var ErrMFAChallengeNotFound = NewNotFoundError("mfa_challenge_not_found", "MFA challenge not found")
+
+// MFAChallenge represents a login that passed the password check and waits for its second factor
+type MFAChallenge struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Attempts  int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NewMFAChallenge creates a new MFA challenge for a user that expires after ttl
+func NewMFAChallenge(userID uuid.UUID, ttl time.Duration) *MFAChallenge {
+	now := time.Now().UTC()
+	return &MFAChallenge{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// AttemptsLeft returns how many more codes can be tried for the challenge
+func (c *MFAChallenge) AttemptsLeft() int {
+	if c.Attempts >= MFAChallengeMaxAttempts {
+		return 0
+	}
+	return MFAChallengeMaxAttempts - c.Attempts
+}
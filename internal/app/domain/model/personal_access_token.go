@@ -0,0 +1,142 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenScope represents a permission granted to a personal access token
+type TokenScope string
+
+const (
+	// Token scopes
+	TokenScopeTodosRead  TokenScope = "todos:read"
+	TokenScopeTodosWrite TokenScope = "todos:write"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, telling them apart from JWTs
+const PersonalAccessTokenPrefix = "tdp_"
+
+const (
+	// personalAccessTokenBytes is the number of random bytes in a personal access token
+	personalAccessTokenBytes = 32
+
+	// personalAccessTokenDisplayLength is the number of leading characters kept to identify a token
+	personalAccessTokenDisplayLength = len(PersonalAccessTokenPrefix) + 8
+)
+
+var (
+	// ErrInvalidTokenScope is returned when a token asks for an unknown scope
+	ErrInvalidTokenScope = NewValidationError("invalid_token_scope", "invalid token scope")
+
+	// ErrInvalidTokenExpiry is returned when a token would expire in the past
+	ErrInvalidTokenExpiry = NewValidationError("invalid_token_expiry", "token expiry must be in the future")
+
+	// ErrAccessTokenNotFound is returned when a personal access token does not exist
+	ErrAccessTokenNotFound = NewNotFoundError("access_token_not_found", "personal access token not found")
+
+	// ErrTooManyAccessTokens is returned when a user already has as many tokens as allowed
+	ErrTooManyAccessTokens = NewConflictError("access_token_limit_reached", "personal access token limit reached")
+)
+
+// IsValid checks if the scope is known
+func (s TokenScope) IsValid() bool {
+	switch s {
+	case TokenScopeTodosRead, TokenScopeTodosWrite:
+		return true
+	}
+	return false
+}
+
+// PersonalAccessToken represents a named, scoped token a user creates for scripts and CLIs.
+// Only a display prefix and the hash of the token are stored.
+type PersonalAccessToken struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Name        string       `json:"name"`
+	TokenPrefix string       `json:"token_prefix"`
+	TokenHash   string       `json:"-"`
+	Scopes      []TokenScope `json:"scopes"`
+	ExpiresAt   *time.Time   `json:"expires_at"`
+	LastUsedAt  *time.Time   `json:"last_used_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// NewPersonalAccessToken creates a new personal access token that never expires if expiresAt is nil.
+// It returns the token together with the raw value, which is only shown to the user once.
+func NewPersonalAccessToken(userID uuid.UUID, name string, scopes []TokenScope, expiresAt *time.Time) (*PersonalAccessToken, string, error) {
+	now := time.Now().UTC()
+
+	if len(scopes) == 0 {
+		return nil, "", ErrInvalidTokenScope
+	}
+
+	unique := make([]TokenScope, 0, len(scopes))
+	seen := make(map[TokenScope]bool)
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", ErrInvalidTokenScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, "", ErrInvalidTokenExpiry
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	buf := make([]byte, personalAccessTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	raw := PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	return &PersonalAccessToken{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        name,
+		TokenPrefix: raw[:personalAccessTokenDisplayLength],
+		TokenHash:   HashPersonalAccessToken(raw),
+		Scopes:      unique,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+	}, raw, nil
+}
+
+// IsPersonalAccessToken checks if a bearer token is a personal access token rather than a JWT
+func IsPersonalAccessToken(raw string) bool {
+	return strings.HasPrefix(raw, PersonalAccessTokenPrefix)
+}
+
+// HashPersonalAccessToken returns the hex encoded SHA-256 hash of a raw personal access token
+func HashPersonalAccessToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// HasScope checks if the token was granted a scope
+func (t *PersonalAccessToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired checks if the token has expired
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
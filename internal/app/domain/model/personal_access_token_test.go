@@ -0,0 +1,74 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewPersonalAccessToken(t *testing.T) {
+	userID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	token, raw, err := NewPersonalAccessToken(userID, "ci", []TokenScope{TokenScopeTodosRead, TokenScopeTodosRead}, &expiresAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !IsPersonalAccessToken(raw) {
+		t.Errorf("expected raw token to start with %s, got %s", PersonalAccessTokenPrefix, raw)
+	}
+	if token.TokenHash != HashPersonalAccessToken(raw) {
+		t.Error("expected the stored hash to match the raw token")
+	}
+	if token.TokenPrefix != raw[:len(token.TokenPrefix)] || len(token.TokenPrefix) >= len(raw) {
+		t.Errorf("expected a short display prefix of the raw token, got %s", token.TokenPrefix)
+	}
+	if len(token.Scopes) != 1 || !token.HasScope(TokenScopeTodosRead) || token.HasScope(TokenScopeTodosWrite) {
+		t.Errorf("unexpected scopes: %v", token.Scopes)
+	}
+}
+
+func TestNewPersonalAccessTokenValidation(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		scopes    []TokenScope
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{"no scopes", nil, nil, ErrInvalidTokenScope},
+		{"unknown scope", []TokenScope{"admin"}, nil, ErrInvalidTokenScope},
+		{"expired", []TokenScope{TokenScopeTodosWrite}, &past, ErrInvalidTokenExpiry},
+		{"no expiry", []TokenScope{TokenScopeTodosWrite}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NewPersonalAccessToken(uuid.New(), "script", tt.scopes, tt.expiresAt)
+			if err != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPersonalAccessTokenIsExpired(t *testing.T) {
+	now := time.Now().UTC()
+	token := &PersonalAccessToken{}
+
+	if token.IsExpired(now) {
+		t.Error("expected a token without expiry never to expire")
+	}
+
+	expiresAt := now.Add(time.Hour)
+	token.ExpiresAt = &expiresAt
+	if token.IsExpired(now) {
+		t.Error("expected the token to be valid before its expiry")
+	}
+	if !token.IsExpired(expiresAt) {
+		t.Error("expected the token to be expired at its expiry")
+	}
+}
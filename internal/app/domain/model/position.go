@@ -0,0 +1,36 @@
+package model
+
+const (
+	// PositionStep is the gap left between neighbouring todos in a board column
+	PositionStep float64 = 1024
+
+	// MinPositionGap is the smallest gap between neighbours before a column has to be rebalanced
+	MinPositionGap float64 = 1e-6
+)
+
+// BoardColumn represents the todos of a single status, ordered by position
+type BoardColumn struct {
+	Status TodoStatus `json:"status"`
+	Todos  []*Todo    `json:"todos"`
+}
+
+// PositionBetween returns a position that sorts between prev and next.
+// A nil prev means the start of the column and a nil next means its end.
+// The second return value is false when the neighbours are too close to
+// fit another position, in which case the column needs to be rebalanced.
+func PositionBetween(prev, next *float64) (float64, bool) {
+	switch {
+	case prev == nil && next == nil:
+		return PositionStep, true
+	case prev == nil:
+		return *next - PositionStep, true
+	case next == nil:
+		return *prev + PositionStep, true
+	}
+
+	if *next-*prev < MinPositionGap {
+		return 0, false
+	}
+
+	return *prev + (*next-*prev)/2, true
+}
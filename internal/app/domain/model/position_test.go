@@ -0,0 +1,43 @@
+package model
+
+import "testing"
+
+func TestPositionBetween(t *testing.T) {
+	prev := 1024.0
+	next := 2048.0
+
+	// Test empty column
+	if pos, ok := PositionBetween(nil, nil); !ok || pos != PositionStep {
+		t.Errorf("Expected position %v for empty column, got %v", PositionStep, pos)
+	}
+
+	// Test insert at the top of a column
+	if pos, ok := PositionBetween(nil, &prev); !ok || pos >= prev {
+		t.Errorf("Expected position before %v, got %v", prev, pos)
+	}
+
+	// Test insert at the bottom of a column
+	if pos, ok := PositionBetween(&next, nil); !ok || pos <= next {
+		t.Errorf("Expected position after %v, got %v", next, pos)
+	}
+
+	// Test insert between two neighbours
+	pos, ok := PositionBetween(&prev, &next)
+	if !ok || pos <= prev || pos >= next {
+		t.Errorf("Expected position between %v and %v, got %v", prev, next, pos)
+	}
+}
+
+func TestPositionBetweenTooDense(t *testing.T) {
+	prev := 1024.0
+	next := prev + MinPositionGap/2
+
+	if _, ok := PositionBetween(&prev, &next); ok {
+		t.Error("Expected dense neighbours to require a rebalance")
+	}
+
+	// Neighbours in the wrong order can never fit a position
+	if _, ok := PositionBetween(&next, &prev); ok {
+		t.Error("Expected reversed neighbours to be rejected")
+	}
+}
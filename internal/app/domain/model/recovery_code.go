@@ -0,0 +1,68 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCodeCount is the number of recovery codes generated when 2FA is enabled
+const RecoveryCodeCount = 10
+
+// recoveryCodeBytes is the number of random bytes in a recovery code
+const recoveryCodeBytes = 5
+
+// ErrInvalidRecoveryCode is returned when a recovery code does not exist or was already used
+var ErrInvalidRecoveryCode = NewValidationError("invalid_recovery_code", "invalid recovery code")
+
+// RecoveryCode represents a one-time code that signs a user in when their authenticator is lost.
+// Only the hash of the code is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewRecoveryCodes creates a set of recovery codes for a user.
+// It returns the codes together with the raw values to show to the user once.
+func NewRecoveryCodes(userID uuid.UUID, count int) ([]*RecoveryCode, []string, error) {
+	now := time.Now().UTC()
+	codes := make([]*RecoveryCode, 0, count)
+	raws := make([]string, 0, count)
+
+	for i := 0; i < count; i++ {
+		buf := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+
+		// Eight lowercase base32 characters, shown as two groups of four
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		raw := encoded[:4] + "-" + encoded[4:]
+
+		codes = append(codes, &RecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(raw),
+			CreatedAt: now,
+		})
+		raws = append(raws, raw)
+	}
+
+	return codes, raws, nil
+}
+
+// HashRecoveryCode returns the hex encoded SHA-256 hash of a recovery code,
+// ignoring case, spaces and dashes so codes can be typed loosely
+func HashRecoveryCode(raw string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(raw)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
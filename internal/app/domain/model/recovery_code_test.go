@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	userID := uuid.New()
+
+	codes, raws, err := NewRecoveryCodes(userID, RecoveryCodeCount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(codes) != RecoveryCodeCount || len(raws) != RecoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d and %d", RecoveryCodeCount, len(codes), len(raws))
+	}
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		if code.UserID != userID {
+			t.Errorf("unexpected user ID: %s", code.UserID)
+		}
+		if code.CodeHash != HashRecoveryCode(raws[i]) {
+			t.Errorf("expected the stored hash to match code %s", raws[i])
+		}
+		if len(raws[i]) != 9 || raws[i][4] != '-' {
+			t.Errorf("unexpected code format: %s", raws[i])
+		}
+		if seen[raws[i]] {
+			t.Errorf("duplicate code: %s", raws[i])
+		}
+		seen[raws[i]] = true
+	}
+}
+
+func TestHashRecoveryCodeNormalizes(t *testing.T) {
+	want := HashRecoveryCode("abcd-efgh")
+
+	for _, raw := range []string{"ABCD-EFGH", "abcdefgh", " abcd efgh "} {
+		if got := HashRecoveryCode(raw); got != want {
+			t.Errorf("expected %q to hash like abcd-efgh", raw)
+		}
+	}
+
+	if HashRecoveryCode(strings.Repeat("a", 8)) == want {
+		t.Error("expected different codes to have different hashes")
+	}
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxSessionUserAgentLength is the number of characters of a user agent kept with a session
+const maxSessionUserAgentLength = 512
+
+// ClientInfo describes the client a user logs in from
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+var (
+	// ErrSessionNotFound is returned when a session does not exist, has expired or was signed out
+	ErrSessionNotFound = NewNotFoundError("session_not_found", "session not found")
+
+	// ErrSessionRevoked is returned when a token names a session that is no longer active
+	ErrSessionRevoked = NewUnauthorizedError("session_revoked", "session revoked")
+)
+
+// Session represents a login on one device. Access tokens name the session they were issued
+// for, and stop working once it is revoked.
+type Session struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"-"`
+
+	// Current marks the session a listing was requested from; it is not stored
+	Current bool `json:"current"`
+}
+
+// NewSession creates a new session for a login from the given client that lasts as long as its access token
+func NewSession(userID uuid.UUID, client ClientInfo, ttl time.Duration) *Session {
+	now := time.Now().UTC()
+
+	userAgent := client.UserAgent
+	if runes := []rune(userAgent); len(runes) > maxSessionUserAgentLength {
+		userAgent = string(runes[:maxSessionUserAgentLength])
+	}
+
+	return &Session{
+		ID:         uuid.New(),
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+// IsActive checks if the session has neither been revoked nor expired
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewSession(t *testing.T) {
+	userID := uuid.New()
+
+	session := NewSession(userID, ClientInfo{UserAgent: "curl/8.0", IP: "192.0.2.1"}, time.Hour)
+
+	if session.ID == uuid.Nil {
+		t.Error("expected a session ID")
+	}
+	if session.UserID != userID || session.UserAgent != "curl/8.0" || session.IP != "192.0.2.1" {
+		t.Errorf("unexpected session fields: %+v", session)
+	}
+	if !session.LastSeenAt.Equal(session.CreatedAt) {
+		t.Error("expected a new session to be last seen when it was created")
+	}
+	if got := session.ExpiresAt.Sub(session.CreatedAt); got != time.Hour {
+		t.Errorf("expected the session to last an hour, got %v", got)
+	}
+}
+
+func TestNewSessionTruncatesUserAgent(t *testing.T) {
+	session := NewSession(uuid.New(), ClientInfo{UserAgent: strings.Repeat("ü", 1000)}, time.Hour)
+
+	if got := len([]rune(session.UserAgent)); got != maxSessionUserAgentLength {
+		t.Errorf("expected the user agent to be cut to %d characters, got %d", maxSessionUserAgentLength, got)
+	}
+}
+
+func TestSessionIsActive(t *testing.T) {
+	now := time.Now().UTC()
+	session := &Session{ExpiresAt: now.Add(time.Hour)}
+
+	if !session.IsActive(now) {
+		t.Error("expected a fresh session to be active")
+	}
+	if session.IsActive(now.Add(2 * time.Hour)) {
+		t.Error("expected an expired session not to be active")
+	}
+
+	session.RevokedAt = &now
+	if session.IsActive(now) {
+		t.Error("expected a revoked session not to be active")
+	}
+}
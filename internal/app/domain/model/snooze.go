@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+)
+
+// ErrInvalidSnooze is returned when a snooze does not end in the future
+var ErrInvalidSnooze = NewValidationError("invalid_snooze", "snooze must end in the future")
+
+// SnoozePreset represents a predefined snooze duration
+type SnoozePreset string
+
+const (
+	// Snooze presets
+	SnoozePresetTomorrow SnoozePreset = "tomorrow"
+	SnoozePresetNextWeek SnoozePreset = "next_week"
+	SnoozePresetCustom   SnoozePreset = "custom"
+)
+
+// SnoozeWakeHour is the hour of day at which preset snoozes end
+const SnoozeWakeHour = 9
+
+// ResolveSnooze returns the time a snooze preset ends, relative to now in the given location.
+// Tomorrow ends at the wake hour of the next day, next week at the wake hour of the next Monday,
+// and custom at the given time.
+func ResolveSnooze(preset SnoozePreset, until *time.Time, now time.Time, loc *time.Location) (time.Time, error) {
+	local := now.In(loc)
+	wake := time.Date(local.Year(), local.Month(), local.Day(), SnoozeWakeHour, 0, 0, 0, loc)
+
+	var resolved time.Time
+	switch preset {
+	case SnoozePresetTomorrow:
+		resolved = wake.AddDate(0, 0, 1)
+	case SnoozePresetNextWeek:
+		days := (int(time.Monday) - int(local.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		resolved = wake.AddDate(0, 0, days)
+	case SnoozePresetCustom:
+		if until == nil {
+			return time.Time{}, ErrInvalidSnooze
+		}
+		resolved = *until
+	default:
+		return time.Time{}, ErrInvalidSnooze
+	}
+
+	if !resolved.After(now) {
+		return time.Time{}, ErrInvalidSnooze
+	}
+
+	return resolved.UTC(), nil
+}
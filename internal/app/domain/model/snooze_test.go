@@ -0,0 +1,120 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveSnooze(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+	later := now.Add(2 * time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		preset  SnoozePreset
+		until   *time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "tomorrow",
+			preset: SnoozePresetTomorrow,
+			want:   time.Date(2025, 3, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "next week",
+			preset: SnoozePresetNextWeek,
+			want:   time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "custom",
+			preset: SnoozePresetCustom,
+			until:  &later,
+			want:   later,
+		},
+		{
+			name:    "custom in the past",
+			preset:  SnoozePresetCustom,
+			until:   &earlier,
+			wantErr: true,
+		},
+		{
+			name:    "custom without time",
+			preset:  SnoozePresetCustom,
+			wantErr: true,
+		},
+		{
+			name:    "unknown preset",
+			preset:  SnoozePreset("someday"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveSnooze(tt.preset, tt.until, now, time.UTC)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveSnoozeNextWeekOnMonday(t *testing.T) {
+	monday := time.Date(2025, 3, 17, 8, 0, 0, 0, time.UTC)
+
+	got, err := ResolveSnooze(SnoozePresetNextWeek, nil, monday, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2025, 3, 24, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestResolveSnoozeInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	// Already Thursday in UTC+7
+	now := time.Date(2025, 3, 12, 20, 0, 0, 0, time.UTC)
+
+	got, err := ResolveSnooze(SnoozePresetTomorrow, nil, now, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2025, 3, 14, 9, 0, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestTodoSnooze(t *testing.T) {
+	todo := &Todo{}
+	now := time.Now().UTC()
+
+	todo.Snooze(now.Add(time.Hour))
+	if !todo.IsSnoozed(now) {
+		t.Error("expected todo to be snoozed")
+	}
+	if todo.IsSnoozed(now.Add(2 * time.Hour)) {
+		t.Error("expected snooze to have ended")
+	}
+
+	todo.Unsnooze()
+	if todo.SnoozedUntil != nil || todo.IsSnoozed(now) {
+		t.Error("expected todo not to be snoozed")
+	}
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidTimeRange is returned when a time entry ends before it starts
+	ErrInvalidTimeRange = NewValidationError("invalid_time_range", "time entry must end after it starts")
+
+	// ErrTimeEntryNotFound is returned when a time entry does not exist
+	ErrTimeEntryNotFound = NewNotFoundError("time_entry_not_found", "time entry not found")
+
+	// ErrTimerAlreadyRunning is returned when a user starts a second timer
+	ErrTimerAlreadyRunning = NewConflictError("timer_already_running", "a timer is already running")
+
+	// ErrNoRunningTimer is returned when a user stops a timer while none is running
+	ErrNoRunningTimer = NewNotFoundError("no_running_timer", "no timer is running")
+)
+
+// TimeEntrySource represents how a time entry was recorded
+type TimeEntrySource string
+
+const (
+	// Time entry sources
+	TimeEntrySourceTimer  TimeEntrySource = "timer"
+	TimeEntrySourceManual TimeEntrySource = "manual"
+)
+
+// TimeEntry represents time spent on a todo
+type TimeEntry struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	TodoID    uuid.UUID       `json:"todo_id"`
+	StartedAt time.Time       `json:"started_at"`
+	EndedAt   *time.Time      `json:"ended_at"`
+	Note      string          `json:"note"`
+	Source    TimeEntrySource `json:"source"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// TodoTimeSummary represents the time tracked against a todo compared to its estimate
+type TodoTimeSummary struct {
+	TodoID          uuid.UUID    `json:"todo_id"`
+	EstimateMinutes *int         `json:"estimate_minutes"`
+	TotalSeconds    int64        `json:"total_seconds"`
+	Entries         []*TimeEntry `json:"entries"`
+}
+
+// TimeReport represents the time entries and total for a date range
+type TimeReport struct {
+	From         time.Time    `json:"from"`
+	To           time.Time    `json:"to"`
+	TotalSeconds int64        `json:"total_seconds"`
+	Entries      []*TimeEntry `json:"entries"`
+}
+
+// TimesheetRow represents the time spent on a todo on a single day
+type TimesheetRow struct {
+	Date      string    `json:"date"`
+	TodoID    uuid.UUID `json:"todo_id"`
+	TodoTitle string    `json:"todo_title"`
+	Seconds   int64     `json:"seconds"`
+}
+
+// StartTimer creates a new running time entry
+func StartTimer(userID, todoID uuid.UUID, note string) *TimeEntry {
+	now := time.Now().UTC()
+	return &TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TodoID:    todoID,
+		StartedAt: now,
+		Note:      note,
+		Source:    TimeEntrySourceTimer,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NewManualTimeEntry creates a new time entry for time that was not tracked with a timer
+func NewManualTimeEntry(userID, todoID uuid.UUID, startedAt, endedAt time.Time, note string) (*TimeEntry, error) {
+	if !endedAt.After(startedAt) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	now := time.Now().UTC()
+	endedAt = endedAt.UTC()
+	return &TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TodoID:    todoID,
+		StartedAt: startedAt.UTC(),
+		EndedAt:   &endedAt,
+		Note:      note,
+		Source:    TimeEntrySourceManual,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsRunning checks if the time entry is a running timer
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Stop stops a running timer
+func (e *TimeEntry) Stop(at time.Time) {
+	if !e.IsRunning() {
+		return
+	}
+
+	at = at.UTC()
+	if at.Before(e.StartedAt) {
+		at = e.StartedAt
+	}
+
+	e.EndedAt = &at
+	e.UpdatedAt = time.Now().UTC()
+}
+
+// Duration returns the tracked duration, counting a running timer up to now
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// TotalSeconds sums the durations of the time entries in whole seconds
+func TotalSeconds(entries []*TimeEntry, now time.Time) int64 {
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.Duration(now)
+	}
+	return int64(total / time.Second)
+}
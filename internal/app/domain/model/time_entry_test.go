@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestTimerStop(t *testing.T) {
+	entry := StartTimer(uuid.New(), uuid.New(), "")
+
+	if !entry.IsRunning() {
+		t.Fatal("Expected new timer to be running")
+	}
+
+	stoppedAt := entry.StartedAt.Add(90 * time.Minute)
+	entry.Stop(stoppedAt)
+
+	if entry.IsRunning() {
+		t.Error("Expected stopped timer to not be running")
+	}
+
+	if entry.Duration(time.Now()) != 90*time.Minute {
+		t.Errorf("Expected duration to be %v, got %v", 90*time.Minute, entry.Duration(time.Now()))
+	}
+
+	// Stopping twice keeps the first end time
+	entry.Stop(stoppedAt.Add(time.Hour))
+	if !entry.EndedAt.Equal(stoppedAt) {
+		t.Errorf("Expected end time to stay %v, got %v", stoppedAt, entry.EndedAt)
+	}
+}
+
+func TestNewManualTimeEntry(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// Test invalid range
+	if _, err := NewManualTimeEntry(uuid.New(), uuid.New(), start, start, ""); err != ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange, got %v", err)
+	}
+
+	entry, err := NewManualTimeEntry(uuid.New(), uuid.New(), start, start.Add(30*time.Minute), "Call with client")
+	if err != nil {
+		t.Fatalf("Expected manual entry to be created, got %v", err)
+	}
+
+	if entry.Source != TimeEntrySourceManual {
+		t.Errorf("Expected source to be %s, got %s", TimeEntrySourceManual, entry.Source)
+	}
+}
+
+func TestTotalSeconds(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	finished, _ := NewManualTimeEntry(uuid.New(), uuid.New(), now.Add(-3*time.Hour), now.Add(-2*time.Hour), "")
+	running := &TimeEntry{StartedAt: now.Add(-15 * time.Minute)}
+
+	total := TotalSeconds([]*TimeEntry{finished, running}, now)
+	if total != int64((75 * time.Minute).Seconds()) {
+		t.Errorf("Expected total to be %d seconds, got %d", int64((75 * time.Minute).Seconds()), total)
+	}
+}
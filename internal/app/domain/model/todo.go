@@ -14,10 +14,10 @@ type TodoPriority string
 
 const (
 	// Todo statuses
-	TodoStatusPending   TodoStatus = "pending"
+	TodoStatusPending    TodoStatus = "pending"
 	TodoStatusInProgress TodoStatus = "in_progress"
-	TodoStatusCompleted TodoStatus = "completed"
-	TodoStatusCancelled TodoStatus = "cancelled"
+	TodoStatusCompleted  TodoStatus = "completed"
+	TodoStatusCancelled  TodoStatus = "cancelled"
 
 	// Todo priorities
 	TodoPriorityLow    TodoPriority = "low"
@@ -25,18 +25,25 @@ const (
 	TodoPriorityHigh   TodoPriority = "high"
 )
 
+// ErrTodoNotFound is returned when a todo does not exist or belongs to another user
+var ErrTodoNotFound = NewNotFoundError("todo_not_found", "todo not found")
+
 // Todo represents a todo item
 type Todo struct {
-	ID          uuid.UUID    `json:"id"`
-	UserID      uuid.UUID    `json:"user_id"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	Status      TodoStatus   `json:"status"`
-	Priority    TodoPriority `json:"priority"`
-	DueDate     *time.Time   `json:"due_date"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	CompletedAt *time.Time   `json:"completed_at"`
+	ID              uuid.UUID    `json:"id"`
+	UserID          uuid.UUID    `json:"user_id"`
+	Title           string       `json:"title"`
+	Description     string       `json:"description"`
+	Status          TodoStatus   `json:"status"`
+	Priority        TodoPriority `json:"priority"`
+	DueDate         *time.Time   `json:"due_date"`
+	Position        float64      `json:"position"`
+	EstimateMinutes *int         `json:"estimate_minutes"`
+	SnoozedUntil    *time.Time   `json:"snoozed_until"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	CompletedAt     *time.Time   `json:"completed_at"`
+	Blocked         bool         `json:"blocked"`
 }
 
 // NewTodo creates a new todo item
@@ -79,20 +86,65 @@ func (t *Todo) UpdateDueDate(dueDate *time.Time) {
 	t.UpdatedAt = time.Now().UTC()
 }
 
-// UpdateStatus updates the todo's status
+// UpdateEstimate updates the todo's time estimate in minutes
+func (t *Todo) UpdateEstimate(estimateMinutes *int) {
+	t.EstimateMinutes = estimateMinutes
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// UpdatePosition updates the todo's position within its board column
+func (t *Todo) UpdatePosition(position float64) {
+	t.Position = position
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// Snooze hides the todo from lists until the given time
+func (t *Todo) Snooze(until time.Time) {
+	until = until.UTC()
+	t.SnoozedUntil = &until
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// Unsnooze makes the todo active again
+func (t *Todo) Unsnooze() {
+	t.SnoozedUntil = nil
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// IsSnoozed checks if the todo is still snoozed at the given time
+func (t *Todo) IsSnoozed(now time.Time) bool {
+	return t.SnoozedUntil != nil && t.SnoozedUntil.After(now)
+}
+
+// UpdateStatus updates the todo's status under the default workflow
 func (t *Todo) UpdateStatus(status TodoStatus) {
+	t.UpdateStatusIn(status, DefaultWorkflow(t.UserID))
+}
+
+// UpdateStatusIn updates the todo's status, recording when it reached a done status of the workflow
+func (t *Todo) UpdateStatusIn(status TodoStatus, workflow *Workflow) {
+	now := time.Now().UTC()
 	t.Status = status
-	t.UpdatedAt = time.Now().UTC()
+	t.UpdatedAt = now
 
-	// If the status is completed, set the completed at time
-	if status == TodoStatusCompleted {
-		now := time.Now().UTC()
-		t.CompletedAt = &now
-	} else {
+	// Moving between done statuses keeps the time the todo was first done
+	if !workflow.IsDone(status) {
 		t.CompletedAt = nil
+	} else if t.CompletedAt == nil {
+		t.CompletedAt = &now
 	}
 }
 
+// TransitionTo updates the todo's status if the workflow allows it
+func (t *Todo) TransitionTo(status TodoStatus, workflow *Workflow) error {
+	if err := workflow.CheckTransition(t.Status, status); err != nil {
+		return err
+	}
+
+	t.UpdateStatusIn(status, workflow)
+	return nil
+}
+
 // MarkAsCompleted marks the todo as completed
 func (t *Todo) MarkAsCompleted() {
 	t.UpdateStatus(TodoStatusCompleted)
@@ -113,12 +165,27 @@ func (t *Todo) MarkAsCancelled() {
 	t.UpdateStatus(TodoStatusCancelled)
 }
 
-// IsOverdue checks if the todo is overdue
+// IsOverdue checks if the todo is overdue under the default workflow
 func (t *Todo) IsOverdue() bool {
+	return t.IsOverdueIn(DefaultWorkflow(t.UserID))
+}
+
+// IsOverdueIn checks if the todo is overdue, treating the workflow's done statuses as finished
+func (t *Todo) IsOverdueIn(workflow *Workflow) bool {
 	if t.DueDate == nil {
 		return false
 	}
-	return t.Status != TodoStatusCompleted && t.Status != TodoStatusCancelled && t.DueDate.Before(time.Now().UTC())
+	return !workflow.IsDone(t.Status) && t.DueDate.Before(time.Now().UTC())
+}
+
+// TodoStatuses returns all todo statuses in board column order
+func TodoStatuses() []TodoStatus {
+	return []TodoStatus{
+		TodoStatusPending,
+		TodoStatusInProgress,
+		TodoStatusCompleted,
+		TodoStatusCancelled,
+	}
 }
 
 // TodoStatusPtr converts a TodoStatus to a pointer
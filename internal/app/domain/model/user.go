@@ -1,61 +1,89 @@
 package model
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// DefaultTimezone is the timezone of new users
+	DefaultTimezone = "UTC"
+
+	// DefaultLocale is the locale of new users
+	DefaultLocale = "en"
+
+	// RoleUser is the role every user has
+	RoleUser = "user"
+
+	// RoleAdmin is the role of users who administer other accounts
+	RoleAdmin = "admin"
+)
+
+var (
+	// ErrUserNotFound is returned when a user does not exist
+	ErrUserNotFound = NewNotFoundError("user_not_found", "user not found")
+
+	// ErrEmailTaken is returned when another user already has an email
+	ErrEmailTaken = NewConflictError("email_taken", "user with this email already exists")
+
+	// ErrInvalidTimezone is returned when a timezone is not a known IANA timezone
+	ErrInvalidTimezone = NewValidationError("invalid_timezone", "invalid timezone")
+
+	// ErrInvalidLocale is returned when a locale is not a language tag such as "en" or "pt-BR"
+	ErrInvalidLocale = NewValidationError("invalid_locale", "invalid locale")
+)
+
+// localePattern matches a language code with an optional region, e.g. "en" or "pt-BR"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
 // User represents a user in the system
 type User struct {
-	ID           uuid.UUID `json:"id"`
-	Fullname     string    `json:"fullname"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-}
-
-// NewUser creates a new user
-func NewUser(fullname, email, password string) (*User, error) {
-	// Hash the password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, err
-	}
+	ID                  uuid.UUID  `json:"id"`
+	Fullname            string     `json:"fullname"`
+	Email               string     `json:"email"`
+	PasswordHash        string     `json:"-"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	TokensRevokedAt     *time.Time `json:"-"`
+	TokenVersion        int        `json:"-"`
+	Timezone            string     `json:"timezone"`
+	Locale              string     `json:"locale"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	MFASecret           string     `json:"-"`
+	MFAEnabledAt        *time.Time `json:"mfa_enabled_at"`
+	Role                string     `json:"role"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
 
+// NewUser creates a new user with an already hashed password
+func NewUser(fullname, email, passwordHash string) *User {
 	now := time.Now().UTC()
 	return &User{
 		ID:           uuid.New(),
 		Fullname:     fullname,
 		Email:        email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: passwordHash,
+		Timezone:     DefaultTimezone,
+		Locale:       DefaultLocale,
+		Role:         RoleUser,
 		CreatedAt:    now,
 		UpdatedAt:    now,
-	}, nil
-}
-
-// CheckPassword checks if the provided password matches the stored hash
-func (u *User) CheckPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
-	return err == nil
-}
-
-// UpdatePassword updates the user's password
-func (u *User) UpdatePassword(password string) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
 	}
+}
 
-	u.PasswordHash = string(hashedPassword)
+// UpdatePasswordHash replaces the hash of the user's password
+func (u *User) UpdatePasswordHash(passwordHash string) {
+	u.PasswordHash = passwordHash
 	u.UpdatedAt = time.Now().UTC()
-	return nil
 }
 
-// UpdateEmail updates the user's email
+// UpdateEmail updates the user's email. A new email has to be verified again.
 func (u *User) UpdateEmail(email string) {
+	if email != u.Email {
+		u.EmailVerifiedAt = nil
+	}
 	u.Email = email
 	u.UpdatedAt = time.Now().UTC()
 }
@@ -65,3 +93,121 @@ func (u *User) UpdateFullname(fullname string) {
 	u.Fullname = fullname
 	u.UpdatedAt = time.Now().UTC()
 }
+
+// MarkEmailVerified marks the user's email as verified
+func (u *User) MarkEmailVerified() {
+	now := time.Now().UTC()
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+// IsEmailVerified checks if the user's email has been verified
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// RevokeTokens invalidates every token issued to the user so far.
+// Tokens carry the token version of the user they were issued to, which is bumped here, as
+// issue times only have second precision and cannot tell apart tokens issued in the same second.
+func (u *User) RevokeTokens() {
+	now := time.Now().UTC()
+	u.TokensRevokedAt = &now
+	u.TokenVersion++
+	u.UpdatedAt = now
+}
+
+// IsTokenRevoked checks if a token issued with the given token version has been revoked
+func (u *User) IsTokenRevoked(tokenVersion int) bool {
+	return tokenVersion != u.TokenVersion
+}
+
+// UpdatePreferences updates the user's timezone and locale
+func (u *User) UpdatePreferences(timezone, locale string) error {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+	if !localePattern.MatchString(locale) {
+		return ErrInvalidLocale
+	}
+
+	u.Timezone = timezone
+	u.Locale = locale
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Location returns the user's timezone, falling back to UTC if it is unknown
+func (u *User) Location() *time.Location {
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ScheduleDeletion schedules the user's account to be deleted at the given time
+func (u *User) ScheduleDeletion(at time.Time) {
+	at = at.UTC()
+	u.DeletionScheduledAt = &at
+	u.UpdatedAt = time.Now().UTC()
+}
+
+// CancelDeletion cancels a scheduled deletion of the user's account
+func (u *User) CancelDeletion() {
+	u.DeletionScheduledAt = nil
+	u.UpdatedAt = time.Now().UTC()
+}
+
+// IsDeletionScheduled checks if the user's account is scheduled to be deleted
+func (u *User) IsDeletionScheduled() bool {
+	return u.DeletionScheduledAt != nil
+}
+
+// StartMFAEnrollment stores a new TOTP secret that becomes active once enrollment is confirmed
+func (u *User) StartMFAEnrollment(secret string) {
+	u.MFASecret = secret
+	u.MFAEnabledAt = nil
+	u.UpdatedAt = time.Now().UTC()
+}
+
+// EnableMFA enforces two-factor authentication with the enrolled secret
+func (u *User) EnableMFA() {
+	now := time.Now().UTC()
+	u.MFAEnabledAt = &now
+	u.UpdatedAt = now
+}
+
+// DisableMFA turns two-factor authentication off and forgets the secret
+func (u *User) DisableMFA() {
+	u.MFASecret = ""
+	u.MFAEnabledAt = nil
+	u.UpdatedAt = time.Now().UTC()
+}
+
+// IsMFAEnabled checks if the user has to pass two-factor authentication to log in
+func (u *User) IsMFAEnabled() bool {
+	return u.MFAEnabledAt != nil && u.MFASecret != ""
+}
+
+// Roles returns the roles of the user
+func (u *User) Roles() []string {
+	if u.Role == RoleAdmin {
+		return []string{RoleUser, RoleAdmin}
+	}
+	return []string{RoleUser}
+}
+
+// HasRole checks if the user has a role
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMFAPending checks if the user has started but not confirmed two-factor enrollment
+func (u *User) IsMFAPending() bool {
+	return u.MFAEnabledAt == nil && u.MFASecret != ""
+}
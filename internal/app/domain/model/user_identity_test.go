@@ -0,0 +1,37 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewOAuthState(t *testing.T) {
+	state, raw, err := NewOAuthState("google", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if state.StateHash != HashOAuthState(raw) {
+		t.Error("expected the stored hash to match the raw state")
+	}
+	if state.Provider != "google" {
+		t.Errorf("unexpected provider: %s", state.Provider)
+	}
+	// RFC 7636 requires verifiers of 43 to 128 characters
+	if len(state.CodeVerifier) < 43 || len(state.CodeVerifier) > 128 {
+		t.Errorf("unexpected code verifier length: %d", len(state.CodeVerifier))
+	}
+	if state.CodeVerifier == raw || state.Nonce == raw || state.Nonce == state.CodeVerifier {
+		t.Error("expected the state, verifier and nonce to be independent")
+	}
+}
+
+func TestOAuthStateCodeChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	state := &OAuthState{CodeVerifier: "dBjftJeZ4CVP-mJ92K9qiEThoSyJ0mU6ZHqXqYyCqbE"}
+
+	want := "BaaO-2mlWwsTBbscy2SkQn5Ta8fENFECSNq-tTI9Huc"
+	if got := state.CodeChallenge(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserUpdatePreferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		locale   string
+		wantErr  error
+	}{
+		{"valid", "Europe/Berlin", "de", nil},
+		{"valid with region", "America/Sao_Paulo", "pt-BR", nil},
+		{"unknown timezone", "Mars/Olympus", "en", ErrInvalidTimezone},
+		{"empty timezone", "", "en", ErrInvalidTimezone},
+		{"local timezone", "Local", "en", ErrInvalidTimezone},
+		{"invalid locale", "UTC", "english", ErrInvalidLocale},
+		{"lowercase region", "UTC", "pt-br", ErrInvalidLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Timezone: DefaultTimezone, Locale: DefaultLocale}
+
+			err := user.UpdatePreferences(tt.timezone, tt.locale)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil && (user.Timezone != tt.timezone || user.Locale != tt.locale) {
+				t.Errorf("unexpected preferences: %s %s", user.Timezone, user.Locale)
+			}
+			if tt.wantErr != nil && (user.Timezone != DefaultTimezone || user.Locale != DefaultLocale) {
+				t.Error("expected preferences to be unchanged")
+			}
+		})
+	}
+}
+
+func TestUserUpdateEmailResetsVerification(t *testing.T) {
+	user := &User{Email: "old@example.com"}
+	user.MarkEmailVerified()
+
+	user.UpdateEmail("old@example.com")
+	if !user.IsEmailVerified() {
+		t.Error("expected the same email to stay verified")
+	}
+
+	user.UpdateEmail("new@example.com")
+	if user.IsEmailVerified() {
+		t.Error("expected a new email to require verification")
+	}
+}
+
+func TestUserIsTokenRevoked(t *testing.T) {
+	user := &User{}
+	tokenVersion := user.TokenVersion
+
+	if user.IsTokenRevoked(tokenVersion) {
+		t.Error("expected tokens not to be revoked")
+	}
+
+	user.RevokeTokens()
+	if !user.IsTokenRevoked(tokenVersion) {
+		t.Error("expected an older token to be revoked")
+	}
+	if user.IsTokenRevoked(user.TokenVersion) {
+		t.Error("expected a token issued after the revocation to be valid")
+	}
+	if user.TokensRevokedAt == nil {
+		t.Error("expected the revocation time to be recorded")
+	}
+}
+
+func TestUserScheduleDeletion(t *testing.T) {
+	user := &User{}
+	at := time.Now().Add(30 * 24 * time.Hour)
+
+	user.ScheduleDeletion(at)
+	if !user.IsDeletionScheduled() || !user.DeletionScheduledAt.Equal(at) {
+		t.Errorf("expected deletion to be scheduled at %v, got %v", at, user.DeletionScheduledAt)
+	}
+
+	user.CancelDeletion()
+	if user.IsDeletionScheduled() {
+		t.Error("expected deletion to be cancelled")
+	}
+}
+
+func TestUserMFAEnrollment(t *testing.T) {
+	user := &User{}
+
+	user.StartMFAEnrollment("JBSWY3DPEHPK3PXP")
+	if !user.IsMFAPending() || user.IsMFAEnabled() {
+		t.Error("expected enrollment to be pending until confirmed")
+	}
+
+	user.EnableMFA()
+	if user.IsMFAPending() || !user.IsMFAEnabled() {
+		t.Error("expected 2FA to be enabled")
+	}
+
+	user.DisableMFA()
+	if user.IsMFAPending() || user.IsMFAEnabled() || user.MFASecret != "" {
+		t.Error("expected 2FA to be disabled and the secret forgotten")
+	}
+}
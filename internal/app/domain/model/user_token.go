@@ -0,0 +1,71 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTokenPurpose represents what a user token may be used for
+type UserTokenPurpose string
+
+const (
+	// User token purposes
+	UserTokenPurposeEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenPurposePasswordReset     UserTokenPurpose = "password_reset"
+)
+
+// userTokenBytes is the number of random bytes in a user token
+const userTokenBytes = 32
+
+// ErrInvalidUserToken is returned when a verification or reset token is unknown, used or expired
+var ErrInvalidUserToken = NewValidationError("invalid_user_token", "invalid or expired token")
+
+// UserToken represents a single-use token sent to a user by email.
+// Only the hash of the token is stored.
+type UserToken struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    uuid.UUID        `json:"user_id"`
+	Purpose   UserTokenPurpose `json:"purpose"`
+	TokenHash string           `json:"-"`
+	Email     string           `json:"email"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	UsedAt    *time.Time       `json:"used_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewUserToken creates a new user token for the given email address.
+// It returns the token together with the raw value to send to the user.
+func NewUserToken(userID uuid.UUID, purpose UserTokenPurpose, email string, ttl time.Duration) (*UserToken, string, error) {
+	buf := make([]byte, userTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := time.Now().UTC()
+	return &UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: HashUserToken(raw),
+		Email:     email,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, raw, nil
+}
+
+// HashUserToken returns the hex encoded SHA-256 hash of a raw user token
+func HashUserToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsable checks if the token has neither been used nor expired
+func (t *UserToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewUserToken(t *testing.T) {
+	userID := uuid.New()
+
+	token, raw, err := NewUserToken(userID, UserTokenPurposePasswordReset, "test@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if raw == "" {
+		t.Fatal("expected a raw token")
+	}
+	if token.TokenHash == raw {
+		t.Error("expected the raw token not to be stored")
+	}
+	if token.TokenHash != HashUserToken(raw) {
+		t.Error("expected the stored hash to match the raw token")
+	}
+	if token.UserID != userID || token.Purpose != UserTokenPurposePasswordReset || token.Email != "test@example.com" {
+		t.Errorf("unexpected token fields: %+v", token)
+	}
+
+	_, other, err := NewUserToken(userID, UserTokenPurposePasswordReset, "test@example.com", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == raw {
+		t.Error("expected tokens to be random")
+	}
+}
+
+func TestUserTokenIsUsable(t *testing.T) {
+	now := time.Now().UTC()
+	token := &UserToken{ExpiresAt: now.Add(time.Hour)}
+
+	if !token.IsUsable(now) {
+		t.Error("expected a fresh token to be usable")
+	}
+	if token.IsUsable(now.Add(2 * time.Hour)) {
+		t.Error("expected an expired token not to be usable")
+	}
+
+	token.UsedAt = &now
+	if token.IsUsable(now) {
+		t.Error("expected a used token not to be usable")
+	}
+}
@@ -0,0 +1,213 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidWorkflow is returned when a workflow definition is inconsistent
+	ErrInvalidWorkflow = NewValidationError("invalid_workflow", "invalid workflow")
+
+	// ErrUnknownStatus is returned when filtering by a status that is not part of the user's workflow
+	ErrUnknownStatus = NewValidationError("unknown_status", "status is not part of your workflow")
+)
+
+// statusPattern restricts custom statuses to short lowercase slugs
+var statusPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// Workflow defines the statuses a user's todos can be in and how they may move between them
+type Workflow struct {
+	UserID        uuid.UUID                   `json:"user_id"`
+	Statuses      []TodoStatus                `json:"statuses"`
+	InitialStatus TodoStatus                  `json:"initial_status"`
+	DoneStatuses  []TodoStatus                `json:"done_statuses"`
+	Transitions   map[TodoStatus][]TodoStatus `json:"transitions"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+}
+
+// StatusTransitionError is returned when a workflow does not allow a status change
+type StatusTransitionError struct {
+	From   TodoStatus
+	To     TodoStatus
+	Reason string
+}
+
+// Error implements the error interface
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change status from %q to %q: %s", e.From, e.To, e.Reason)
+}
+
+// ErrorCode returns the machine-readable code of the error
+func (e *StatusTransitionError) ErrorCode() string {
+	return "invalid_status_transition"
+}
+
+// Is makes the error a validation error
+func (e *StatusTransitionError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// NoDoneStatusError is returned when no done status of a workflow can be reached from a status
+type NoDoneStatusError struct {
+	From TodoStatus
+}
+
+// Error implements the error interface
+func (e *NoDoneStatusError) Error() string {
+	return fmt.Sprintf("cannot complete a todo in status %q: no done status of the workflow can be reached from it", e.From)
+}
+
+// ErrorCode returns the machine-readable code of the error
+func (e *NoDoneStatusError) ErrorCode() string {
+	return "no_done_status"
+}
+
+// Is makes the error a validation error
+func (e *NoDoneStatusError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// DefaultWorkflow returns the built-in workflow, which allows any change between the default statuses
+func DefaultWorkflow(userID uuid.UUID) *Workflow {
+	statuses := TodoStatuses()
+	transitions := make(map[TodoStatus][]TodoStatus, len(statuses))
+	for _, from := range statuses {
+		for _, to := range statuses {
+			if from != to {
+				transitions[from] = append(transitions[from], to)
+			}
+		}
+	}
+
+	return &Workflow{
+		UserID:        userID,
+		Statuses:      statuses,
+		InitialStatus: TodoStatusPending,
+		DoneStatuses:  []TodoStatus{TodoStatusCompleted, TodoStatusCancelled},
+		Transitions:   transitions,
+	}
+}
+
+// NewWorkflow creates a new custom workflow
+func NewWorkflow(userID uuid.UUID, statuses []TodoStatus, initialStatus TodoStatus, doneStatuses []TodoStatus, transitions map[TodoStatus][]TodoStatus) (*Workflow, error) {
+	now := time.Now().UTC()
+	workflow := &Workflow{
+		UserID:        userID,
+		Statuses:      statuses,
+		InitialStatus: initialStatus,
+		DoneStatuses:  doneStatuses,
+		Transitions:   transitions,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := workflow.Validate(); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// Validate checks that every status referenced by the workflow is declared exactly once
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return ErrInvalidWorkflow.Errorf("at least one status is required")
+	}
+
+	seen := make(map[TodoStatus]bool, len(w.Statuses))
+	for _, status := range w.Statuses {
+		if !statusPattern.MatchString(string(status)) {
+			return ErrInvalidWorkflow.Errorf("status %q must be a lowercase slug of at most 20 characters", status)
+		}
+		if seen[status] {
+			return ErrInvalidWorkflow.Errorf("status %q is declared twice", status)
+		}
+		seen[status] = true
+	}
+
+	if !seen[w.InitialStatus] {
+		return ErrInvalidWorkflow.Errorf("initial status %q is not declared", w.InitialStatus)
+	}
+
+	for _, status := range w.DoneStatuses {
+		if !seen[status] {
+			return ErrInvalidWorkflow.Errorf("done status %q is not declared", status)
+		}
+	}
+
+	for from, targets := range w.Transitions {
+		if !seen[from] {
+			return ErrInvalidWorkflow.Errorf("transition from undeclared status %q", from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return ErrInvalidWorkflow.Errorf("transition to undeclared status %q", to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// HasStatus checks if the status is part of the workflow
+func (w *Workflow) HasStatus(status TodoStatus) bool {
+	return containsStatus(w.Statuses, status)
+}
+
+// IsDone checks if the status counts as done in the workflow
+func (w *Workflow) IsDone(status TodoStatus) bool {
+	return containsStatus(w.DoneStatuses, status)
+}
+
+// IsInProgress checks if the status counts as started in the workflow, which is any status that is
+// neither the initial status nor a done status
+func (w *Workflow) IsInProgress(status TodoStatus) bool {
+	return w.HasStatus(status) && status != w.InitialStatus && !w.IsDone(status)
+}
+
+// CompletionStatus returns the first done status that can be reached from a status, or a
+// NoDoneStatusError if there is none
+func (w *Workflow) CompletionStatus(from TodoStatus) (TodoStatus, error) {
+	for _, status := range w.DoneStatuses {
+		if w.CanTransition(from, status) {
+			return status, nil
+		}
+	}
+	return "", &NoDoneStatusError{From: from}
+}
+
+// CanTransition checks if the workflow allows moving from one status to another
+func (w *Workflow) CanTransition(from, to TodoStatus) bool {
+	if from == to {
+		return w.HasStatus(to)
+	}
+	return containsStatus(w.Transitions[from], to)
+}
+
+// CheckTransition returns a StatusTransitionError if the workflow does not allow the status change
+func (w *Workflow) CheckTransition(from, to TodoStatus) error {
+	if !w.HasStatus(to) {
+		return &StatusTransitionError{From: from, To: to, Reason: "status is not part of the workflow"}
+	}
+
+	if !w.CanTransition(from, to) {
+		return &StatusTransitionError{From: from, To: to, Reason: "transition is not allowed by the workflow"}
+	}
+
+	return nil
+}
+
+// containsStatus checks if a status is in the list
+func containsStatus(statuses []TodoStatus, status TodoStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTeamWorkflow(t *testing.T) *Workflow {
+	t.Helper()
+
+	review := TodoStatus("review")
+	blocked := TodoStatus("blocked")
+
+	workflow, err := NewWorkflow(
+		uuid.New(),
+		[]TodoStatus{TodoStatusPending, TodoStatusInProgress, review, blocked, TodoStatusCompleted, TodoStatusCancelled},
+		TodoStatusPending,
+		[]TodoStatus{TodoStatusCompleted, TodoStatusCancelled},
+		map[TodoStatus][]TodoStatus{
+			TodoStatusPending:    {TodoStatusInProgress, TodoStatusCancelled},
+			TodoStatusInProgress: {review, blocked, TodoStatusCancelled},
+			review:               {TodoStatusInProgress, TodoStatusCompleted},
+			blocked:              {TodoStatusInProgress},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected workflow to be valid, got %v", err)
+	}
+
+	return workflow
+}
+
+func TestDefaultWorkflowAllowsAnyTransition(t *testing.T) {
+	workflow := DefaultWorkflow(uuid.New())
+
+	for _, from := range TodoStatuses() {
+		for _, to := range TodoStatuses() {
+			if !workflow.CanTransition(from, to) {
+				t.Errorf("Expected default workflow to allow %s -> %s", from, to)
+			}
+		}
+	}
+}
+
+func TestWorkflowTransitions(t *testing.T) {
+	workflow := newTeamWorkflow(t)
+	todo := NewTodo(workflow.UserID, "Test Todo", "This is a test todo", TodoPriorityMedium, nil)
+
+	// Test allowed transition
+	if err := todo.TransitionTo(TodoStatusInProgress, workflow); err != nil {
+		t.Errorf("Expected pending -> in_progress to be allowed, got %v", err)
+	}
+
+	// Test disallowed transition
+	todo.Status = TodoStatusCancelled
+	err := todo.TransitionTo(TodoStatusCompleted, workflow)
+	var transitionErr *StatusTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected StatusTransitionError, got %v", err)
+	}
+	if todo.Status != TodoStatusCancelled {
+		t.Errorf("Expected status to stay %s, got %s", TodoStatusCancelled, todo.Status)
+	}
+
+	// Test status outside of the workflow
+	if err := todo.TransitionTo(TodoStatus("archived"), workflow); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected StatusTransitionError for unknown status, got %v", err)
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	userID := uuid.New()
+
+	// Test initial status that is not declared
+	_, err := NewWorkflow(userID, []TodoStatus{TodoStatusPending}, TodoStatusInProgress, nil, nil)
+	if !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("Expected ErrInvalidWorkflow for undeclared initial status, got %v", err)
+	}
+
+	// Test duplicate status
+	_, err = NewWorkflow(userID, []TodoStatus{TodoStatusPending, TodoStatusPending}, TodoStatusPending, nil, nil)
+	if !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("Expected ErrInvalidWorkflow for duplicate status, got %v", err)
+	}
+
+	// Test transition to an undeclared status
+	_, err = NewWorkflow(userID, []TodoStatus{TodoStatusPending}, TodoStatusPending, nil, map[TodoStatus][]TodoStatus{
+		TodoStatusPending: {TodoStatusCompleted},
+	})
+	if !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("Expected ErrInvalidWorkflow for undeclared transition target, got %v", err)
+	}
+}
+
+func TestIsOverdueInCustomWorkflow(t *testing.T) {
+	workflow := newTeamWorkflow(t)
+	workflow.DoneStatuses = append(workflow.DoneStatuses, TodoStatus("review"))
+
+	pastDueDate := time.Now().UTC().Add(-24 * time.Hour)
+	todo := NewTodo(workflow.UserID, "Test Todo", "This is a test todo", TodoPriorityMedium, &pastDueDate)
+
+	if !todo.IsOverdueIn(workflow) {
+		t.Error("Expected pending todo with past due date to be overdue")
+	}
+
+	todo.Status = TodoStatus("review")
+	if todo.IsOverdueIn(workflow) {
+		t.Error("Expected todo in a custom done status to not be overdue")
+	}
+}
+
+func TestCompletedAtInCustomWorkflow(t *testing.T) {
+	workflow := newTeamWorkflow(t)
+	review := TodoStatus("review")
+	shipped := TodoStatus("shipped")
+	workflow.Statuses = append(workflow.Statuses, shipped)
+	workflow.DoneStatuses = append(workflow.DoneStatuses, shipped)
+	workflow.Transitions[review] = append(workflow.Transitions[review], shipped)
+
+	todo := NewTodo(workflow.UserID, "Test Todo", "This is a test todo", TodoPriorityMedium, nil)
+	todo.Status = review
+
+	if err := todo.TransitionTo(shipped, workflow); err != nil {
+		t.Fatalf("Expected transition to be allowed, got %v", err)
+	}
+	if todo.CompletedAt == nil {
+		t.Fatal("Expected completed at to be set for a custom done status")
+	}
+
+	completedAt := *todo.CompletedAt
+	todo.UpdateStatusIn(TodoStatusCompleted, workflow)
+	if todo.CompletedAt == nil || !todo.CompletedAt.Equal(completedAt) {
+		t.Errorf("Expected completed at to be kept between done statuses, got %v", todo.CompletedAt)
+	}
+
+	todo.UpdateStatusIn(review, workflow)
+	if todo.CompletedAt != nil {
+		t.Errorf("Expected completed at to be cleared, got %v", todo.CompletedAt)
+	}
+}
+
+func TestWorkflowCompletionStatus(t *testing.T) {
+	workflow := newTeamWorkflow(t)
+
+	tests := []struct {
+		from     TodoStatus
+		expected TodoStatus
+	}{
+		{from: TodoStatusPending, expected: TodoStatusCancelled},
+		{from: TodoStatus("review"), expected: TodoStatusCompleted},
+		{from: TodoStatusCompleted, expected: TodoStatusCompleted},
+	}
+	for _, tt := range tests {
+		status, err := workflow.CompletionStatus(tt.from)
+		if err != nil || status != tt.expected {
+			t.Errorf("Expected %s to complete to %s, got %q and %v", tt.from, tt.expected, status, err)
+		}
+	}
+
+	// Test status without a done status within reach
+	var noDoneErr *NoDoneStatusError
+	if _, err := workflow.CompletionStatus(TodoStatus("blocked")); !errors.As(err, &noDoneErr) || !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected NoDoneStatusError, got %v", err)
+	}
+
+	// Test workflow without the default statuses
+	done := TodoStatus("done")
+	custom, err := NewWorkflow(workflow.UserID, []TodoStatus{"todo", "doing", done}, "todo", []TodoStatus{done}, map[TodoStatus][]TodoStatus{
+		"todo":  {"doing"},
+		"doing": {done},
+	})
+	if err != nil {
+		t.Fatalf("Expected workflow to be valid, got %v", err)
+	}
+	if status, err := custom.CompletionStatus("doing"); err != nil || status != done {
+		t.Errorf("Expected doing to complete to %s, got %q and %v", done, status, err)
+	}
+}
+
+func TestWorkflowIsInProgress(t *testing.T) {
+	workflow := newTeamWorkflow(t)
+
+	for status, expected := range map[TodoStatus]bool{
+		TodoStatusPending:     false,
+		TodoStatusInProgress:  true,
+		TodoStatus("review"):  true,
+		TodoStatus("blocked"): true,
+		TodoStatusCompleted:   false,
+		TodoStatus("unknown"): false,
+	} {
+		if workflow.IsInProgress(status) != expected {
+			t.Errorf("Expected in progress for %s to be %t", status, expected)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// DependencyRepository defines the interface for todo dependency repository operations
+type DependencyRepository interface {
+	// Create creates a new dependency
+	Create(ctx context.Context, dependency *model.TodoDependency) error
+
+	// Delete deletes a dependency
+	Delete(ctx context.Context, todoID, blockerID uuid.UUID) error
+
+	// ListByUserID lists all dependencies between a user's todos
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.TodoDependency, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key repository operations
+type IdempotencyKeyRepository interface {
+	// Reserve saves a new idempotency key and reports whether it was saved. A key the user already
+	// has is only replaced if it expired, or if its request started before staleBefore and never completed.
+	Reserve(ctx context.Context, key *model.IdempotencyKey, staleBefore time.Time) (bool, error)
+
+	// Get gets one of a user's idempotency keys
+	Get(ctx context.Context, userID uuid.UUID, key string) (*model.IdempotencyKey, error)
+
+	// Complete saves the response of an idempotency key's request
+	Complete(ctx context.Context, key *model.IdempotencyKey) error
+
+	// Delete deletes one of a user's idempotency keys, so its request can be tried again
+	Delete(ctx context.Context, userID uuid.UUID, key string) error
+
+	// DeleteExpiredByUserID deletes a user's idempotency keys that expired before the given time
+	DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID, before time.Time) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// LoginThrottleRepository defines the interface for login throttle repository operations
+type LoginThrottleRepository interface {
+	// Get gets the throttle of a key
+	Get(ctx context.Context, key string) (*model.LoginThrottle, error)
+
+	// RecordFailure counts a failed login for a key and returns its throttle.
+	// Failures of a throttle that failed last before resetBefore and is not locked start over.
+	RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (*model.LoginThrottle, error)
+
+	// Lock locks a key until the given time
+	Lock(ctx context.Context, key string, until time.Time) error
+
+	// Delete forgets the failures of a key
+	Delete(ctx context.Context, key string) error
+
+	// DeleteStale deletes the unlocked throttles that failed last before the given time
+	DeleteStale(ctx context.Context, before, now time.Time) error
+}
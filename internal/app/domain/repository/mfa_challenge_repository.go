@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// MFAChallengeRepository defines the interface for MFA challenge repository operations
+type MFAChallengeRepository interface {
+	// Create creates a new MFA challenge
+	Create(ctx context.Context, challenge *model.MFAChallenge) error
+
+	// UseAttempt counts an attempt at an unexpired challenge of a user and returns the challenge.
+	// It returns ErrMFAChallengeNotFound once the challenge has no attempts left.
+	UseAttempt(ctx context.Context, userID, id uuid.UUID, now time.Time) (*model.MFAChallenge, error)
+
+	// Delete deletes a challenge, returning ErrMFAChallengeNotFound if it was already deleted
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteExpired deletes the challenges that expired before the given time
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// OAuthStateRepository defines the interface for OAuth state repository operations
+type OAuthStateRepository interface {
+	// Create creates a new OAuth state
+	Create(ctx context.Context, state *model.OAuthState) error
+
+	// Consume deletes an unexpired OAuth state and returns it
+	Consume(ctx context.Context, stateHash string, now time.Time) (*model.OAuthState, error)
+
+	// DeleteExpired deletes the OAuth states that expired before the given time
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
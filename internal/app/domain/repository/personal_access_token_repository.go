@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// PersonalAccessTokenRepository defines the interface for personal access token repository operations
+type PersonalAccessTokenRepository interface {
+	// Create creates a new personal access token
+	Create(ctx context.Context, token *model.PersonalAccessToken) error
+
+	// GetByHash gets a personal access token by the hash of its raw value
+	GetByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+
+	// GetByUserIDAndID gets a personal access token by user ID and token ID
+	GetByUserIDAndID(ctx context.Context, userID, id uuid.UUID) (*model.PersonalAccessToken, error)
+
+	// ListByUserID lists a user's personal access tokens, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.PersonalAccessToken, error)
+
+	// Delete deletes a user's personal access token
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+
+	// TouchLastUsed records when a personal access token was last used
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
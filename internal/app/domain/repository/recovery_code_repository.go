@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// RecoveryCodeRepository defines the interface for recovery code repository operations
+type RecoveryCodeRepository interface {
+	// Create creates a new recovery code
+	Create(ctx context.Context, code *model.RecoveryCode) error
+
+	// Consume marks an unused recovery code of a user as used
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) error
+
+	// DeleteByUserID deletes all recovery codes of a user
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// CountUnused counts the recovery codes a user has left
+	CountUnused(ctx context.Context, userID uuid.UUID) (int, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// SessionRepository defines the interface for session repository operations
+type SessionRepository interface {
+	// Create creates a new session
+	Create(ctx context.Context, session *model.Session) error
+
+	// ListActiveByUserID lists a user's active sessions, most recently seen first
+	ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*model.Session, error)
+
+	// Touch records that an active session was seen, failing if it has been revoked or has expired
+	Touch(ctx context.Context, userID, id uuid.UUID, now time.Time) error
+
+	// Revoke revokes one of a user's active sessions
+	Revoke(ctx context.Context, userID, id uuid.UUID, now time.Time) error
+
+	// RevokeAllByUserID revokes all of a user's active sessions
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, now time.Time) error
+
+	// DeleteExpiredByUserID deletes a user's sessions that expired before the given time
+	DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID, before time.Time) error
+}
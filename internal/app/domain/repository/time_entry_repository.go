@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// TimeEntryRepository defines the interface for time entry repository operations
+type TimeEntryRepository interface {
+	// Create creates a new time entry
+	Create(ctx context.Context, entry *model.TimeEntry) error
+
+	// GetByUserIDAndID gets a time entry by user ID and entry ID
+	GetByUserIDAndID(ctx context.Context, userID, entryID uuid.UUID) (*model.TimeEntry, error)
+
+	// GetRunningByUserID gets a user's running timer, or nil if none is running
+	GetRunningByUserID(ctx context.Context, userID uuid.UUID) (*model.TimeEntry, error)
+
+	// ListByTodoID lists the time entries of a todo
+	ListByTodoID(ctx context.Context, todoID uuid.UUID) ([]*model.TimeEntry, error)
+
+	// ListByUserID lists a user's time entries started within [from, to)
+	ListByUserID(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*model.TimeEntry, error)
+
+	// Timesheet aggregates a user's time entries started within [from, to) per day and todo
+	Timesheet(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*model.TimesheetRow, error)
+
+	// Update updates a time entry
+	Update(ctx context.Context, entry *model.TimeEntry) error
+
+	// Delete deletes a time entry
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -10,16 +10,19 @@ import (
 
 // TodoFilter defines the filter options for querying todos
 type TodoFilter struct {
-	UserID      *uuid.UUID
-	Status      *model.TodoStatus
-	Priority    *model.TodoPriority
-	DueDateFrom *time.Time
-	DueDateTo   *time.Time
-	Search      *string
-	Limit       int
-	Offset      int
-	SortBy      string
-	SortOrder   string
+	UserID         *uuid.UUID
+	Status         *model.TodoStatus
+	Priority       *model.TodoPriority
+	DueDateFrom    *time.Time
+	DueDateTo      *time.Time
+	Search         *string
+	DoneStatuses   []model.TodoStatus // Statuses that no longer block other todos
+	OnlyActionable bool               // Hide done todos and todos with open blockers
+	IncludeSnoozed bool               // Show todos whose snooze has not ended yet
+	Limit          int
+	Offset         int
+	SortBy         string
+	SortOrder      string
 }
 
 // TodoRepository defines the interface for todo repository operations
@@ -47,4 +50,19 @@ type TodoRepository interface {
 
 	// DeleteByUserID deletes all todos for a user
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// ListBlockers lists the todos blocking a todo
+	ListBlockers(ctx context.Context, todoID uuid.UUID, doneStatuses []model.TodoStatus) ([]*model.Todo, error)
+
+	// MaxPosition gets the highest position in a user's status column
+	MaxPosition(ctx context.Context, userID uuid.UUID, status model.TodoStatus) (float64, error)
+
+	// ListStatuses lists the distinct statuses in use by a user's todos
+	ListStatuses(ctx context.Context, userID uuid.UUID) ([]model.TodoStatus, error)
+
+	// WakeSnoozed clears the snooze of up to limit todos whose snooze ended at or before now
+	WakeSnoozed(ctx context.Context, now time.Time, limit int) ([]*model.Todo, error)
+
+	// RebalancePositions spreads the positions of a user's status column evenly
+	RebalancePositions(ctx context.Context, userID uuid.UUID, status model.TodoStatus) error
 }
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+)
+
+// IsolationLevel is the isolation level of a transaction
+type IsolationLevel int
+
+const (
+	// IsolationDefault uses the configured default isolation level
+	IsolationDefault IsolationLevel = iota
+	IsolationReadCommitted
+	IsolationRepeatableRead
+	IsolationSerializable
+)
+
+// TxOptions configures a transaction
+type TxOptions struct {
+	Isolation IsolationLevel
+	ReadOnly  bool
+}
+
+// TxManager defines the interface for running repository operations in a single transaction
+type TxManager interface {
+	// WithinTx runs fn in a transaction, committing if fn returns nil and rolling back otherwise.
+	// Repositories called with the context passed to fn take part in the transaction.
+	// A nested call runs in a savepoint of the outer transaction, so its failure only undoes its own changes.
+	// The outermost call runs fn again if the transaction fails to serialize, so fn must not have
+	// side effects outside the database.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// WithinTxOptions runs fn like WithinTx, in a transaction with the given options.
+	// A nested call cannot ask for a stricter isolation level than the outer transaction.
+	WithinTxOptions(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error
+}
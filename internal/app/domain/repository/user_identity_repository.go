@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// UserIdentityRepository defines the interface for user identity repository operations
+type UserIdentityRepository interface {
+	// Create creates a new user identity
+	Create(ctx context.Context, identity *model.UserIdentity) error
+
+	// GetByProviderAndSubject gets the identity a provider knows by the given subject
+	GetByProviderAndSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error)
+
+	// ListByUserID lists the identities linked to a user
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserIdentity, error)
+
+	// TouchLastLogin records a login through an identity
+	TouchLastLogin(ctx context.Context, id uuid.UUID, email string, now time.Time) error
+}
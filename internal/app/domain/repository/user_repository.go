@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
@@ -9,7 +10,7 @@ import (
 
 // UserRepository defines the interface for user repository operations
 type UserRepository interface {
-	// Create creates a new user
+	// Create creates a new user. It returns model.ErrEmailTaken if another user has the email.
 	Create(ctx context.Context, user *model.User) error
 
 	// GetByID gets a user by ID
@@ -18,12 +19,24 @@ type UserRepository interface {
 	// GetByEmail gets a user by email
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
 
-	// Update updates a user
+	// Update updates a user. It returns model.ErrEmailTaken if another user has the email.
 	Update(ctx context.Context, user *model.User) error
 
+	// UpdatePasswordHash replaces a user's password hash, unless it changed since oldHash was read
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
+
 	// Delete deletes a user
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Exists checks if a user exists by email
 	Exists(ctx context.Context, email string) (bool, error)
+
+	// ListDueForDeletion lists the IDs of at most limit users whose scheduled deletion is due at now
+	ListDueForDeletion(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+
+	// LockDueForDeletion locks a user for the rest of the transaction if their scheduled deletion is due at now
+	LockDueForDeletion(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+
+	// UseMFAStep records a TOTP time step as used and reports false if it or a later step was used before
+	UseMFAStep(ctx context.Context, id uuid.UUID, step int64) (bool, error)
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// UserTokenRepository defines the interface for user token repository operations
+type UserTokenRepository interface {
+	// Create creates a new user token
+	Create(ctx context.Context, token *model.UserToken) error
+
+	// Consume marks an unused, unexpired token as used and returns it
+	Consume(ctx context.Context, purpose model.UserTokenPurpose, tokenHash string, now time.Time) (*model.UserToken, error)
+
+	// InvalidateByUserID marks all of a user's unused tokens for a purpose as used
+	InvalidateByUserID(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose) error
+
+	// CountSince counts the tokens created for a user and purpose since the given time
+	CountSince(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose, since time.Time) (int, error)
+}
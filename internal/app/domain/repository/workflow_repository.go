@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// WorkflowRepository defines the interface for workflow repository operations
+type WorkflowRepository interface {
+	// GetByUserID gets a user's custom workflow, or nil if the user has none
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*model.Workflow, error)
+
+	// Save creates or replaces a user's custom workflow
+	Save(ctx context.Context, workflow *model.Workflow) error
+
+	// Delete deletes a user's custom workflow
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+const (
+	// MaxPersonalAccessTokens is the number of personal access tokens a user can have
+	MaxPersonalAccessTokens = 50
+
+	// tokenLastUsedPrecision is how stale the last use of a token may get before it is written again,
+	// so busy scripts do not write on every request
+	tokenLastUsedPrecision = time.Minute
+)
+
+// AccessTokenService provides personal access token functionality
+type AccessTokenService struct {
+	tokenRepo repository.PersonalAccessTokenRepository
+	userRepo  repository.UserRepository
+	logger    *logger.Logger
+}
+
+// NewAccessTokenService creates a new access token service
+func NewAccessTokenService(tokenRepo repository.PersonalAccessTokenRepository, userRepo repository.UserRepository, logger *logger.Logger) *AccessTokenService {
+	return &AccessTokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+	}
+}
+
+// CreateToken creates a personal access token and returns it with its raw value, which is only shown once
+func (s *AccessTokenService) CreateToken(ctx context.Context, userID uuid.UUID, name string, scopes []model.TokenScope, expiresAt *time.Time) (*model.PersonalAccessToken, string, error) {
+	existing, err := s.tokenRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list personal access tokens", "userID", userID, "error", err)
+		return nil, "", err
+	}
+
+	if len(existing) >= MaxPersonalAccessTokens {
+		return nil, "", model.ErrTooManyAccessTokens
+	}
+
+	token, raw, err := model.NewPersonalAccessToken(userID, name, scopes, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		s.logger.Error("Failed to save personal access token", "userID", userID, "error", err)
+		return nil, "", err
+	}
+
+	s.logger.Info("Personal access token created", "userID", userID, "tokenID", token.ID, "scopes", token.Scopes)
+	return token, raw, nil
+}
+
+// ListTokens lists a user's personal access tokens
+func (s *AccessTokenService) ListTokens(ctx context.Context, userID uuid.UUID) ([]*model.PersonalAccessToken, error) {
+	tokens, err := s.tokenRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list personal access tokens", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// GetToken gets one of a user's personal access tokens
+func (s *AccessTokenService) GetToken(ctx context.Context, userID, tokenID uuid.UUID) (*model.PersonalAccessToken, error) {
+	token, err := s.tokenRepo.GetByUserIDAndID(ctx, userID, tokenID)
+	if err != nil {
+		s.logger.Error("Failed to get personal access token", "userID", userID, "tokenID", tokenID, "error", err)
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// RevokeToken deletes one of a user's personal access tokens
+func (s *AccessTokenService) RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	if err := s.tokenRepo.Delete(ctx, userID, tokenID); err != nil {
+		s.logger.Error("Failed to revoke personal access token", "userID", userID, "tokenID", tokenID, "error", err)
+		return err
+	}
+
+	s.logger.Info("Personal access token revoked", "userID", userID, "tokenID", tokenID)
+	return nil
+}
+
+// Authenticate gets the user and token a raw personal access token belongs to, recording its use
+func (s *AccessTokenService) Authenticate(ctx context.Context, raw string) (*model.User, *model.PersonalAccessToken, error) {
+	token, err := s.tokenRepo.GetByHash(ctx, model.HashPersonalAccessToken(raw))
+	if err != nil {
+		if errors.Is(err, model.ErrAccessTokenNotFound) {
+			return nil, nil, ErrInvalidToken
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.IsExpired(now) {
+		return nil, nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenLastUsedPrecision {
+		// A failed write only makes the last use stale, so the request goes ahead
+		if err := s.tokenRepo.TouchLastUsed(ctx, token.ID, now); err != nil {
+			s.logger.Error("Failed to record personal access token use", "tokenID", token.ID, "error", err)
+		} else {
+			token.LastUsedAt = &now
+		}
+	}
+
+	return user, token, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// AccountPurgeBatchSize is the maximum number of accounts listed for deletion in a single query
+const AccountPurgeBatchSize = 100
+
+// AccountPurgeScheduler periodically deletes the accounts whose grace period has ended
+type AccountPurgeScheduler struct {
+	userRepo  repository.UserRepository
+	todoRepo  repository.TodoRepository
+	txManager repository.TxManager
+	interval  time.Duration
+	logger    *logger.Logger
+}
+
+// NewAccountPurgeScheduler creates a new account purge scheduler
+func NewAccountPurgeScheduler(userRepo repository.UserRepository, todoRepo repository.TodoRepository, txManager repository.TxManager, interval time.Duration, logger *logger.Logger) *AccountPurgeScheduler {
+	return &AccountPurgeScheduler{
+		userRepo:  userRepo,
+		todoRepo:  todoRepo,
+		txManager: txManager,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Start runs the scheduler until the context is cancelled
+func (s *AccountPurgeScheduler) Start(ctx context.Context) {
+	s.logger.Info("Account purge scheduler started", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := s.RunOnce(ctx); err != nil {
+			s.logger.Error("Failed to purge accounts", "error", err)
+		} else if purged > 0 {
+			s.logger.Info("Purged accounts", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Account purge scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce deletes every account whose scheduled deletion is due and returns how many were deleted
+func (s *AccountPurgeScheduler) RunOnce(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		now := time.Now().UTC()
+		userIDs, err := s.userRepo.ListDueForDeletion(ctx, now, AccountPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		deleted := 0
+		for _, userID := range userIDs {
+			ok, err := s.purge(ctx, userID, now)
+			if err != nil {
+				return purged, err
+			}
+			if ok {
+				deleted++
+			}
+		}
+
+		purged += deleted
+		// Stop when a batch is short, or when nothing in it could be deleted to avoid looping on the same rows
+		if len(userIDs) < AccountPurgeBatchSize || deleted == 0 || ctx.Err() != nil {
+			return purged, nil
+		}
+	}
+}
+
+// purge deletes a user and all of their data in a single transaction, if their deletion is still due.
+// It reports whether the account was deleted.
+func (s *AccountPurgeScheduler) purge(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	purged := false
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// The user may have cancelled the deletion since it was listed
+		due, err := s.userRepo.LockDueForDeletion(ctx, userID, now)
+		if err != nil || !due {
+			return err
+		}
+
+		if err := s.todoRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Delete(ctx, userID); err != nil {
+			return err
+		}
+
+		purged = true
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to purge account", "userID", userID, "error", err)
+		return false, err
+	}
+
+	if purged {
+		s.logger.Info("Account purged", "userID", userID)
+	}
+	return purged, nil
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// MaxPasswordResetsPerHour is the number of reset emails a user can receive per hour
+const MaxPasswordResetsPerHour = 3
+
+// passwordResetMailTimeout is how long issuing and mailing a password reset link may take
+const passwordResetMailTimeout = time.Minute
+
+// ErrEmailAlreadyVerified is returned when resending the verification email of a verified user
+var ErrEmailAlreadyVerified = model.NewConflictError("email_already_verified", "email is already verified")
+
+// AccountOptions holds the settings of the account service
+type AccountOptions struct {
+	AppURL               string
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+}
+
+// AccountService provides email verification and password recovery functionality
+type AccountService struct {
+	userRepo  repository.UserRepository
+	tokenRepo repository.UserTokenRepository
+	txManager repository.TxManager
+	passwords *PasswordService
+	sessions  *SessionService
+	mailer    Mailer
+	options   AccountOptions
+	logger    *logger.Logger
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(userRepo repository.UserRepository, tokenRepo repository.UserTokenRepository, txManager repository.TxManager, passwords *PasswordService, sessions *SessionService, mailer Mailer, options AccountOptions, logger *logger.Logger) *AccountService {
+	return &AccountService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		txManager: txManager,
+		passwords: passwords,
+		sessions:  sessions,
+		mailer:    mailer,
+		options:   options,
+		logger:    logger,
+	}
+}
+
+// SendVerificationEmail sends a user a link to verify their email, invalidating earlier links
+func (s *AccountService) SendVerificationEmail(ctx context.Context, user *model.User) error {
+	raw, err := s.issueToken(ctx, user, model.UserTokenPurposeEmailVerification, s.options.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	msg := MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease verify your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			user.Fullname, s.link("verify-email", raw), s.options.EmailVerificationTTL,
+		),
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error("Failed to send verification email", "userID", user.ID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail sends a new verification link to a user whose email is not verified yet
+func (s *AccountService) ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for verification email", "userID", userID, "error", err)
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	return s.SendVerificationEmail(ctx, user)
+}
+
+// VerifyEmail verifies the email a verification token was sent to
+func (s *AccountService) VerifyEmail(ctx context.Context, rawToken string) (*model.User, error) {
+	token, err := s.tokenRepo.Consume(ctx, model.UserTokenPurposeEmailVerification, model.HashUserToken(rawToken), time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get user for email verification", "userID", token.UserID, "error", err)
+		return nil, err
+	}
+
+	// The email changed after the token was sent
+	if !strings.EqualFold(user.Email, token.Email) {
+		return nil, model.ErrInvalidUserToken
+	}
+
+	user.MarkEmailVerified()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("Failed to mark email as verified", "userID", user.ID, "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ForgotPassword sends a password reset link to a registered email.
+// Unknown emails are silently ignored, so callers cannot tell which emails are registered.
+// The link is issued and mailed in the background, so the call takes as long for a
+// registered email as for an unknown one.
+func (s *AccountService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			s.logger.Info("Password reset requested for unknown email")
+			return nil
+		}
+		s.logger.Error("Failed to get user for password reset", "error", err)
+		return err
+	}
+
+	// Outlive the request, which ends before the email is sent
+	go s.sendPasswordReset(context.WithoutCancel(ctx), user)
+
+	return nil
+}
+
+// sendPasswordReset issues a password reset token and emails its link, unless the user
+// already received MaxPasswordResetsPerHour of them
+func (s *AccountService) sendPasswordReset(ctx context.Context, user *model.User) {
+	ctx, cancel := context.WithTimeout(ctx, passwordResetMailTimeout)
+	defer cancel()
+
+	sent, err := s.tokenRepo.CountSince(ctx, user.ID, model.UserTokenPurposePasswordReset, time.Now().UTC().Add(-time.Hour))
+	if err != nil {
+		s.logger.Error("Failed to count password reset tokens", "userID", user.ID, "error", err)
+		return
+	}
+
+	if sent >= MaxPasswordResetsPerHour {
+		s.logger.Warn("Password reset throttled", "userID", user.ID)
+		return
+	}
+
+	raw, err := s.issueToken(ctx, user, model.UserTokenPurposePasswordReset, s.options.PasswordResetTTL)
+	if err != nil {
+		return
+	}
+
+	msg := MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your account. If it was you, open the link below:\n\n%s\n\nThe link expires in %s. If you did not ask for a reset, you can ignore this email.\n",
+			user.Fullname, s.link("reset-password", raw), s.options.PasswordResetTTL,
+		),
+	}
+
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error("Failed to send password reset email", "userID", user.ID, "error", err)
+	}
+}
+
+// ResetPassword sets a new password with a reset token and signs the user out everywhere.
+// Consuming the token and setting the password run in one transaction, so a password the policy
+// rejects leaves the reset link usable.
+func (s *AccountService) ResetPassword(ctx context.Context, rawToken, password string) error {
+	var userID uuid.UUID
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		token, err := s.tokenRepo.Consume(ctx, model.UserTokenPurposePasswordReset, model.HashUserToken(rawToken), time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			s.logger.Error("Failed to get user for password reset", "userID", token.UserID, "error", err)
+			return err
+		}
+
+		if !strings.EqualFold(user.Email, token.Email) {
+			return model.ErrInvalidUserToken
+		}
+
+		if err := s.passwords.SetPassword(user, password); err != nil {
+			return err
+		}
+
+		// Receiving the reset email proves the user owns the address
+		if !user.IsEmailVerified() {
+			user.MarkEmailVerified()
+		}
+		user.RevokeTokens()
+
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			s.logger.Error("Failed to reset password", "userID", user.ID, "error", err)
+			return err
+		}
+
+		// Any other reset links still in flight are no longer needed
+		if err := s.tokenRepo.InvalidateByUserID(ctx, user.ID, model.UserTokenPurposePasswordReset); err != nil {
+			s.logger.Error("Failed to invalidate password reset tokens", "userID", user.ID, "error", err)
+			return err
+		}
+
+		userID = user.ID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sessions are revoked once the reset is committed, so the session cache cannot pick them up again before
+	return s.sessions.RevokeAllSessions(ctx, userID)
+}
+
+// issueToken invalidates a user's outstanding tokens for a purpose and creates a new one
+func (s *AccountService) issueToken(ctx context.Context, user *model.User, purpose model.UserTokenPurpose, ttl time.Duration) (string, error) {
+	if err := s.tokenRepo.InvalidateByUserID(ctx, user.ID, purpose); err != nil {
+		s.logger.Error("Failed to invalidate user tokens", "userID", user.ID, "purpose", purpose, "error", err)
+		return "", err
+	}
+
+	token, raw, err := model.NewUserToken(user.ID, purpose, user.Email, ttl)
+	if err != nil {
+		s.logger.Error("Failed to generate user token", "userID", user.ID, "error", err)
+		return "", err
+	}
+
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		s.logger.Error("Failed to save user token", "userID", user.ID, "error", err)
+		return "", err
+	}
+
+	return raw, nil
+}
+
+// link builds a link to a page of the app carrying a token
+func (s *AccountService) link(path, rawToken string) string {
+	return fmt.Sprintf("%s/%s?token=%s", strings.TrimRight(s.options.AppURL, "/"), path, url.QueryEscape(rawToken))
+}
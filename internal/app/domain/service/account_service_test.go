@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeUserRepository finds users by email or ID, leaving the other methods unimplemented
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*model.User
+}
+
+func (r *fakeUserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
+	user, exists := r.users[email]
+	if !exists {
+		return nil, model.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, model.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) Update(ctx context.Context, user *model.User) error {
+	return nil
+}
+
+// fakeUserTokenRepository keeps no tokens, leaving consuming them unimplemented
+type fakeUserTokenRepository struct {
+	repository.UserTokenRepository
+}
+
+func (r *fakeUserTokenRepository) Create(ctx context.Context, token *model.UserToken) error {
+	return nil
+}
+
+func (r *fakeUserTokenRepository) InvalidateByUserID(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose) error {
+	return nil
+}
+
+func (r *fakeUserTokenRepository) CountSince(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose, since time.Time) (int, error) {
+	return 0, nil
+}
+
+// blockingMailer hands emails over one at a time, once the test is ready to take them
+type blockingMailer struct {
+	sent chan MailMessage
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg MailMessage) error {
+	select {
+	case m.sent <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestForgotPasswordSendsInBackground(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Email: "user@example.com", Fullname: "User"}
+	mailer := &blockingMailer{sent: make(chan MailMessage)}
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	accountService := NewAccountService(
+		&fakeUserRepository{users: map[string]*model.User{user.Email: user}},
+		&fakeUserTokenRepository{},
+		nil,
+		nil,
+		nil,
+		mailer,
+		AccountOptions{AppURL: "http://localhost:3000", PasswordResetTTL: time.Hour},
+		log,
+	)
+
+	// The mailer blocks until the email is taken, so returning shows it is sent in the background
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, email := range []string{"unknown@example.com", user.Email} {
+		if err := accountService.ForgotPassword(ctx, email); err != nil {
+			t.Fatalf("unexpected error for %s: %v", email, err)
+		}
+	}
+
+	// The email outlives the request
+	cancel()
+
+	select {
+	case msg := <-mailer.sent:
+		if msg.To != user.Email || !strings.Contains(msg.Body, "/reset-password?token=") {
+			t.Errorf("unexpected email %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a password reset email")
+	}
+}
+
+// fakeResetTokenRepository holds a single password reset token
+type fakeResetTokenRepository struct {
+	repository.UserTokenRepository
+	token *model.UserToken
+}
+
+func (r *fakeResetTokenRepository) Consume(ctx context.Context, purpose model.UserTokenPurpose, tokenHash string, now time.Time) (*model.UserToken, error) {
+	if r.token.UsedAt != nil || r.token.Purpose != purpose || r.token.TokenHash != tokenHash {
+		return nil, model.ErrInvalidUserToken
+	}
+	r.token.UsedAt = &now
+	return r.token, nil
+}
+
+func (r *fakeResetTokenRepository) InvalidateByUserID(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose) error {
+	return nil
+}
+
+// tokenRollbackTxManager rolls back failed transactions by restoring the reset token they consumed
+type tokenRollbackTxManager struct {
+	tokens *fakeResetTokenRepository
+}
+
+func (m *tokenRollbackTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.WithinTxOptions(ctx, repository.TxOptions{}, fn)
+}
+
+func (m *tokenRollbackTxManager) WithinTxOptions(ctx context.Context, opts repository.TxOptions, fn func(ctx context.Context) error) error {
+	usedAt := m.tokens.token.UsedAt
+	if err := fn(ctx); err != nil {
+		m.tokens.token.UsedAt = usedAt
+		return err
+	}
+	return nil
+}
+
+func TestResetPasswordKeepsTokenForRejectedPassword(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Email: "jane@example.com", Fullname: "Jane Doe"}
+	token, raw, err := model.NewUserToken(user.ID, model.UserTokenPurposePasswordReset, user.Email, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	tokenRepo := &fakeResetTokenRepository{token: token}
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	sessions := NewSessionService(&fakeSessionRepository{sessions: map[uuid.UUID]*model.Session{}}, time.Minute, log)
+	accountService := NewAccountService(
+		&fakeUserRepository{users: map[string]*model.User{user.Email: user}},
+		tokenRepo,
+		&tokenRollbackTxManager{tokens: tokenRepo},
+		newTestPasswordService(t),
+		sessions,
+		nil,
+		AccountOptions{},
+		log,
+	)
+	ctx := context.Background()
+
+	// A password with the user's email breaks the policy, which leaves the link usable
+	if err := accountService.ResetPassword(ctx, raw, "jane@example.com!"); err == nil {
+		t.Fatal("expected the password to be rejected")
+	}
+	if err := accountService.ResetPassword(ctx, raw, "correct horse battery"); err != nil {
+		t.Fatalf("expected the reset to succeed, got %v", err)
+	}
+	if err := accountService.ResetPassword(ctx, raw, "another horse battery"); !errors.Is(err, model.ErrInvalidUserToken) {
+		t.Errorf("expected the used link to be invalid, got %v", err)
+	}
+}
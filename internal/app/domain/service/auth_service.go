@@ -5,156 +5,330 @@ import (
 	"errors"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/repository"
 	"github.com/sh1ro/todo-api/pkg/logger"
 )
 
+const (
+	// MFAChallengeTTL is how long a user has to pass two-factor authentication after their password
+	MFAChallengeTTL = 5 * time.Minute
+
+	// TokenPurposeMFAChallenge marks tokens that can only be exchanged at the MFA verification step
+	TokenPurposeMFAChallenge = "mfa_challenge"
+)
+
+var (
+	// ErrInvalidCredentials is returned when the email or password of a login is wrong
+	ErrInvalidCredentials = model.NewUnauthorizedError("invalid_credentials", "invalid email or password")
+
+	// ErrInvalidToken is returned for tokens that are malformed, expired or not meant for the request
+	ErrInvalidToken = model.NewUnauthorizedError("invalid_token", "invalid or expired token")
+
+	// ErrTokenRevoked is returned for tokens issued before the user's tokens were revoked
+	ErrTokenRevoked = model.NewUnauthorizedError("token_revoked", "token has been revoked")
+
+	// ErrInvalidMFAChallenge is returned for MFA challenge tokens that are invalid or no longer apply
+	ErrInvalidMFAChallenge = model.NewUnauthorizedError("invalid_mfa_challenge", "invalid or expired MFA challenge, please log in again")
+)
+
 // AuthService provides authentication related functionality
 type AuthService struct {
-	userRepo repository.UserRepository
-	logger   *logger.Logger
-	jwtKey   []byte
-	jwtExp   time.Duration
+	userRepo   repository.UserRepository
+	txManager  repository.TxManager
+	passwords  *PasswordService
+	throttle   *LoginThrottleService
+	sessions   *SessionService
+	challenges repository.MFAChallengeRepository
+	logger     *logger.Logger
+	issuer     TokenIssuer
+	verifier   TokenVerifier
+	jwtExp     time.Duration
+}
+
+// LoginResult represents the outcome of a password login. Users with 2FA enabled
+// get an MFA challenge token instead of an access token.
+type LoginResult struct {
+	User     *model.User
+	Token    string
+	MFAToken string
 }
 
-// JWTClaims represents the claims in a JWT token
-type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Fullname string `json:"fullname"`
-	jwt.RegisteredClaims
+// MFARequired checks if the login has to be completed with a second factor
+func (r *LoginResult) MFARequired() bool {
+	return r.MFAToken != ""
 }
 
 // NewAuthService creates a new authentication service
-func NewAuthService(userRepo repository.UserRepository, logger *logger.Logger, jwtSecret string, jwtExpiration time.Duration) *AuthService {
+func NewAuthService(userRepo repository.UserRepository, txManager repository.TxManager, passwords *PasswordService, throttle *LoginThrottleService, sessions *SessionService, challengeRepo repository.MFAChallengeRepository, logger *logger.Logger, issuer TokenIssuer, verifier TokenVerifier, jwtExpiration time.Duration) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
-		logger:   logger,
-		jwtKey:   []byte(jwtSecret),
-		jwtExp:   jwtExpiration,
+		userRepo:   userRepo,
+		txManager:  txManager,
+		passwords:  passwords,
+		throttle:   throttle,
+		sessions:   sessions,
+		challenges: challengeRepo,
+		logger:     logger,
+		issuer:     issuer,
+		verifier:   verifier,
+		jwtExp:     jwtExpiration,
 	}
 }
 
-// Register registers a new user
+// Register registers a new user. The email is checked and the user saved in a serializable
+// transaction, so concurrent registrations of the same email can't both succeed.
 func (s *AuthService) Register(ctx context.Context, fullname, email, password string) (*model.User, error) {
-	// Check if user already exists
-	exists, err := s.userRepo.Exists(ctx, email)
+	// Create new user, with a password that follows the policy
+	user, err := s.passwords.NewUser(fullname, email, password)
 	if err != nil {
-		s.logger.Error("Failed to check if user exists", "error", err)
 		return nil, err
 	}
 
-	if exists {
-		return nil, errors.New("user with this email already exists")
-	}
+	err = s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		// Check if user already exists
+		exists, err := s.userRepo.Exists(ctx, email)
+		if err != nil {
+			s.logger.Error("Failed to check if user exists", "error", err)
+			return err
+		}
 
-	// Create new user
-	user, err := model.NewUser(fullname, email, password)
-	if err != nil {
-		s.logger.Error("Failed to create user", "error", err)
-		return nil, err
-	}
+		if exists {
+			return model.ErrEmailTaken
+		}
+
+		// Save user to repository
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			s.logger.Error("Failed to save user", "error", err)
+			return err
+		}
 
-	// Save user to repository
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		s.logger.Error("Failed to save user", "error", err)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *AuthService) Login(ctx context.Context, email, password string) (*model.User, string, error) {
+// Login authenticates a user with their password. It returns a JWT token, or an MFA
+// challenge token to exchange at the verification step if the user has 2FA enabled.
+// Repeated failures for the email or from the client IP are throttled.
+func (s *AuthService) Login(ctx context.Context, email, password string, client model.ClientInfo) (*LoginResult, error) {
+	if err := s.throttle.Check(ctx, email, client.IP); err != nil {
+		return nil, err
+	}
+
 	// Find user by email
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
+		if !errors.Is(err, model.ErrUserNotFound) {
+			s.logger.Error("Failed to get user by email", "error", err)
+			return nil, err
+		}
+
+		// Spend as long as a wrong password would, so unknown emails cannot be told apart by timing
+		s.passwords.VerifyDummy(password)
 		s.logger.Error("User not found", "email", email)
-		return nil, "", errors.New("invalid credentials")
+		return nil, s.loginFailed(ctx, email, client.IP)
 	}
 
 	// Check password
-	if !user.CheckPassword(password) {
+	if !s.passwords.Verify(user, password) {
 		s.logger.Error("Invalid password", "email", email)
-		return nil,"", errors.New("invalid credentials")
+		return nil, s.loginFailed(ctx, email, client.IP)
+	}
+
+	// Rehash the password if the hashing algorithm or its cost changed
+	s.passwords.Upgrade(ctx, user, password)
+
+	// With 2FA enabled, the failures are only forgotten once the second factor checks out
+	if !user.IsMFAEnabled() {
+		if err := s.throttle.RecordSuccess(ctx, email); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.IssueLogin(ctx, user, client)
+}
+
+// IssueLogin completes the login of an authenticated user. It returns a JWT token, or an
+// MFA challenge token if the user has 2FA enabled, whichever way they proved who they are.
+func (s *AuthService) IssueLogin(ctx context.Context, user *model.User, client model.ClientInfo) (*LoginResult, error) {
+	// Ask for the second factor before handing out a token
+	if user.IsMFAEnabled() {
+		challenge, err := s.startMFAChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{MFAToken: challenge}, nil
 	}
 
-	// Generate JWT token
-	token, err := s.GenerateToken(user)
+	token, err := s.StartSession(ctx, user, client)
 	if err != nil {
-		s.logger.Error("Failed to generate token", "error", err)
-		return nil, "", err
+		return nil, err
 	}
 
-	return user, token, nil
+	return &LoginResult{User: user, Token: token}, nil
 }
 
-// GenerateToken generates a JWT token for a user
-func (s *AuthService) GenerateToken(user *model.User) (string, error) {
-	expirationTime := time.Now().Add(s.jwtExp)
+// startMFAChallenge records a new MFA challenge for a user and returns a token for it.
+// The token carries the challenge ID as its session ID.
+func (s *AuthService) startMFAChallenge(ctx context.Context, user *model.User) (string, error) {
+	challenge := model.NewMFAChallenge(user.ID, MFAChallengeTTL)
+	if err := s.challenges.Create(ctx, challenge); err != nil {
+		s.logger.Error("Failed to save MFA challenge", "error", err)
+		return "", err
+	}
 
-	claims := &JWTClaims{
-		UserID:   user.ID.String(),
-		Fullname: user.Fullname,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    "todo-api",
-			Subject:   user.ID.String(),
-		},
+	// Forget the challenges nobody completed
+	if err := s.challenges.DeleteExpired(ctx, challenge.CreatedAt); err != nil {
+		s.logger.Warn("Failed to delete expired MFA challenges", "error", err)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtKey)
+	token, err := s.generate(user, TokenPurposeMFAChallenge, challenge.ID, MFAChallengeTTL)
 	if err != nil {
+		s.logger.Error("Failed to generate MFA challenge", "error", err)
 		return "", err
 	}
 
-	return tokenString, nil
+	return token, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	claims := &JWTClaims{}
+// loginFailed records a failed login and returns the error to report it with
+func (s *AuthService) loginFailed(ctx context.Context, email, ip string) error {
+	if err := s.throttle.RecordFailure(ctx, email, ip); err != nil {
+		return err
+	}
+	return ErrInvalidCredentials
+}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return s.jwtKey, nil
-	})
+// StartSession records a new session for a user logging in from the given client
+// and returns a JWT token for it
+func (s *AuthService) StartSession(ctx context.Context, user *model.User, client model.ClientInfo) (string, error) {
+	session, err := s.sessions.Start(ctx, user.ID, client, s.jwtExp)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := s.generate(user, "", session.ID, s.jwtExp)
+	if err != nil {
+		s.logger.Error("Failed to generate token", "error", err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+// SessionUser gets the user a verified access token was issued to, as long as its session is active
+func (s *AuthService) SessionUser(ctx context.Context, claims *TokenClaims) (*model.User, error) {
+	// Tokens issued for a single purpose, such as MFA challenges, cannot be used as access tokens
+	if claims.Purpose != "" || claims.SessionID == uuid.Nil {
+		return nil, ErrInvalidToken
+	}
 
+	user, err := s.userFromClaims(ctx, claims)
 	if err != nil {
 		return nil, err
 	}
 
-	if !token.Valid {
-		return nil, errors.New("invalid token")
+	if err := s.sessions.Validate(ctx, user.ID, claims.SessionID); err != nil {
+		return nil, err
 	}
 
-	return claims, nil
+	return user, nil
 }
 
-// GetUserFromToken gets a user from a JWT token
-func (s *AuthService) GetUserFromToken(ctx context.Context, tokenString string) (*model.User, error) {
-	claims, err := s.ValidateToken(tokenString)
+// AttemptMFAChallenge validates an MFA challenge token and counts an attempt at its challenge.
+// It returns the user the token was issued to and the challenge. Challenges that used up their
+// attempts are invalid, and attempts for users whose logins are throttled have to wait.
+func (s *AuthService) AttemptMFAChallenge(ctx context.Context, tokenString string, client model.ClientInfo) (*model.User, *model.MFAChallenge, error) {
+	claims, err := s.verifier.Verify(tokenString)
+	if err != nil || claims.Purpose != TokenPurposeMFAChallenge {
+		return nil, nil, ErrInvalidMFAChallenge
+	}
+
+	user, err := s.userFromClaims(ctx, claims)
 	if err != nil {
-		return nil, err
+		return nil, nil, ErrInvalidMFAChallenge
 	}
 
-	userID, err := uuid.Parse(claims.UserID)
+	if err := s.throttle.Check(ctx, user.Email, client.IP); err != nil {
+		return nil, nil, err
+	}
+
+	challenge, err := s.challenges.UseAttempt(ctx, user.ID, claims.SessionID, time.Now().UTC())
 	if err != nil {
-		return nil, err
+		if errors.Is(err, model.ErrMFAChallengeNotFound) {
+			return nil, nil, ErrInvalidMFAChallenge
+		}
+		s.logger.Error("Failed to use MFA challenge attempt", "error", err)
+		return nil, nil, err
 	}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
+	return user, challenge, nil
+}
+
+// FailMFAChallenge records a wrong code for a challenge as a failed login of its user,
+// and invalidates the challenge once it has no attempts left
+func (s *AuthService) FailMFAChallenge(ctx context.Context, user *model.User, challenge *model.MFAChallenge, client model.ClientInfo) error {
+	if challenge.AttemptsLeft() == 0 {
+		if err := s.challenges.Delete(ctx, challenge.ID); err != nil && !errors.Is(err, model.ErrMFAChallengeNotFound) {
+			s.logger.Error("Failed to delete MFA challenge", "error", err)
+			return err
+		}
+	}
+
+	return s.throttle.RecordFailure(ctx, user.Email, client.IP)
+}
+
+// CompleteMFAChallenge deletes a challenge whose code checked out, so it cannot be used again,
+// and starts a session for its user. It returns a JWT token for the session.
+func (s *AuthService) CompleteMFAChallenge(ctx context.Context, user *model.User, challenge *model.MFAChallenge, client model.ClientInfo) (string, error) {
+	if err := s.challenges.Delete(ctx, challenge.ID); err != nil {
+		if errors.Is(err, model.ErrMFAChallengeNotFound) {
+			return "", ErrInvalidMFAChallenge
+		}
+		s.logger.Error("Failed to delete MFA challenge", "error", err)
+		return "", err
+	}
+
+	if err := s.throttle.RecordSuccess(ctx, user.Email); err != nil {
+		return "", err
+	}
+
+	return s.StartSession(ctx, user, client)
+}
+
+// userFromClaims gets the user a token was issued to, unless the token has been revoked
+func (s *AuthService) userFromClaims(ctx context.Context, claims *TokenClaims) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Tokens issued before the user's tokens were revoked, e.g. by a password reset, are no longer valid
+	if user.IsTokenRevoked(claims.TokenVersion) {
+		return nil, ErrTokenRevoked
+	}
+
 	return user, nil
 }
 
+// generate issues a token for a user with an optional purpose and session
+func (s *AuthService) generate(user *model.User, purpose string, sessionID uuid.UUID, ttl time.Duration) (string, error) {
+	return s.issuer.Issue(TokenClaims{
+		UserID:       user.ID,
+		Email:        user.Email,
+		Fullname:     user.Fullname,
+		Roles:        user.Roles(),
+		Purpose:      purpose,
+		SessionID:    sessionID,
+		TokenVersion: user.TokenVersion,
+	}, ttl)
+}
+
 func (s *AuthService) GetUserFromId(ctx context.Context, id string) (*model.User, error) {
 	userID, err := uuid.Parse(id)
 	if err != nil {
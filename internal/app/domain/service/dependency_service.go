@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// DependencyService provides todo dependency related functionality
+type DependencyService struct {
+	todoRepo        repository.TodoRepository
+	dependencyRepo  repository.DependencyRepository
+	txManager       repository.TxManager
+	workflowService *WorkflowService
+	logger          *logger.Logger
+}
+
+// NewDependencyService creates a new dependency service
+func NewDependencyService(todoRepo repository.TodoRepository, dependencyRepo repository.DependencyRepository, txManager repository.TxManager, workflowService *WorkflowService, logger *logger.Logger) *DependencyService {
+	return &DependencyService{
+		todoRepo:        todoRepo,
+		dependencyRepo:  dependencyRepo,
+		txManager:       txManager,
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// ListBlockers lists the todos blocking a user's todo
+func (s *DependencyService) ListBlockers(ctx context.Context, userID, todoID uuid.UUID) ([]*model.Todo, error) {
+	if _, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID); err != nil {
+		s.logger.Error("Failed to get todo for blockers", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers, err := s.todoRepo.ListBlockers(ctx, todoID, workflow.DoneStatuses)
+	if err != nil {
+		s.logger.Error("Failed to list blockers", "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	return blockers, nil
+}
+
+// AddBlocker marks a user's todo as blocked by another of their todos.
+// The cycle check and the insert run in one serializable transaction, so two concurrent
+// calls cannot each add half of a cycle.
+func (s *DependencyService) AddBlocker(ctx context.Context, userID, todoID, blockerID uuid.UUID) (*model.TodoDependency, error) {
+	var dependency *model.TodoDependency
+	err := s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		if _, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID); err != nil {
+			s.logger.Error("Failed to get todo for blocker", "userID", userID, "todoID", todoID, "error", err)
+			return err
+		}
+
+		if _, err := s.todoRepo.GetByUserIDAndID(ctx, userID, blockerID); err != nil {
+			if errors.Is(err, model.ErrTodoNotFound) {
+				return model.ErrBlockerNotFound
+			}
+			s.logger.Error("Failed to get blocker todo", "userID", userID, "blockerID", blockerID, "error", err)
+			return err
+		}
+
+		var err error
+		dependency, err = model.NewTodoDependency(todoID, blockerID)
+		if err != nil {
+			return err
+		}
+
+		edges, err := s.dependencyRepo.ListByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Error("Failed to list dependencies", "userID", userID, "error", err)
+			return err
+		}
+
+		if model.WouldCreateCycle(edges, todoID, blockerID) {
+			return model.ErrDependencyCycle
+		}
+
+		if err := s.dependencyRepo.Create(ctx, dependency); err != nil {
+			s.logger.Error("Failed to create dependency", "todoID", todoID, "blockerID", blockerID, "error", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dependency, nil
+}
+
+// RemoveBlocker removes a blocker from a user's todo
+func (s *DependencyService) RemoveBlocker(ctx context.Context, userID, todoID, blockerID uuid.UUID) error {
+	if _, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID); err != nil {
+		s.logger.Error("Failed to get todo for blocker removal", "userID", userID, "todoID", todoID, "error", err)
+		return err
+	}
+
+	if err := s.dependencyRepo.Delete(ctx, todoID, blockerID); err != nil {
+		s.logger.Error("Failed to delete dependency", "todoID", todoID, "blockerID", blockerID, "error", err)
+		return err
+	}
+
+	return nil
+}
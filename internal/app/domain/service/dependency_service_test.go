@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// serialTxManager runs transactions one at a time, which is how serializable transactions
+// are guaranteed to appear to run. It records the isolation levels asked for.
+type serialTxManager struct {
+	mu         sync.Mutex
+	isolations []repository.IsolationLevel
+}
+
+func (m *serialTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.WithinTxOptions(ctx, repository.TxOptions{}, fn)
+}
+
+func (m *serialTxManager) WithinTxOptions(ctx context.Context, opts repository.TxOptions, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.isolations = append(m.isolations, opts.Isolation)
+	return fn(ctx)
+}
+
+// fakeTodoRepository finds the todos of a user, leaving the other methods unimplemented
+type fakeTodoRepository struct {
+	repository.TodoRepository
+	todos map[uuid.UUID]*model.Todo
+}
+
+func (r *fakeTodoRepository) GetByUserIDAndID(ctx context.Context, userID, id uuid.UUID) (*model.Todo, error) {
+	todo, exists := r.todos[id]
+	if !exists || todo.UserID != userID {
+		return nil, model.ErrTodoNotFound
+	}
+	return todo, nil
+}
+
+func (r *fakeTodoRepository) ListStatuses(ctx context.Context, userID uuid.UUID) ([]model.TodoStatus, error) {
+	var statuses []model.TodoStatus
+	for _, todo := range r.todos {
+		if todo.UserID == userID {
+			statuses = append(statuses, todo.Status)
+		}
+	}
+	return statuses, nil
+}
+
+// fakeDependencyRepository keeps dependencies in memory. Listing them is slow, so concurrent
+// cycle checks overlap unless they run in a transaction.
+type fakeDependencyRepository struct {
+	mu           sync.Mutex
+	dependencies []*model.TodoDependency
+}
+
+func (r *fakeDependencyRepository) Create(ctx context.Context, dependency *model.TodoDependency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dependencies = append(r.dependencies, dependency)
+	return nil
+}
+
+func (r *fakeDependencyRepository) Delete(ctx context.Context, todoID, blockerID uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeDependencyRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.TodoDependency, error) {
+	r.mu.Lock()
+	dependencies := append([]*model.TodoDependency(nil), r.dependencies...)
+	r.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+	return dependencies, nil
+}
+
+func TestAddBlockerConcurrentCycle(t *testing.T) {
+	userID := uuid.New()
+	a := model.NewTodo(userID, "A", "", model.TodoPriorityMedium, nil)
+	b := model.NewTodo(userID, "B", "", model.TodoPriorityMedium, nil)
+
+	todoRepo := &fakeTodoRepository{todos: map[uuid.UUID]*model.Todo{a.ID: a, b.ID: b}}
+	dependencyRepo := &fakeDependencyRepository{}
+	txManager := &serialTxManager{}
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	dependencyService := NewDependencyService(todoRepo, dependencyRepo, txManager, nil, log)
+
+	// A is blocked by B and B by A at the same time
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, pair := range [][2]uuid.UUID{{a.ID, b.ID}, {b.ID, a.ID}} {
+		i, pair := i, pair
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = dependencyService.AddBlocker(context.Background(), userID, pair[0], pair[1])
+		}()
+	}
+	wg.Wait()
+
+	cycles := 0
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, model.ErrDependencyCycle):
+			cycles++
+		case err != nil:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if cycles != 1 || len(dependencyRepo.dependencies) != 1 {
+		t.Fatalf("expected one dependency and one cycle error, got %d dependencies and %d cycle errors", len(dependencyRepo.dependencies), cycles)
+	}
+
+	for _, isolation := range txManager.isolations {
+		if isolation != repository.IsolationSerializable {
+			t.Errorf("expected serializable transactions, got isolation %d", isolation)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// IdempotencyLockTimeout is how long a request may run before a repeat of it can take over its
+// idempotency key, so a key is not stuck if the instance running the request went away
+const IdempotencyLockTimeout = time.Minute
+
+// IdempotencyService makes requests sent with an idempotency key run at most once per key,
+// replaying the response of the first request for its repeats
+type IdempotencyService struct {
+	keyRepo repository.IdempotencyKeyRepository
+	ttl     time.Duration
+	logger  *logger.Logger
+}
+
+// NewIdempotencyService creates a new idempotency service that keeps keys for ttl
+func NewIdempotencyService(keyRepo repository.IdempotencyKeyRepository, ttl time.Duration, logger *logger.Logger) *IdempotencyService {
+	return &IdempotencyService{
+		keyRepo: keyRepo,
+		ttl:     ttl,
+		logger:  logger,
+	}
+}
+
+// Begin starts a request sent with an idempotency key. If the request should run, it returns the
+// reserved key to complete or release once it is done. If it is a repeat of a completed request,
+// it returns that request's key and true, and its response is to be sent instead.
+func (s *IdempotencyService) Begin(ctx context.Context, userID uuid.UUID, key, fingerprint string) (*model.IdempotencyKey, bool, error) {
+	record, err := model.NewIdempotencyKey(userID, key, fingerprint, s.ttl)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// A failed cleanup only leaves expired rows behind, so the request goes ahead
+	if err := s.keyRepo.DeleteExpiredByUserID(ctx, userID, record.CreatedAt); err != nil {
+		s.logger.Error("Failed to delete expired idempotency keys", "userID", userID, "error", err)
+	}
+
+	reserved, err := s.keyRepo.Reserve(ctx, record, record.CreatedAt.Add(-IdempotencyLockTimeout))
+	if err != nil {
+		s.logger.Error("Failed to reserve idempotency key", "userID", userID, "error", err)
+		return nil, false, err
+	}
+	if reserved {
+		return record, false, nil
+	}
+
+	existing, err := s.keyRepo.Get(ctx, userID, key)
+	if err != nil {
+		// The first request failed and released the key since it was reserved
+		if errors.Is(err, model.ErrIdempotencyKeyNotFound) {
+			return nil, false, model.ErrIdempotencyKeyInProgress
+		}
+		s.logger.Error("Failed to get idempotency key", "userID", userID, "error", err)
+		return nil, false, err
+	}
+
+	if !existing.Matches(fingerprint) {
+		return nil, false, model.ErrIdempotencyKeyReused
+	}
+	if !existing.IsCompleted() {
+		return nil, false, model.ErrIdempotencyKeyInProgress
+	}
+
+	return existing, true, nil
+}
+
+// Complete saves the response of a request, to be replayed for its repeats
+func (s *IdempotencyService) Complete(ctx context.Context, record *model.IdempotencyKey, statusCode int, contentType string, body []byte) error {
+	record.Complete(statusCode, contentType, body)
+
+	if err := s.keyRepo.Complete(ctx, record); err != nil {
+		s.logger.Error("Failed to complete idempotency key", "userID", record.UserID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// Release forgets the key of a request that failed, so it can be tried again with the same key
+func (s *IdempotencyService) Release(ctx context.Context, record *model.IdempotencyKey) error {
+	if err := s.keyRepo.Delete(ctx, record.UserID, record.Key); err != nil {
+		s.logger.Error("Failed to release idempotency key", "userID", record.UserID, "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// IdentityProvider is an external identity provider users can log in with,
+// through the OAuth2 authorization code flow with PKCE
+type IdentityProvider interface {
+	// Name returns the name the provider is addressed by in routes and stored identities
+	Name() string
+
+	// AuthCodeURL returns the URL to send the user to for authorization
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange exchanges an authorization code for the identity of the user who granted it.
+	// Providers that issue ID tokens must verify them, including the nonce.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/metrics"
+)
+
+const (
+	// loginFailureResetAfter is how long failures are remembered without another one
+	loginFailureResetAfter = time.Hour
+
+	// loginBaseDelay is the delay after the last free failure, doubled by each further failure
+	loginBaseDelay = time.Second
+
+	// loginMaxDelay caps the delay between attempts before the lockout
+	loginMaxDelay = 30 * time.Second
+
+	// Free failures per email and per client IP; a shared IP, such as an office NAT, gets more
+	loginEmailFreeFailures = 3
+	loginIPFreeFailures    = 20
+)
+
+// LoginThrottleOptions configures when logins are locked out
+type LoginThrottleOptions struct {
+	EmailMaxFailures int
+	IPMaxFailures    int
+	LockoutDuration  time.Duration
+}
+
+// LoginThrottledError is returned when a login has to wait after too many failures
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *LoginThrottledError) Error() string {
+	return "too many login attempts"
+}
+
+// LoginThrottleService tracks failed password logins per email and per client IP,
+// delaying further attempts progressively and locking them out temporarily
+type LoginThrottleService struct {
+	throttleRepo repository.LoginThrottleRepository
+	emailPolicy  model.LoginThrottlePolicy
+	ipPolicy     model.LoginThrottlePolicy
+	logger       *logger.Logger
+}
+
+// NewLoginThrottleService creates a new login throttle service
+func NewLoginThrottleService(throttleRepo repository.LoginThrottleRepository, opts LoginThrottleOptions, logger *logger.Logger) *LoginThrottleService {
+	return &LoginThrottleService{
+		throttleRepo: throttleRepo,
+		emailPolicy: model.LoginThrottlePolicy{
+			FreeFailures:    loginEmailFreeFailures,
+			BaseDelay:       loginBaseDelay,
+			MaxDelay:        loginMaxDelay,
+			MaxFailures:     opts.EmailMaxFailures,
+			LockoutDuration: opts.LockoutDuration,
+			ResetAfter:      loginFailureResetAfter,
+		},
+		ipPolicy: model.LoginThrottlePolicy{
+			FreeFailures:    loginIPFreeFailures,
+			BaseDelay:       loginBaseDelay,
+			MaxDelay:        loginMaxDelay,
+			MaxFailures:     opts.IPMaxFailures,
+			LockoutDuration: opts.LockoutDuration,
+			ResetAfter:      loginFailureResetAfter,
+		},
+		logger: logger,
+	}
+}
+
+// Check returns a LoginThrottledError if a login for the email from the IP has to wait
+func (s *LoginThrottleService) Check(ctx context.Context, email, ip string) error {
+	now := time.Now().UTC()
+
+	var wait time.Duration
+	for _, check := range []struct {
+		key    string
+		policy model.LoginThrottlePolicy
+	}{
+		{model.LoginThrottleKeyForEmail(email), s.emailPolicy},
+		{model.LoginThrottleKeyForIP(ip), s.ipPolicy},
+	} {
+		throttle, err := s.throttleRepo.Get(ctx, check.key)
+		if err != nil {
+			if errors.Is(err, model.ErrLoginThrottleNotFound) {
+				continue
+			}
+			s.logger.Error("Failed to get login throttle", "error", err)
+			return err
+		}
+
+		if w := check.policy.RetryAfter(throttle, now); w > wait {
+			wait = w
+		}
+	}
+
+	if wait > 0 {
+		metrics.RecordLoginAttempt(metrics.LoginResultThrottled)
+		return &LoginThrottledError{RetryAfter: wait}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login for the email and the IP, locking out either that reaches its limit
+func (s *LoginThrottleService) RecordFailure(ctx context.Context, email, ip string) error {
+	metrics.RecordLoginAttempt(metrics.LoginResultFailure)
+	now := time.Now().UTC()
+
+	if err := s.recordFailure(ctx, model.LoginThrottleKeyForEmail(email), "email", s.emailPolicy, now); err != nil {
+		return err
+	}
+	if err := s.recordFailure(ctx, model.LoginThrottleKeyForIP(ip), "ip", s.ipPolicy, now); err != nil {
+		return err
+	}
+
+	// Forget failures nobody has repeated in a while
+	if err := s.throttleRepo.DeleteStale(ctx, now.Add(-loginFailureResetAfter), now); err != nil {
+		s.logger.Warn("Failed to delete stale login throttles", "error", err)
+	}
+
+	return nil
+}
+
+// RecordSuccess forgets the failures of an email after a successful login.
+// Failures of the IP are kept, so one valid account cannot reset them.
+func (s *LoginThrottleService) RecordSuccess(ctx context.Context, email string) error {
+	metrics.RecordLoginAttempt(metrics.LoginResultSuccess)
+
+	if err := s.throttleRepo.Delete(ctx, model.LoginThrottleKeyForEmail(email)); err != nil {
+		s.logger.Error("Failed to reset login throttle", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// Unlock forgets the failures of an email or client IP, lifting any lockout
+func (s *LoginThrottleService) Unlock(ctx context.Context, email, ip string) error {
+	keys := []string{}
+	if email != "" {
+		keys = append(keys, model.LoginThrottleKeyForEmail(email))
+	}
+	if ip != "" {
+		keys = append(keys, model.LoginThrottleKeyForIP(ip))
+	}
+
+	for _, key := range keys {
+		if err := s.throttleRepo.Delete(ctx, key); err != nil {
+			s.logger.Error("Failed to unlock login", "error", err)
+			return err
+		}
+	}
+
+	s.logger.Info("Unlocked login", "email", email, "ip", ip)
+	return nil
+}
+
+// recordFailure counts a failure for a key and locks it if it reached its limit
+func (s *LoginThrottleService) recordFailure(ctx context.Context, key, scope string, policy model.LoginThrottlePolicy, now time.Time) error {
+	throttle, err := s.throttleRepo.RecordFailure(ctx, key, now, now.Add(-policy.ResetAfter))
+	if err != nil {
+		s.logger.Error("Failed to record login failure", "error", err)
+		return err
+	}
+
+	alreadyLocked := throttle.LockedUntil != nil && now.Before(*throttle.LockedUntil)
+	if alreadyLocked || !policy.ShouldLock(throttle) {
+		return nil
+	}
+
+	if err := s.throttleRepo.Lock(ctx, key, now.Add(policy.LockoutDuration)); err != nil {
+		s.logger.Error("Failed to lock login", "error", err)
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	metrics.RecordLoginLockout(scope)
+	s.logger.Warn("Locked out login after repeated failures", "scope", scope, "failures", throttle.Failures)
+	return nil
+}
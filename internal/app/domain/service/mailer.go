@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+)
+
+// MailMessage represents a plain text email
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer defines the interface for sending email
+type Mailer interface {
+	// Send sends an email
+	Send(ctx context.Context, msg MailMessage) error
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/totp"
+)
+
+// MFAClockSkew is the number of TOTP steps a code may be off by in either direction
+const MFAClockSkew = 1
+
+var (
+	// ErrMFAAlreadyEnabled is returned when enrolling a user who already uses two-factor authentication
+	ErrMFAAlreadyEnabled = model.NewConflictError("mfa_already_enabled", "two-factor authentication is already enabled")
+
+	// ErrMFANotEnabled is returned when disabling two-factor authentication for a user who does not use it
+	ErrMFANotEnabled = model.NewConflictError("mfa_not_enabled", "two-factor authentication is not enabled")
+
+	// ErrMFAEnrollmentNotStarted is returned when confirming an enrollment that was never started
+	ErrMFAEnrollmentNotStarted = model.NewConflictError("mfa_enrollment_not_started", "two-factor enrollment has not been started")
+
+	// ErrInvalidMFACode is returned for wrong, reused or expired TOTP and recovery codes
+	ErrInvalidMFACode = model.NewValidationError("invalid_mfa_code", "invalid authentication code")
+
+	// ErrMFALoginFailed is returned for a wrong code at the MFA step of a login
+	ErrMFALoginFailed = model.NewUnauthorizedError("invalid_mfa_code", "invalid authentication code")
+)
+
+// MFAEnrollment holds what an authenticator app needs to generate codes for a user
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// MFAService provides TOTP two-factor authentication functionality
+type MFAService struct {
+	userRepo     repository.UserRepository
+	recoveryRepo repository.RecoveryCodeRepository
+	txManager    repository.TxManager
+	passwords    *PasswordService
+	authService  *AuthService
+	issuer       string
+	logger       *logger.Logger
+}
+
+// NewMFAService creates a new MFA service. The issuer is the name authenticator apps show for the account.
+func NewMFAService(userRepo repository.UserRepository, recoveryRepo repository.RecoveryCodeRepository, txManager repository.TxManager, passwords *PasswordService, authService *AuthService, issuer string, logger *logger.Logger) *MFAService {
+	return &MFAService{
+		userRepo:     userRepo,
+		recoveryRepo: recoveryRepo,
+		txManager:    txManager,
+		passwords:    passwords,
+		authService:  authService,
+		issuer:       issuer,
+		logger:       logger,
+	}
+}
+
+// StartEnrollment generates a new TOTP secret for a user. It is only enforced once confirmed with a code.
+func (s *MFAService) StartEnrollment(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for MFA enrollment", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if user.IsMFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		s.logger.Error("Failed to generate TOTP secret", "error", err)
+		return nil, err
+	}
+
+	user.StartMFAEnrollment(secret)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("Failed to start MFA enrollment", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables 2FA once the user proves their authenticator works, and returns
+// recovery codes. The codes are only stored hashed, so this is the only time they are shown.
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for MFA confirmation", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if !user.IsMFAPending() {
+		return nil, ErrMFAEnrollmentNotStarted
+	}
+
+	if err := s.verifyTOTP(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, raws, err := model.NewRecoveryCodes(user.ID, model.RecoveryCodeCount)
+	if err != nil {
+		s.logger.Error("Failed to generate recovery codes", "error", err)
+		return nil, err
+	}
+
+	user.EnableMFA()
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.replaceRecoveryCodes(ctx, user.ID, codes)
+	})
+	if err != nil {
+		s.logger.Error("Failed to enable MFA", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("MFA enabled", "userID", userID)
+	return raws, nil
+}
+
+// Disable turns 2FA off after checking the user's password and a TOTP or recovery code
+func (s *MFAService) Disable(ctx context.Context, userID uuid.UUID, password, code string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for disabling MFA", "userID", userID, "error", err)
+		return err
+	}
+
+	if !user.IsMFAEnabled() {
+		return ErrMFANotEnabled
+	}
+
+	if !s.passwords.Verify(user, password) {
+		return ErrInvalidCurrentPassword
+	}
+
+	if err := s.verifyCode(ctx, user, code); err != nil {
+		return err
+	}
+
+	user.DisableMFA()
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return s.recoveryRepo.DeleteByUserID(ctx, user.ID)
+	})
+	if err != nil {
+		s.logger.Error("Failed to disable MFA", "userID", userID, "error", err)
+		return err
+	}
+
+	s.logger.Info("MFA disabled", "userID", userID)
+	return nil
+}
+
+// VerifyLogin completes a login by exchanging an MFA challenge token and a TOTP or recovery code for a JWT token
+// Each challenge allows model.MFAChallengeMaxAttempts codes, and wrong codes count as failed logins of the user.
+func (s *MFAService) VerifyLogin(ctx context.Context, challenge, code string, client model.ClientInfo) (*model.User, string, error) {
+	user, mfaChallenge, err := s.authService.AttemptMFAChallenge(ctx, challenge, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 2FA was turned off since the challenge was issued
+	if !user.IsMFAEnabled() {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+
+	if err := s.verifyCode(ctx, user, code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			s.logger.Warn("Invalid MFA code", "userID", user.ID, "attemptsLeft", mfaChallenge.AttemptsLeft())
+			if err := s.authService.FailMFAChallenge(ctx, user, mfaChallenge, client); err != nil {
+				return nil, "", err
+			}
+			return nil, "", ErrMFALoginFailed
+		}
+		return nil, "", err
+	}
+
+	token, err := s.authService.CompleteMFAChallenge(ctx, user, mfaChallenge, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
+// verifyCode accepts either a TOTP code or an unused recovery code
+func (s *MFAService) verifyCode(ctx context.Context, user *model.User, code string) error {
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(ctx, user, code)
+	}
+
+	err := s.recoveryRepo.Consume(ctx, user.ID, model.HashRecoveryCode(code), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidRecoveryCode) {
+			return ErrInvalidMFACode
+		}
+		s.logger.Error("Failed to consume recovery code", "userID", user.ID, "error", err)
+		return err
+	}
+
+	s.logger.Info("Recovery code used", "userID", user.ID)
+	return nil
+}
+
+// verifyTOTP accepts a TOTP code of the user's secret, at most once per time step
+func (s *MFAService) verifyTOTP(ctx context.Context, user *model.User, code string) error {
+	step, ok := totp.Validate(user.MFASecret, code, time.Now(), MFAClockSkew)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	fresh, err := s.userRepo.UseMFAStep(ctx, user.ID, step)
+	if err != nil {
+		s.logger.Error("Failed to record TOTP step", "userID", user.ID, "error", err)
+		return err
+	}
+
+	// The code, or a later one, has already been used
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// replaceRecoveryCodes swaps a user's recovery codes for a new set
+func (s *MFAService) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []*model.RecoveryCode) error {
+	if err := s.recoveryRepo.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, code := range codes {
+		if err := s.recoveryRepo.Create(ctx, code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeTokens issues tokens that are only references to the claims they were issued with
+type fakeTokens struct {
+	mu     sync.Mutex
+	claims map[string]TokenClaims
+}
+
+func (t *fakeTokens) Issue(claims TokenClaims, ttl time.Duration) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	claims.IssuedAt = time.Now().UTC()
+	claims.ExpiresAt = claims.IssuedAt.Add(ttl)
+	token := "token-" + strconv.Itoa(len(t.claims))
+	t.claims[token] = claims
+	return token, nil
+}
+
+func (t *fakeTokens) Verify(token string) (*TokenClaims, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	claims, exists := t.claims[token]
+	if !exists {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+// fakeMFAChallengeRepository keeps MFA challenges in memory
+type fakeMFAChallengeRepository struct {
+	challenges map[uuid.UUID]*model.MFAChallenge
+}
+
+func (r *fakeMFAChallengeRepository) Create(ctx context.Context, challenge *model.MFAChallenge) error {
+	r.challenges[challenge.ID] = challenge
+	return nil
+}
+
+func (r *fakeMFAChallengeRepository) UseAttempt(ctx context.Context, userID, id uuid.UUID, now time.Time) (*model.MFAChallenge, error) {
+	challenge, exists := r.challenges[id]
+	if !exists || challenge.UserID != userID || !challenge.ExpiresAt.After(now) || challenge.AttemptsLeft() == 0 {
+		return nil, model.ErrMFAChallengeNotFound
+	}
+	challenge.Attempts++
+	return challenge, nil
+}
+
+func (r *fakeMFAChallengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, exists := r.challenges[id]; !exists {
+		return model.ErrMFAChallengeNotFound
+	}
+	delete(r.challenges, id)
+	return nil
+}
+
+func (r *fakeMFAChallengeRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+// fakeLoginThrottleRepository counts failures without ever delaying a login, so tests reach the
+// limits of MFA challenges
+type fakeLoginThrottleRepository struct {
+	failures map[string]int
+}
+
+func (r *fakeLoginThrottleRepository) Get(ctx context.Context, key string) (*model.LoginThrottle, error) {
+	return nil, model.ErrLoginThrottleNotFound
+}
+
+func (r *fakeLoginThrottleRepository) RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (*model.LoginThrottle, error) {
+	r.failures[key]++
+	return &model.LoginThrottle{Key: key, Failures: r.failures[key], LastFailedAt: now}, nil
+}
+
+func (r *fakeLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return nil
+}
+
+func (r *fakeLoginThrottleRepository) Delete(ctx context.Context, key string) error {
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *fakeLoginThrottleRepository) DeleteStale(ctx context.Context, before, now time.Time) error {
+	return nil
+}
+
+// fakeRecoveryCodeRepository consumes each of a set of recovery codes once
+type fakeRecoveryCodeRepository struct {
+	repository.RecoveryCodeRepository
+	unused map[string]bool
+}
+
+func (r *fakeRecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) error {
+	if !r.unused[codeHash] {
+		return model.ErrInvalidRecoveryCode
+	}
+	delete(r.unused, codeHash)
+	return nil
+}
+
+func TestVerifyLoginLimitsAttempts(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Email: "user@example.com", Fullname: "User"}
+	user.StartMFAEnrollment("JBSWY3DPEHPK3PXP")
+	user.EnableMFA()
+	codes := []string{"aaaaa-aaaaa", "bbbbb-bbbbb", "ccccc-ccccc"}
+
+	recoveryRepo := &fakeRecoveryCodeRepository{unused: map[string]bool{}}
+	for _, code := range codes {
+		recoveryRepo.unused[model.HashRecoveryCode(code)] = true
+	}
+	throttleRepo := &fakeLoginThrottleRepository{failures: map[string]int{}}
+	challengeRepo := &fakeMFAChallengeRepository{challenges: map[uuid.UUID]*model.MFAChallenge{}}
+	userRepo := &fakeUserRepository{users: map[string]*model.User{user.Email: user}}
+	tokens := &fakeTokens{claims: map[string]TokenClaims{}}
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+
+	throttle := NewLoginThrottleService(throttleRepo, LoginThrottleOptions{EmailMaxFailures: 100, IPMaxFailures: 100, LockoutDuration: time.Minute}, log)
+	sessions := NewSessionService(&fakeSessionRepository{sessions: map[uuid.UUID]*model.Session{}}, time.Minute, log)
+	authService := NewAuthService(userRepo, nil, nil, throttle, sessions, challengeRepo, log, tokens, tokens, time.Hour)
+	mfaService := NewMFAService(userRepo, recoveryRepo, nil, nil, authService, "Todo API", log)
+
+	ctx := context.Background()
+	client := model.ClientInfo{IP: "192.0.2.1"}
+	emailKey := model.LoginThrottleKeyForEmail(user.Email)
+
+	// Wrong codes use up the attempts of a challenge and count as failed logins
+	result, err := authService.IssueLogin(ctx, user, client)
+	if err != nil {
+		t.Fatalf("failed to issue login: %v", err)
+	}
+	for i := 0; i < model.MFAChallengeMaxAttempts; i++ {
+		if _, _, err := mfaService.VerifyLogin(ctx, result.MFAToken, "wrong-code", client); !errors.Is(err, ErrMFALoginFailed) {
+			t.Fatalf("expected a failed attempt, got %v", err)
+		}
+	}
+	if throttleRepo.failures[emailKey] != model.MFAChallengeMaxAttempts {
+		t.Errorf("expected %d failed logins, got %d", model.MFAChallengeMaxAttempts, throttleRepo.failures[emailKey])
+	}
+	if _, _, err := mfaService.VerifyLogin(ctx, result.MFAToken, codes[0], client); !errors.Is(err, ErrInvalidMFAChallenge) {
+		t.Errorf("expected the challenge to be invalidated, got %v", err)
+	}
+	if len(challengeRepo.challenges) != 0 {
+		t.Errorf("expected the used up challenge to be deleted, got %d challenges", len(challengeRepo.challenges))
+	}
+
+	// A challenge can only be completed once
+	result, err = authService.IssueLogin(ctx, user, client)
+	if err != nil {
+		t.Fatalf("failed to issue login: %v", err)
+	}
+	if _, token, err := mfaService.VerifyLogin(ctx, result.MFAToken, codes[1], client); err != nil || token == "" {
+		t.Fatalf("expected the login to succeed, got %v", err)
+	}
+	if _, _, err := mfaService.VerifyLogin(ctx, result.MFAToken, codes[2], client); !errors.Is(err, ErrInvalidMFAChallenge) {
+		t.Errorf("expected the completed challenge to be invalid, got %v", err)
+	}
+	if _, failed := throttleRepo.failures[emailKey]; failed {
+		t.Error("expected the failed logins to be forgotten after the login succeeded")
+	}
+}
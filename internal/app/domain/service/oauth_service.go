@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// OAuthStateTTL is how long a user has to complete a login at an identity provider
+const OAuthStateTTL = 10 * time.Minute
+
+var (
+	// ErrProviderNotFound is returned for identity providers that are not configured
+	ErrProviderNotFound = model.NewNotFoundError("provider_not_found", "identity provider not found")
+
+	// ErrOAuthExchangeFailed is returned when the identity provider does not accept the login
+	ErrOAuthExchangeFailed = model.NewUnauthorizedError("oauth_exchange_failed", "identity provider rejected the login")
+
+	// ErrEmailNotVerifiedByProvider is returned when the identity provider cannot vouch for the user's email
+	ErrEmailNotVerifiedByProvider = model.NewForbiddenError("provider_email_not_verified", "the identity provider has not verified your email")
+
+	// ErrAccountEmailNotVerified is returned when linking to an account whose email was never verified
+	ErrAccountEmailNotVerified = model.NewConflictError("account_email_not_verified", "an account with this email exists; verify its email before linking")
+)
+
+// OAuthService provides login through external identity providers
+type OAuthService struct {
+	providers    map[string]IdentityProvider
+	stateRepo    repository.OAuthStateRepository
+	identityRepo repository.UserIdentityRepository
+	userRepo     repository.UserRepository
+	txManager    repository.TxManager
+	passwords    *PasswordService
+	authService  *AuthService
+	logger       *logger.Logger
+}
+
+// NewOAuthService creates a new OAuth service for the given identity providers
+func NewOAuthService(providers []IdentityProvider, stateRepo repository.OAuthStateRepository, identityRepo repository.UserIdentityRepository, userRepo repository.UserRepository, txManager repository.TxManager, passwords *PasswordService, authService *AuthService, logger *logger.Logger) *OAuthService {
+	byName := make(map[string]IdentityProvider, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+
+	return &OAuthService{
+		providers:    byName,
+		stateRepo:    stateRepo,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		txManager:    txManager,
+		passwords:    passwords,
+		authService:  authService,
+		logger:       logger,
+	}
+}
+
+// Providers returns the names of the configured identity providers
+func (s *OAuthService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Authorize starts a login with an identity provider and returns the URL to send the user to,
+// together with the hash of the state to bind the login to the browser that started it.
+// The PKCE verifier and nonce stay in the API; the client only passes the state back on callback.
+func (s *OAuthService) Authorize(ctx context.Context, providerName string) (string, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", ErrProviderNotFound
+	}
+
+	state, raw, err := model.NewOAuthState(providerName, OAuthStateTTL)
+	if err != nil {
+		s.logger.Error("Failed to generate OAuth state", "error", err)
+		return "", "", err
+	}
+
+	// Clean up logins that were never completed
+	if err := s.stateRepo.DeleteExpired(ctx, state.CreatedAt); err != nil {
+		s.logger.Warn("Failed to delete expired OAuth states", "error", err)
+	}
+
+	if err := s.stateRepo.Create(ctx, state); err != nil {
+		s.logger.Error("Failed to save OAuth state", "error", err)
+		return "", "", err
+	}
+
+	url, err := provider.AuthCodeURL(ctx, raw, state.Nonce, state.CodeChallenge())
+	if err != nil {
+		s.logger.Error("Failed to build authorization URL", "provider", providerName, "error", err)
+		return "", "", err
+	}
+
+	return url, state.StateHash, nil
+}
+
+// Callback completes a login with an identity provider and logs in the user the identity belongs to.
+// Identities seen for the first time are linked to the local account with the same verified email,
+// or get a new account. The boundState is the state hash the browser got when it started the login.
+func (s *OAuthService) Callback(ctx context.Context, providerName, code, rawState, boundState string, client model.ClientInfo) (*LoginResult, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+
+	// A login must come back to the browser that started it, or a victim could be logged into someone else's account
+	if subtle.ConstantTimeCompare([]byte(boundState), []byte(model.HashOAuthState(rawState))) != 1 {
+		return nil, model.ErrInvalidOAuthState
+	}
+
+	state, err := s.stateRepo.Consume(ctx, model.HashOAuthState(rawState), time.Now().UTC())
+	if err != nil || state.Provider != providerName {
+		return nil, model.ErrInvalidOAuthState
+	}
+
+	identity, err := provider.Exchange(ctx, code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		s.logger.Error("Failed to exchange authorization code", "provider", providerName, "error", err)
+		return nil, ErrOAuthExchangeFailed
+	}
+	identity.Provider = providerName
+
+	var user *model.User
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.resolveUser(ctx, identity)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.IssueLogin(ctx, user, client)
+}
+
+// resolveUser finds or creates the local user an external identity belongs to
+func (s *OAuthService) resolveUser(ctx context.Context, identity *model.ExternalIdentity) (*model.User, error) {
+	now := time.Now().UTC()
+
+	// Returning users are known by the provider's subject, whatever their email is now
+	linked, err := s.identityRepo.GetByProviderAndSubject(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		if err := s.identityRepo.TouchLastLogin(ctx, linked.ID, identity.Email, now); err != nil {
+			s.logger.Error("Failed to record identity login", "identityID", linked.ID, "error", err)
+			return nil, err
+		}
+		return s.userRepo.GetByID(ctx, linked.UserID)
+	}
+	if !errors.Is(err, model.ErrIdentityNotFound) {
+		s.logger.Error("Failed to get user identity", "provider", identity.Provider, "error", err)
+		return nil, err
+	}
+
+	// Only an email the provider has verified can be trusted to identify a local account
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrEmailNotVerifiedByProvider
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// Linking to an account whose owner never proved the email would hand it to whoever registered it
+		if !user.IsEmailVerified() {
+			return nil, ErrAccountEmailNotVerified
+		}
+	case errors.Is(err, model.ErrUserNotFound):
+		user, err = s.createUser(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		s.logger.Error("Failed to get user by email", "error", err)
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, model.NewUserIdentity(user.ID, identity)); err != nil {
+		s.logger.Error("Failed to link user identity", "userID", user.ID, "provider", identity.Provider, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Linked user identity", "userID", user.ID, "provider", identity.Provider)
+	return user, nil
+}
+
+// createUser creates an account for an external identity. It gets a random password,
+// which the user can replace through a password reset.
+func (s *OAuthService) createUser(ctx context.Context, identity *model.ExternalIdentity) (*model.User, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	fullname := identity.Name
+	if fullname == "" {
+		fullname = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+
+	hash, err := s.passwords.Hash(base64.RawURLEncoding.EncodeToString(buf))
+	if err != nil {
+		return nil, err
+	}
+
+	user := model.NewUser(fullname, identity.Email, hash)
+	user.MarkEmailVerified()
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		s.logger.Error("Failed to save user", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
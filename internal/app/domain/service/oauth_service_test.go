@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeIdentityProvider puts the state into its authorization URL and rejects every code
+type fakeIdentityProvider struct {
+	exchanges int
+}
+
+func (p *fakeIdentityProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeIdentityProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	return "https://idp.example.com/authorize?state=" + url.QueryEscape(state), nil
+}
+
+func (p *fakeIdentityProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error) {
+	p.exchanges++
+	return nil, errors.New("unknown code")
+}
+
+// fakeOAuthStateRepository keeps OAuth states in memory
+type fakeOAuthStateRepository struct {
+	states map[string]*model.OAuthState
+}
+
+func (r *fakeOAuthStateRepository) Create(ctx context.Context, state *model.OAuthState) error {
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *fakeOAuthStateRepository) Consume(ctx context.Context, stateHash string, now time.Time) (*model.OAuthState, error) {
+	state, exists := r.states[stateHash]
+	if !exists {
+		return nil, model.ErrInvalidOAuthState
+	}
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+func (r *fakeOAuthStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+func TestOAuthCallbackRequiresBoundState(t *testing.T) {
+	provider := &fakeIdentityProvider{}
+	stateRepo := &fakeOAuthStateRepository{states: map[string]*model.OAuthState{}}
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	oauthService := NewOAuthService([]IdentityProvider{provider}, stateRepo, nil, nil, nil, nil, nil, log)
+
+	ctx := context.Background()
+	authorizationURL, stateHash, err := oauthService.Authorize(ctx, "fake")
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		t.Fatalf("failed to parse authorization URL: %v", err)
+	}
+	state := parsed.Query().Get("state")
+
+	// A state started in another browser is rejected before the code is exchanged
+	for _, boundState := range []string{"", model.HashOAuthState("other")} {
+		if _, err := oauthService.Callback(ctx, "fake", "code", state, boundState, model.ClientInfo{}); !errors.Is(err, model.ErrInvalidOAuthState) {
+			t.Errorf("expected an invalid state for bound state %q, got %v", boundState, err)
+		}
+	}
+	if provider.exchanges != 0 {
+		t.Fatalf("expected no code exchange, got %d", provider.exchanges)
+	}
+
+	// The browser that started the login gets to the exchange
+	if _, err := oauthService.Callback(ctx, "fake", "code", state, stateHash, model.ClientInfo{}); !errors.Is(err, ErrOAuthExchangeFailed) {
+		t.Errorf("expected the exchange to fail, got %v", err)
+	}
+	if provider.exchanges != 1 {
+		t.Errorf("expected one code exchange, got %d", provider.exchanges)
+	}
+}
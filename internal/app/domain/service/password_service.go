@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/password"
+)
+
+// ErrInvalidCurrentPassword is returned when a user confirms a sensitive change with a wrong password
+var ErrInvalidCurrentPassword = model.NewForbiddenError("invalid_current_password", "current password is incorrect")
+
+// PasswordService checks passwords against the password policy and hashes them.
+// Hashes made with another algorithm or cost than the configured one are upgraded on login.
+type PasswordService struct {
+	userRepo repository.UserRepository
+	hasher   *password.Hasher
+	policy   *password.Policy
+	logger   *logger.Logger
+}
+
+// NewPasswordService creates a new password service
+func NewPasswordService(userRepo repository.UserRepository, hasher *password.Hasher, policy *password.Policy, logger *logger.Logger) *PasswordService {
+	return &PasswordService{
+		userRepo: userRepo,
+		hasher:   hasher,
+		policy:   policy,
+		logger:   logger,
+	}
+}
+
+// NewUser creates a new user with a password that follows the policy.
+// It returns a *password.PolicyError if the password breaks a rule.
+func (s *PasswordService) NewUser(fullname, email, plain string) (*model.User, error) {
+	if err := s.policy.Check(plain, email, fullname); err != nil {
+		return nil, err
+	}
+
+	hash, err := s.Hash(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewUser(fullname, email, hash), nil
+}
+
+// SetPassword changes a user's password to one that follows the policy.
+// It returns a *password.PolicyError if the password breaks a rule.
+func (s *PasswordService) SetPassword(user *model.User, plain string) error {
+	if err := s.policy.Check(plain, user.Email, user.Fullname); err != nil {
+		return err
+	}
+
+	hash, err := s.Hash(plain)
+	if err != nil {
+		return err
+	}
+
+	user.UpdatePasswordHash(hash)
+	return nil
+}
+
+// Hash hashes a password without checking it against the policy, e.g. for random passwords
+func (s *PasswordService) Hash(plain string) (string, error) {
+	hash, err := s.hasher.Hash(plain)
+	if err != nil {
+		s.logger.Error("Failed to hash password", "error", err)
+		return "", err
+	}
+	return hash, nil
+}
+
+// Verify checks if a password is the user's password
+func (s *PasswordService) Verify(user *model.User, plain string) bool {
+	return s.hasher.Verify(user.PasswordHash, plain)
+}
+
+// VerifyDummy spends the time of verifying a password without a user
+func (s *PasswordService) VerifyDummy(plain string) {
+	s.hasher.VerifyDummy(plain)
+}
+
+// Upgrade rehashes a verified password if its hash was made with another algorithm or cost than
+// the configured one. A failed upgrade is retried on the next login, so it is only logged.
+func (s *PasswordService) Upgrade(ctx context.Context, user *model.User, plain string) {
+	if !s.hasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	hash, err := s.Hash(plain)
+	if err != nil {
+		return
+	}
+
+	if err := s.userRepo.UpdatePasswordHash(ctx, user.ID, user.PasswordHash, hash); err != nil {
+		s.logger.Error("Failed to upgrade password hash", "userID", user.ID, "error", err)
+		return
+	}
+
+	user.UpdatePasswordHash(hash)
+	s.logger.Info("Upgraded password hash", "userID", user.ID)
+}
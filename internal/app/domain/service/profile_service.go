@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+var (
+	// ErrAccountDeletionScheduled is returned when scheduling the deletion of an account that is already scheduled
+	ErrAccountDeletionScheduled = model.NewConflictError("account_deletion_scheduled", "account deletion is already scheduled")
+
+	// ErrAccountDeletionNotScheduled is returned when cancelling an account deletion that was never scheduled
+	ErrAccountDeletionNotScheduled = model.NewConflictError("account_deletion_not_scheduled", "account deletion is not scheduled")
+)
+
+// ProfileService provides profile, preference and account deletion functionality.
+// Accounts scheduled for deletion are deleted by the AccountPurgeScheduler.
+type ProfileService struct {
+	userRepo       repository.UserRepository
+	passwords      *PasswordService
+	authService    *AuthService
+	sessions       *SessionService
+	accountService *AccountService
+	deletionGrace  time.Duration
+	logger         *logger.Logger
+}
+
+// NewProfileService creates a new profile service
+func NewProfileService(userRepo repository.UserRepository, passwords *PasswordService, authService *AuthService, sessions *SessionService, accountService *AccountService, deletionGrace time.Duration, logger *logger.Logger) *ProfileService {
+	return &ProfileService{
+		userRepo:       userRepo,
+		passwords:      passwords,
+		authService:    authService,
+		sessions:       sessions,
+		accountService: accountService,
+		deletionGrace:  deletionGrace,
+		logger:         logger,
+	}
+}
+
+// UpdateProfile updates a user's name and email. A new email is sent a verification link.
+// The email is where password reset links go, so changing it needs the current password,
+// like changing the password does.
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID uuid.UUID, fullname, email *string, currentPassword string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for profile update", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if fullname != nil {
+		user.UpdateFullname(*fullname)
+	}
+
+	emailChanged := email != nil && *email != user.Email
+	if emailChanged {
+		if !s.passwords.Verify(user, currentPassword) {
+			return nil, ErrInvalidCurrentPassword
+		}
+		user.UpdateEmail(*email)
+	}
+
+	// The unique email of users rejects an email taken by someone else, even by a concurrent update
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, model.ErrEmailTaken) {
+			return nil, err
+		}
+		s.logger.Error("Failed to update profile", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if emailChanged {
+		// The profile is saved either way; the user can ask for another link
+		if err := s.accountService.SendVerificationEmail(ctx, user); err != nil {
+			s.logger.Error("Failed to send verification email after email change", "userID", userID, "error", err)
+		}
+	}
+
+	return user, nil
+}
+
+// UpdatePreferences updates a user's timezone and locale
+func (s *ProfileService) UpdatePreferences(ctx context.Context, userID uuid.UUID, timezone, locale *string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for preferences update", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	newTimezone, newLocale := user.Timezone, user.Locale
+	if timezone != nil {
+		newTimezone = *timezone
+	}
+	if locale != nil {
+		newLocale = *locale
+	}
+
+	if err := user.UpdatePreferences(newTimezone, newLocale); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("Failed to update preferences", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangePassword changes a user's password, signs them out everywhere and returns a token for a new session
+func (s *ProfileService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string, client model.ClientInfo) (*model.User, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for password change", "userID", userID, "error", err)
+		return nil, "", err
+	}
+
+	if !s.passwords.Verify(user, currentPassword) {
+		return nil, "", ErrInvalidCurrentPassword
+	}
+
+	if err := s.passwords.SetPassword(user, newPassword); err != nil {
+		return nil, "", err
+	}
+	user.RevokeTokens()
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("Failed to change password", "userID", userID, "error", err)
+		return nil, "", err
+	}
+
+	if err := s.sessions.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, "", err
+	}
+
+	token, err := s.authService.StartSession(ctx, user, client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
+// ScheduleDeletion schedules a user's account to be deleted once the grace period has passed
+func (s *ProfileService) ScheduleDeletion(ctx context.Context, userID uuid.UUID, password string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for account deletion", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if !s.passwords.Verify(user, password) {
+		return nil, ErrInvalidCurrentPassword
+	}
+
+	if user.IsDeletionScheduled() {
+		return nil, ErrAccountDeletionScheduled
+	}
+
+	user.ScheduleDeletion(time.Now().UTC().Add(s.deletionGrace))
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("Failed to schedule account deletion", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Account deletion scheduled", "userID", userID, "at", *user.DeletionScheduledAt)
+	return user, nil
+}
+
+// CancelDeletion cancels a scheduled deletion of a user's account
+func (s *ProfileService) CancelDeletion(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for deletion cancellation", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if !user.IsDeletionScheduled() {
+		return nil, ErrAccountDeletionNotScheduled
+	}
+
+	user.CancelDeletion()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		s.logger.Error("Failed to cancel account deletion", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Account deletion cancelled", "userID", userID)
+	return user, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestPasswordService creates a password service with cheap hashes and a policy that bans personal info
+func newTestPasswordService(t *testing.T) *PasswordService {
+	t.Helper()
+
+	opts := password.DefaultHasherOptions()
+	opts.BcryptCost = bcrypt.MinCost
+	hasher, err := password.NewHasher(opts)
+	if err != nil {
+		t.Fatalf("failed to create hasher: %v", err)
+	}
+
+	policy := &password.Policy{MinLength: 8, RejectPersonalInfo: true}
+	return NewPasswordService(nil, hasher, policy, logger.NewLogger("error", "json").WithOutput(io.Discard))
+}
+
+func TestUpdateProfileEmailRequiresPassword(t *testing.T) {
+	passwords := newTestPasswordService(t)
+	user, err := passwords.NewUser("User", "user@example.com", "correct horse")
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	profileService := NewProfileService(&fakeUserRepository{users: map[string]*model.User{user.Email: user}}, passwords, nil, nil, nil, 0, log)
+
+	// The user repository cannot update users, so a change that is not rejected fails the test
+	email := "attacker@example.com"
+	for _, currentPassword := range []string{"", "wrong password"} {
+		if _, err := profileService.UpdateProfile(context.Background(), user.ID, nil, &email, currentPassword); !errors.Is(err, ErrInvalidCurrentPassword) {
+			t.Errorf("expected an invalid current password for %q, got %v", currentPassword, err)
+		}
+	}
+	if user.Email != "user@example.com" {
+		t.Errorf("expected the email to be kept, got %s", user.Email)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// SessionService keeps track of where users are logged in. Checks of active sessions are cached
+// in memory for a short time, so revoking a session takes up to that long on other instances.
+type SessionService struct {
+	sessionRepo repository.SessionRepository
+	cache       *sessionCache
+	logger      *logger.Logger
+}
+
+// NewSessionService creates a new session service that caches active sessions for cacheTTL
+func NewSessionService(sessionRepo repository.SessionRepository, cacheTTL time.Duration, logger *logger.Logger) *SessionService {
+	return &SessionService{
+		sessionRepo: sessionRepo,
+		cache:       newSessionCache(cacheTTL),
+		logger:      logger,
+	}
+}
+
+// Start records a new session for a login from the given client that lasts ttl
+func (s *SessionService) Start(ctx context.Context, userID uuid.UUID, client model.ClientInfo, ttl time.Duration) (*model.Session, error) {
+	session := model.NewSession(userID, client, ttl)
+
+	// A failed cleanup only leaves expired rows behind, so the login goes ahead
+	if err := s.sessionRepo.DeleteExpiredByUserID(ctx, userID, session.CreatedAt); err != nil {
+		s.logger.Error("Failed to delete expired sessions", "userID", userID, "error", err)
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		s.logger.Error("Failed to save session", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Validate checks that a session of the user is still active, recording that it was seen.
+// The last use of a session is only written when it is not cached, so it is as precise as the cache.
+func (s *SessionService) Validate(ctx context.Context, userID, sessionID uuid.UUID) error {
+	now := time.Now().UTC()
+	if s.cache.get(sessionID, userID, now) {
+		return nil
+	}
+
+	// A revocation while the session is checked must not be undone by caching it afterwards
+	generation := s.cache.generation()
+
+	if err := s.sessionRepo.Touch(ctx, userID, sessionID, now); err != nil {
+		if errors.Is(err, model.ErrSessionNotFound) {
+			return model.ErrSessionRevoked
+		}
+		s.logger.Error("Failed to check session", "sessionID", sessionID, "error", err)
+		return err
+	}
+
+	s.cache.put(sessionID, userID, now, generation)
+	return nil
+}
+
+// ListSessions lists a user's active sessions, marking the current one
+func (s *SessionService) ListSessions(ctx context.Context, userID, currentID uuid.UUID) ([]*model.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("Failed to list sessions", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == currentID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession signs one of a user's sessions out. The session is dropped from the cache once it
+// is revoked, so a check in between cannot cache it again.
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.sessionRepo.Revoke(ctx, userID, sessionID, time.Now().UTC()); err != nil {
+		s.logger.Error("Failed to revoke session", "userID", userID, "sessionID", sessionID, "error", err)
+		return err
+	}
+
+	s.cache.delete(sessionID)
+
+	s.logger.Info("Session revoked", "userID", userID, "sessionID", sessionID)
+	return nil
+}
+
+// RevokeAllSessions signs all of a user's sessions out, dropping them from the cache once revoked
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	if err := s.sessionRepo.RevokeAllByUserID(ctx, userID, time.Now().UTC()); err != nil {
+		s.logger.Error("Failed to revoke sessions", "userID", userID, "error", err)
+		return err
+	}
+
+	s.cache.deleteUser(userID)
+	return nil
+}
+
+// sessionCache remembers sessions that were recently found to be active. Every eviction starts
+// a new generation, and sessions checked in an earlier generation are not cached.
+type sessionCache struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	entries   map[uuid.UUID]sessionCacheEntry
+	lastSweep time.Time
+	gen       uint64
+}
+
+// sessionCacheEntry is a cached active session
+type sessionCacheEntry struct {
+	userID    uuid.UUID
+	expiresAt time.Time
+}
+
+// newSessionCache creates a session cache whose entries last ttl; a zero ttl disables it
+func newSessionCache(ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]sessionCacheEntry),
+	}
+}
+
+// get checks if a session of the user is cached as active
+func (c *sessionCache) get(sessionID, userID uuid.UUID, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[sessionID]
+	return ok && entry.userID == userID && now.Before(entry.expiresAt)
+}
+
+// generation returns the current generation of the cache
+func (c *sessionCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gen
+}
+
+// put caches a session found active in the given generation, dropping expired entries every ttl
+// so the cache does not grow forever
+func (c *sessionCache) put(sessionID, userID uuid.UUID, now time.Time, generation uint64) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Sessions may have been revoked since it was checked
+	if generation != c.gen {
+		return
+	}
+
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for id, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[sessionID] = sessionCacheEntry{userID: userID, expiresAt: now.Add(c.ttl)}
+}
+
+// delete drops a session from the cache
+func (c *sessionCache) delete(sessionID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, sessionID)
+	c.gen++
+}
+
+// deleteUser drops all of a user's sessions from the cache
+func (c *sessionCache) deleteUser(userID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.entries {
+		if entry.userID == userID {
+			delete(c.entries, id)
+		}
+	}
+	c.gen++
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeSessionRepository keeps sessions in memory. The onRevoke hook runs before a revocation
+// takes effect, like a request checked while it is written.
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*model.Session
+	onRevoke func()
+}
+
+func (r *fakeSessionRepository) Create(ctx context.Context, session *model.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.ID] = session
+	return nil
+}
+
+func (r *fakeSessionRepository) Touch(ctx context.Context, userID, id uuid.UUID, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[id]
+	if !exists || session.UserID != userID || !session.IsActive(now) {
+		return model.ErrSessionNotFound
+	}
+	session.LastSeenAt = now
+	return nil
+}
+
+func (r *fakeSessionRepository) Revoke(ctx context.Context, userID, id uuid.UUID, now time.Time) error {
+	if r.onRevoke != nil {
+		r.onRevoke()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[id]
+	if !exists || session.UserID != userID {
+		return model.ErrSessionNotFound
+	}
+	session.RevokedAt = &now
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	if r.onRevoke != nil {
+		r.onRevoke()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, session := range r.sessions {
+		if session.UserID == userID {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	return nil
+}
+
+func TestRevokeSessionEvictsCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		revoke func(s *SessionService, session *model.Session) error
+	}{
+		{
+			name: "one session",
+			revoke: func(s *SessionService, session *model.Session) error {
+				return s.RevokeSession(context.Background(), session.UserID, session.ID)
+			},
+		},
+		{
+			name: "all sessions",
+			revoke: func(s *SessionService, session *model.Session) error {
+				return s.RevokeAllSessions(context.Background(), session.UserID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			sessionRepo := &fakeSessionRepository{sessions: map[uuid.UUID]*model.Session{}}
+			log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+			sessionService := NewSessionService(sessionRepo, time.Hour, log)
+
+			session, err := sessionService.Start(ctx, uuid.New(), model.ClientInfo{}, time.Hour)
+			if err != nil {
+				t.Fatalf("failed to start session: %v", err)
+			}
+			if err := sessionService.Validate(ctx, session.UserID, session.ID); err != nil {
+				t.Fatalf("expected the session to be active, got %v", err)
+			}
+
+			// The session is checked again while it is being revoked
+			sessionRepo.onRevoke = func() {
+				if err := sessionService.Validate(ctx, session.UserID, session.ID); err != nil {
+					t.Errorf("expected the session to be active until revoked, got %v", err)
+				}
+			}
+
+			if err := tt.revoke(sessionService, session); err != nil {
+				t.Fatalf("failed to revoke: %v", err)
+			}
+			if err := sessionService.Validate(ctx, session.UserID, session.ID); !errors.Is(err, model.ErrSessionRevoked) {
+				t.Errorf("expected the session to be revoked, got %v", err)
+			}
+		})
+	}
+}
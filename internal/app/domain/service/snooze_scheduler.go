@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/event"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// SnoozeBatchSize is the maximum number of todos woken in a single query
+const SnoozeBatchSize = 100
+
+// SnoozeScheduler periodically wakes snoozed todos and publishes an event for each of them
+type SnoozeScheduler struct {
+	todoRepo  repository.TodoRepository
+	publisher event.Publisher
+	interval  time.Duration
+	logger    *logger.Logger
+}
+
+// NewSnoozeScheduler creates a new snooze scheduler
+func NewSnoozeScheduler(todoRepo repository.TodoRepository, publisher event.Publisher, interval time.Duration, logger *logger.Logger) *SnoozeScheduler {
+	return &SnoozeScheduler{
+		todoRepo:  todoRepo,
+		publisher: publisher,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Start runs the scheduler until the context is cancelled
+func (s *SnoozeScheduler) Start(ctx context.Context) {
+	s.logger.Info("Snooze scheduler started", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if woken, err := s.RunOnce(ctx); err != nil {
+			s.logger.Error("Failed to wake snoozed todos", "error", err)
+		} else if woken > 0 {
+			s.logger.Info("Woke snoozed todos", "count", woken)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Snooze scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce wakes every todo whose snooze has ended and returns how many were woken.
+// A todo is woken before its event is published, so events are delivered at most once.
+func (s *SnoozeScheduler) RunOnce(ctx context.Context) (int, error) {
+	woken := 0
+	for {
+		now := time.Now().UTC()
+		todos, err := s.todoRepo.WakeSnoozed(ctx, now, SnoozeBatchSize)
+		if err != nil {
+			return woken, err
+		}
+
+		events := make([]event.Event, 0, len(todos))
+		for _, todo := range todos {
+			events = append(events, event.TodoUnsnoozed{
+				TodoID:       todo.ID,
+				UserID:       todo.UserID,
+				Title:        todo.Title,
+				SnoozedUntil: *todo.SnoozedUntil,
+				OccurredAt:   now,
+			})
+		}
+
+		if len(events) > 0 {
+			if err := s.publisher.Publish(ctx, events...); err != nil {
+				return woken, err
+			}
+		}
+
+		woken += len(todos)
+		if len(todos) < SnoozeBatchSize || ctx.Err() != nil {
+			return woken, nil
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// MaxTimeReportRange is the longest date range a time report or timesheet may cover
+const MaxTimeReportRange = 366 * 24 * time.Hour
+
+// ErrInvalidDateRange is returned for report ranges that are not ordered or longer than MaxTimeReportRange
+var ErrInvalidDateRange = model.NewValidationError("invalid_date_range", "invalid date range")
+
+// TimeTrackingService provides time tracking related functionality
+type TimeTrackingService struct {
+	todoRepo      repository.TodoRepository
+	timeEntryRepo repository.TimeEntryRepository
+	txManager     repository.TxManager
+	logger        *logger.Logger
+}
+
+// NewTimeTrackingService creates a new time tracking service
+func NewTimeTrackingService(todoRepo repository.TodoRepository, timeEntryRepo repository.TimeEntryRepository, txManager repository.TxManager, logger *logger.Logger) *TimeTrackingService {
+	return &TimeTrackingService{
+		todoRepo:      todoRepo,
+		timeEntryRepo: timeEntryRepo,
+		txManager:     txManager,
+		logger:        logger,
+	}
+}
+
+// StartTimer starts a timer on a user's todo, stopping the timer that is already running
+func (s *TimeTrackingService) StartTimer(ctx context.Context, userID, todoID uuid.UUID, note string) (*model.TimeEntry, error) {
+	if _, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID); err != nil {
+		s.logger.Error("Failed to get todo for timer", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	// A user only has one running timer, so starting a new one stops the previous one
+	entry := model.StartTimer(userID, todoID, note)
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.stopRunning(ctx, userID, nil); err != nil {
+			return err
+		}
+
+		if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+			s.logger.Error("Failed to start timer", "userID", userID, "todoID", todoID, "error", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// StopTimer stops a user's running timer
+func (s *TimeTrackingService) StopTimer(ctx context.Context, userID uuid.UUID) (*model.TimeEntry, error) {
+	entry, err := s.stopRunning(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry == nil {
+		return nil, model.ErrNoRunningTimer
+	}
+
+	return entry, nil
+}
+
+// StopTimerForTodo stops a user's running timer if it runs on the given todo
+func (s *TimeTrackingService) StopTimerForTodo(ctx context.Context, userID, todoID uuid.UUID) error {
+	_, err := s.stopRunning(ctx, userID, &todoID)
+	return err
+}
+
+// GetRunningTimer gets a user's running timer, or nil if none is running
+func (s *TimeTrackingService) GetRunningTimer(ctx context.Context, userID uuid.UUID) (*model.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetRunningByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get running timer", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// AddManualEntry records time spent on a user's todo without a timer
+func (s *TimeTrackingService) AddManualEntry(ctx context.Context, userID, todoID uuid.UUID, startedAt, endedAt time.Time, note string) (*model.TimeEntry, error) {
+	if _, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID); err != nil {
+		s.logger.Error("Failed to get todo for time entry", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	entry, err := model.NewManualTimeEntry(userID, todoID, startedAt, endedAt, note)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.timeEntryRepo.Create(ctx, entry); err != nil {
+		s.logger.Error("Failed to create time entry", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// DeleteEntry deletes one of a user's time entries
+func (s *TimeTrackingService) DeleteEntry(ctx context.Context, userID, entryID uuid.UUID) error {
+	if _, err := s.timeEntryRepo.GetByUserIDAndID(ctx, userID, entryID); err != nil {
+		s.logger.Error("Failed to get time entry for delete", "userID", userID, "entryID", entryID, "error", err)
+		return err
+	}
+
+	if err := s.timeEntryRepo.Delete(ctx, entryID); err != nil {
+		s.logger.Error("Failed to delete time entry", "entryID", entryID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetTodoTime gets the time tracked against a user's todo
+func (s *TimeTrackingService) GetTodoTime(ctx context.Context, userID, todoID uuid.UUID) (*model.TodoTimeSummary, error) {
+	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
+	if err != nil {
+		s.logger.Error("Failed to get todo for time summary", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepo.ListByTodoID(ctx, todoID)
+	if err != nil {
+		s.logger.Error("Failed to list time entries", "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	return &model.TodoTimeSummary{
+		TodoID:          todoID,
+		EstimateMinutes: todo.EstimateMinutes,
+		TotalSeconds:    model.TotalSeconds(entries, time.Now().UTC()),
+		Entries:         entries,
+	}, nil
+}
+
+// GetTimeReport gets a user's time entries started within [from, to) and their total
+func (s *TimeTrackingService) GetTimeReport(ctx context.Context, userID uuid.UUID, from, to time.Time) (*model.TimeReport, error) {
+	if err := checkReportRange(from, to); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeEntryRepo.ListByUserID(ctx, userID, from, to)
+	if err != nil {
+		s.logger.Error("Failed to list time entries", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return &model.TimeReport{
+		From:         from,
+		To:           to,
+		TotalSeconds: model.TotalSeconds(entries, time.Now().UTC()),
+		Entries:      entries,
+	}, nil
+}
+
+// GetTimesheet gets a user's tracked time within [from, to) aggregated per day and todo
+func (s *TimeTrackingService) GetTimesheet(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*model.TimesheetRow, error) {
+	if err := checkReportRange(from, to); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.timeEntryRepo.Timesheet(ctx, userID, from, to)
+	if err != nil {
+		s.logger.Error("Failed to build timesheet", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// stopRunning stops a user's running timer, optionally only when it runs on the given todo.
+// It returns the stopped entry, or nil if no matching timer was running.
+func (s *TimeTrackingService) stopRunning(ctx context.Context, userID uuid.UUID, todoID *uuid.UUID) (*model.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetRunningByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get running timer", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if entry == nil || (todoID != nil && entry.TodoID != *todoID) {
+		return nil, nil
+	}
+
+	entry.Stop(time.Now().UTC())
+	if err := s.timeEntryRepo.Update(ctx, entry); err != nil {
+		s.logger.Error("Failed to stop timer", "entryID", entry.ID, "error", err)
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// checkReportRange checks that a report range is ordered and not too long
+func checkReportRange(from, to time.Time) error {
+	if !to.After(from) || to.Sub(from) > MaxTimeReportRange {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
@@ -9,28 +9,73 @@ import (
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/repository"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
 )
 
+// BoardColumnLimit is the maximum number of todos returned per board column
+const BoardColumnLimit = 200
+
+// errPositionTooDense is returned when two neighbours leave no room for a position between them
+var errPositionTooDense = errors.New("todo positions too dense")
+
+// ErrInvalidNeighbours is returned when the neighbours of a moved todo are not other todos in its target column, in order
+var ErrInvalidNeighbours = model.NewValidationError("invalid_neighbours", "neighbours must be other todos in the target column, in order")
+
 // TodoService provides todo related functionality
 type TodoService struct {
-	todoRepo repository.TodoRepository
-	logger   *logger.Logger
+	todoRepo            repository.TodoRepository
+	userRepo            repository.UserRepository
+	txManager           repository.TxManager
+	workflowService     *WorkflowService
+	timeTrackingService *TimeTrackingService
+	logger              *logger.Logger
 }
 
 // NewTodoService creates a new todo service
-func NewTodoService(todoRepo repository.TodoRepository, logger *logger.Logger) *TodoService {
+func NewTodoService(todoRepo repository.TodoRepository, userRepo repository.UserRepository, txManager repository.TxManager, workflowService *WorkflowService, timeTrackingService *TimeTrackingService, logger *logger.Logger) *TodoService {
 	return &TodoService{
-		todoRepo: todoRepo,
-		logger:   logger,
+		todoRepo:            todoRepo,
+		userRepo:            userRepo,
+		txManager:           txManager,
+		workflowService:     workflowService,
+		timeTrackingService: timeTrackingService,
+		logger:              logger,
 	}
 }
 
 // CreateTodo creates a new todo
-func (s *TodoService) CreateTodo(ctx context.Context, userID uuid.UUID, title, description string, priority model.TodoPriority, dueDate *time.Time) (*model.Todo, error) {
+func (s *TodoService) CreateTodo(ctx context.Context, userID uuid.UUID, title, description string, priority model.TodoPriority, dueDate *time.Time, estimateMinutes *int) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.CreateTodo")
+	defer func() { tracing.End(span, err) }()
+
 	todo := model.NewTodo(userID, title, description, priority, dueDate)
+	todo.EstimateMinutes = estimateMinutes
+
+	// The workflow is read in the same serializable transaction as the insert, so a concurrent
+	// workflow change cannot drop the status the todo starts in
+	err = s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		// New todos start in the initial status of the user's workflow
+		workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+		if err != nil {
+			return err
+		}
+		todo.Status = workflow.InitialStatus
+
+		// Append the todo to the end of its board column
+		position, err := s.nextPosition(ctx, userID, todo.Status)
+		if err != nil {
+			return err
+		}
+		todo.Position = position
 
-	if err := s.todoRepo.Create(ctx, todo); err != nil {
-		s.logger.Error("Failed to create todo", "error", err)
+		if err := s.todoRepo.Create(ctx, todo); err != nil {
+			s.logger.Error("Failed to create todo", "error", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -38,7 +83,10 @@ func (s *TodoService) CreateTodo(ctx context.Context, userID uuid.UUID, title, d
 }
 
 // GetTodo gets a todo by ID
-func (s *TodoService) GetTodo(ctx context.Context, id uuid.UUID) (*model.Todo, error) {
+func (s *TodoService) GetTodo(ctx context.Context, id uuid.UUID) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.GetTodo")
+	defer func() { tracing.End(span, err) }()
+
 	todo, err := s.todoRepo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.Error("Failed to get todo", "id", id, "error", err)
@@ -49,18 +97,46 @@ func (s *TodoService) GetTodo(ctx context.Context, id uuid.UUID) (*model.Todo, e
 }
 
 // GetUserTodo gets a todo by user ID and todo ID
-func (s *TodoService) GetUserTodo(ctx context.Context, userID, todoID uuid.UUID) (*model.Todo, error) {
+func (s *TodoService) GetUserTodo(ctx context.Context, userID, todoID uuid.UUID) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.GetUserTodo")
+	defer func() { tracing.End(span, err) }()
+
 	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
 	if err != nil {
 		s.logger.Error("Failed to get user todo", "userID", userID, "todoID", todoID, "error", err)
 		return nil, err
 	}
 
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	openBlockers, err := s.openBlockers(ctx, todo, workflow)
+	if err != nil {
+		return nil, err
+	}
+	todo.Blocked = len(openBlockers) > 0
+
 	return todo, nil
 }
 
 // ListTodos lists todos based on filter
-func (s *TodoService) ListTodos(ctx context.Context, filter repository.TodoFilter) ([]*model.Todo, int, error) {
+func (s *TodoService) ListTodos(ctx context.Context, filter repository.TodoFilter) (_ []*model.Todo, _ int, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.ListTodos")
+	defer func() { tracing.End(span, err) }()
+
+	if filter.UserID != nil {
+		workflow, err := s.workflowService.GetWorkflow(ctx, *filter.UserID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.Status != nil && !workflow.HasStatus(*filter.Status) {
+			return nil, 0, model.ErrUnknownStatus
+		}
+		filter.DoneStatuses = workflow.DoneStatuses
+	}
+
 	todos, err := s.todoRepo.List(ctx, filter)
 	if err != nil {
 		s.logger.Error("Failed to list todos", "error", err)
@@ -76,8 +152,26 @@ func (s *TodoService) ListTodos(ctx context.Context, filter repository.TodoFilte
 	return todos, count, nil
 }
 
-// UpdateTodo updates a todo
-func (s *TodoService) UpdateTodo(ctx context.Context, userID uuid.UUID, todoID uuid.UUID, title, description *string, status *model.TodoStatus, priority *model.TodoPriority, dueDate *time.Time) (*model.Todo, error) {
+// UpdateTodo updates a todo, in a serializable transaction with stopping its timer
+func (s *TodoService) UpdateTodo(ctx context.Context, userID uuid.UUID, todoID uuid.UUID, title, description *string, status *model.TodoStatus, priority *model.TodoPriority, dueDate *time.Time, estimateMinutes *int) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.UpdateTodo")
+	defer func() { tracing.End(span, err) }()
+
+	var todo *model.Todo
+	err = s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		var err error
+		todo, err = s.updateTodo(ctx, userID, todoID, title, description, status, priority, dueDate, estimateMinutes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// updateTodo updates a todo
+func (s *TodoService) updateTodo(ctx context.Context, userID uuid.UUID, todoID uuid.UUID, title, description *string, status *model.TodoStatus, priority *model.TodoPriority, dueDate *time.Time, estimateMinutes *int) (*model.Todo, error) {
 	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
 	if err != nil {
 		s.logger.Error("Failed to get todo for update", "userID", userID, "todoID", todoID, "error", err)
@@ -85,7 +179,7 @@ func (s *TodoService) UpdateTodo(ctx context.Context, userID uuid.UUID, todoID u
 	}
 
 	if todo == nil {
-		return nil, errors.New("todo not found")
+		return nil, model.ErrTodoNotFound
 	}
 
 	// Update fields if provided
@@ -97,8 +191,26 @@ func (s *TodoService) UpdateTodo(ctx context.Context, userID uuid.UUID, todoID u
 		todo.UpdateDescription(*description)
 	}
 
-	if status != nil {
-		todo.UpdateStatus(*status)
+	if status != nil && *status != todo.Status {
+		workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Moving to another column puts the todo at the end of it
+		position, err := s.nextPosition(ctx, userID, *status)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.checkStart(ctx, todo, *status, workflow); err != nil {
+			return nil, err
+		}
+
+		if err := todo.TransitionTo(*status, workflow); err != nil {
+			return nil, err
+		}
+		todo.UpdatePosition(position)
 	}
 
 	if priority != nil {
@@ -109,16 +221,29 @@ func (s *TodoService) UpdateTodo(ctx context.Context, userID uuid.UUID, todoID u
 		todo.UpdateDueDate(dueDate)
 	}
 
+	if estimateMinutes != nil {
+		todo.UpdateEstimate(estimateMinutes)
+	}
+
 	if err := s.todoRepo.Update(ctx, todo); err != nil {
 		s.logger.Error("Failed to update todo", "todoID", todoID, "error", err)
 		return nil, err
 	}
 
+	if status != nil {
+		if err := s.stopTimerIfDone(ctx, todo); err != nil {
+			return nil, err
+		}
+	}
+
 	return todo, nil
 }
 
 // DeleteTodo deletes a todo
-func (s *TodoService) DeleteTodo(ctx context.Context, userID, todoID uuid.UUID) error {
+func (s *TodoService) DeleteTodo(ctx context.Context, userID, todoID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.DeleteTodo")
+	defer func() { tracing.End(span, err) }()
+
 	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
 	if err != nil {
 		s.logger.Error("Failed to get todo for delete", "userID", userID, "todoID", todoID, "error", err)
@@ -126,7 +251,7 @@ func (s *TodoService) DeleteTodo(ctx context.Context, userID, todoID uuid.UUID)
 	}
 
 	if todo == nil {
-		return errors.New("todo not found")
+		return model.ErrTodoNotFound
 	}
 
 	if err := s.todoRepo.Delete(ctx, todoID); err != nil {
@@ -137,8 +262,26 @@ func (s *TodoService) DeleteTodo(ctx context.Context, userID, todoID uuid.UUID)
 	return nil
 }
 
-// MarkTodoAsCompleted marks a todo as completed
-func (s *TodoService) MarkTodoAsCompleted(ctx context.Context, userID, todoID uuid.UUID) (*model.Todo, error) {
+// MarkTodoAsCompleted marks a todo as completed, in a serializable transaction with stopping its timer
+func (s *TodoService) MarkTodoAsCompleted(ctx context.Context, userID, todoID uuid.UUID) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.MarkTodoAsCompleted")
+	defer func() { tracing.End(span, err) }()
+
+	var todo *model.Todo
+	err = s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		var err error
+		todo, err = s.markTodoAsCompleted(ctx, userID, todoID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// markTodoAsCompleted marks a todo as completed
+func (s *TodoService) markTodoAsCompleted(ctx context.Context, userID, todoID uuid.UUID) (*model.Todo, error) {
 	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
 	if err != nil {
 		s.logger.Error("Failed to get todo for completion", "userID", userID, "todoID", todoID, "error", err)
@@ -146,27 +289,115 @@ func (s *TodoService) MarkTodoAsCompleted(ctx context.Context, userID, todoID uu
 	}
 
 	if todo == nil {
-		return nil, errors.New("todo not found")
+		return nil, model.ErrTodoNotFound
+	}
+
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := workflow.CompletionStatus(todo.Status)
+	if err != nil {
+		return nil, err
 	}
 
-	todo.MarkAsCompleted()
+	// Completing moves the todo to the end of the done column, like any other change of column
+	if todo.Status != status {
+		position, err := s.nextPosition(ctx, userID, status)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := todo.TransitionTo(status, workflow); err != nil {
+			return nil, err
+		}
+		todo.UpdatePosition(position)
+	}
 
 	if err := s.todoRepo.Update(ctx, todo); err != nil {
 		s.logger.Error("Failed to mark todo as completed", "todoID", todoID, "error", err)
 		return nil, err
 	}
 
+	// Time stops counting once the todo is completed
+	if err := s.timeTrackingService.StopTimerForTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// SnoozeTodo hides a todo from lists until the snooze preset ends in the user's timezone
+func (s *TodoService) SnoozeTodo(ctx context.Context, userID, todoID uuid.UUID, preset model.SnoozePreset, until *time.Time) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.SnoozeTodo")
+	defer func() { tracing.End(span, err) }()
+
+	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
+	if err != nil {
+		s.logger.Error("Failed to get todo for snooze", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user for snooze", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	snoozedUntil, err := model.ResolveSnooze(preset, until, time.Now().UTC(), user.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	todo.Snooze(snoozedUntil)
+
+	if err := s.todoRepo.Update(ctx, todo); err != nil {
+		s.logger.Error("Failed to snooze todo", "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// UnsnoozeTodo makes a snoozed todo active again
+func (s *TodoService) UnsnoozeTodo(ctx context.Context, userID, todoID uuid.UUID) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.UnsnoozeTodo")
+	defer func() { tracing.End(span, err) }()
+
+	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
+	if err != nil {
+		s.logger.Error("Failed to get todo for unsnooze", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	todo.Unsnooze()
+
+	if err := s.todoRepo.Update(ctx, todo); err != nil {
+		s.logger.Error("Failed to unsnooze todo", "todoID", todoID, "error", err)
+		return nil, err
+	}
+
 	return todo, nil
 }
 
 // GetOverdueTodos gets all overdue todos for a user
-func (s *TodoService) GetOverdueTodos(ctx context.Context, userID uuid.UUID) ([]*model.Todo, error) {
+func (s *TodoService) GetOverdueTodos(ctx context.Context, userID uuid.UUID) (_ []*model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.GetOverdueTodos")
+	defer func() { tracing.End(span, err) }()
+
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now().UTC()
 	filter := repository.TodoFilter{
-		UserID:      &userID,
-		DueDateTo:   &now,
-		SortBy:      "due_date",
-		SortOrder:   "asc",
+		UserID:       &userID,
+		DueDateTo:    &now,
+		DoneStatuses: workflow.DoneStatuses,
+		SortBy:       "due_date",
+		SortOrder:    "asc",
 	}
 
 	todos, err := s.todoRepo.List(ctx, filter)
@@ -175,13 +406,247 @@ func (s *TodoService) GetOverdueTodos(ctx context.Context, userID uuid.UUID) ([]
 		return nil, err
 	}
 
-	// Filter out completed and cancelled todos
+	// Filter out todos in a done status
 	var overdueTodos []*model.Todo
 	for _, todo := range todos {
-		if todo.Status != model.TodoStatusCompleted && todo.Status != model.TodoStatusCancelled {
+		if !workflow.IsDone(todo.Status) {
 			overdueTodos = append(overdueTodos, todo)
 		}
 	}
 
 	return overdueTodos, nil
 }
+
+// MoveTodo moves a todo into a status column, between the given neighbours.
+// A nil prevID places the todo at the top of the column and a nil nextID at the bottom.
+// Rebalancing the column, moving the todo and stopping its timer happen in one serializable transaction.
+func (s *TodoService) MoveTodo(ctx context.Context, userID, todoID uuid.UUID, status model.TodoStatus, prevID, nextID *uuid.UUID) (_ *model.Todo, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.MoveTodo")
+	defer func() { tracing.End(span, err) }()
+
+	var todo *model.Todo
+	err = s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		var err error
+		todo, err = s.moveTodo(ctx, userID, todoID, status, prevID, nextID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// moveTodo moves a todo into a status column, between the given neighbours
+func (s *TodoService) moveTodo(ctx context.Context, userID, todoID uuid.UUID, status model.TodoStatus, prevID, nextID *uuid.UUID) (*model.Todo, error) {
+	todo, err := s.todoRepo.GetByUserIDAndID(ctx, userID, todoID)
+	if err != nil {
+		s.logger.Error("Failed to get todo for move", "userID", userID, "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	if todo == nil {
+		return nil, model.ErrTodoNotFound
+	}
+
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the transition before touching any positions
+	if err := workflow.CheckTransition(todo.Status, status); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkStart(ctx, todo, status, workflow); err != nil {
+		return nil, err
+	}
+
+	position, err := s.positionBetween(ctx, userID, todoID, status, prevID, nextID)
+	if errors.Is(err, errPositionTooDense) {
+		// The neighbours are too close together, spread the column out and try again
+		if err := s.todoRepo.RebalancePositions(ctx, userID, status); err != nil {
+			s.logger.Error("Failed to rebalance todo positions", "userID", userID, "status", status, "error", err)
+			return nil, err
+		}
+		position, err = s.positionBetween(ctx, userID, todoID, status, prevID, nextID)
+		if errors.Is(err, errPositionTooDense) {
+			return nil, ErrInvalidNeighbours
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.Status != status {
+		todo.UpdateStatusIn(status, workflow)
+	}
+	todo.UpdatePosition(position)
+
+	if err := s.todoRepo.Update(ctx, todo); err != nil {
+		s.logger.Error("Failed to move todo", "todoID", todoID, "error", err)
+		return nil, err
+	}
+
+	if err := s.stopTimerIfDone(ctx, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// GetBoard gets a user's todos grouped into the status columns of their workflow, ordered by position
+func (s *TodoService) GetBoard(ctx context.Context, userID uuid.UUID) (_ []*model.BoardColumn, err error) {
+	ctx, span := tracing.Start(ctx, "TodoService.GetBoard")
+	defer func() { tracing.End(span, err) }()
+
+	workflow, err := s.workflowService.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []*model.BoardColumn
+	for _, status := range workflow.Statuses {
+		status := status
+		filter := repository.TodoFilter{
+			UserID:       &userID,
+			Status:       &status,
+			DoneStatuses: workflow.DoneStatuses,
+			Limit:        BoardColumnLimit,
+			SortBy:       "position",
+			SortOrder:    "asc",
+		}
+
+		todos, err := s.todoRepo.List(ctx, filter)
+		if err != nil {
+			s.logger.Error("Failed to get board column", "userID", userID, "status", status, "error", err)
+			return nil, err
+		}
+
+		if todos == nil {
+			todos = []*model.Todo{}
+		}
+
+		columns = append(columns, &model.BoardColumn{Status: status, Todos: todos})
+	}
+
+	return columns, nil
+}
+
+// checkStart refuses to move a todo into progress while it still has open blockers. Any status of the
+// workflow that is neither its initial status nor a done status counts as in progress.
+func (s *TodoService) checkStart(ctx context.Context, todo *model.Todo, status model.TodoStatus, workflow *model.Workflow) error {
+	if !workflow.IsInProgress(status) || workflow.IsInProgress(todo.Status) {
+		return nil
+	}
+
+	openBlockers, err := s.openBlockers(ctx, todo, workflow)
+	if err != nil {
+		return err
+	}
+
+	if len(openBlockers) > 0 {
+		blockerIDs := make([]uuid.UUID, len(openBlockers))
+		for i, blocker := range openBlockers {
+			blockerIDs[i] = blocker.ID
+		}
+		return &model.TodoBlockedError{TodoID: todo.ID, BlockerIDs: blockerIDs}
+	}
+
+	return nil
+}
+
+// stopTimerIfDone stops the running timer of a todo that reached a done status of the user's workflow
+func (s *TodoService) stopTimerIfDone(ctx context.Context, todo *model.Todo) error {
+	workflow, err := s.workflowService.GetWorkflow(ctx, todo.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !workflow.IsDone(todo.Status) {
+		return nil
+	}
+
+	return s.timeTrackingService.StopTimerForTodo(ctx, todo.UserID, todo.ID)
+}
+
+// openBlockers lists the blockers of a todo that are not in a done status
+func (s *TodoService) openBlockers(ctx context.Context, todo *model.Todo, workflow *model.Workflow) ([]*model.Todo, error) {
+	blockers, err := s.todoRepo.ListBlockers(ctx, todo.ID, workflow.DoneStatuses)
+	if err != nil {
+		s.logger.Error("Failed to list blockers", "todoID", todo.ID, "error", err)
+		return nil, err
+	}
+
+	var openBlockers []*model.Todo
+	for _, blocker := range blockers {
+		if !workflow.IsDone(blocker.Status) {
+			openBlockers = append(openBlockers, blocker)
+		}
+	}
+
+	return openBlockers, nil
+}
+
+// nextPosition returns a position at the end of a user's status column
+func (s *TodoService) nextPosition(ctx context.Context, userID uuid.UUID, status model.TodoStatus) (float64, error) {
+	maxPosition, err := s.todoRepo.MaxPosition(ctx, userID, status)
+	if err != nil {
+		s.logger.Error("Failed to get max todo position", "userID", userID, "status", status, "error", err)
+		return 0, err
+	}
+
+	return maxPosition + model.PositionStep, nil
+}
+
+// positionBetween resolves the neighbours of a move and returns the position between them
+func (s *TodoService) positionBetween(ctx context.Context, userID, todoID uuid.UUID, status model.TodoStatus, prevID, nextID *uuid.UUID) (float64, error) {
+	prev, err := s.neighbourPosition(ctx, userID, todoID, status, prevID)
+	if err != nil {
+		return 0, err
+	}
+
+	next, err := s.neighbourPosition(ctx, userID, todoID, status, nextID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Without neighbours the todo goes to the end of the column
+	if prev == nil && next == nil {
+		return s.nextPosition(ctx, userID, status)
+	}
+
+	position, ok := model.PositionBetween(prev, next)
+	if !ok {
+		return 0, errPositionTooDense
+	}
+
+	return position, nil
+}
+
+// neighbourPosition gets the position of a neighbour, which must live in the target column
+func (s *TodoService) neighbourPosition(ctx context.Context, userID, todoID uuid.UUID, status model.TodoStatus, neighbourID *uuid.UUID) (*float64, error) {
+	if neighbourID == nil {
+		return nil, nil
+	}
+
+	if *neighbourID == todoID {
+		return nil, ErrInvalidNeighbours
+	}
+
+	neighbour, err := s.todoRepo.GetByUserIDAndID(ctx, userID, *neighbourID)
+	if err != nil {
+		if errors.Is(err, model.ErrTodoNotFound) {
+			return nil, ErrInvalidNeighbours
+		}
+		s.logger.Error("Failed to get neighbour todo", "userID", userID, "todoID", *neighbourID, "error", err)
+		return nil, err
+	}
+
+	if neighbour.Status != status {
+		return nil, ErrInvalidNeighbours
+	}
+
+	return &neighbour.Position, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenClaims represents the claims in the tokens the API issues
+type TokenClaims struct {
+	UserID       uuid.UUID
+	Email        string
+	Fullname     string
+	Roles        []string
+	Purpose      string
+	SessionID    uuid.UUID
+	TokenVersion int
+	IssuedAt     time.Time
+	ExpiresAt    time.Time
+}
+
+// TokenIssuer defines the interface for issuing signed tokens
+type TokenIssuer interface {
+	// Issue signs a token with the given claims that expires after ttl.
+	// The issue and expiry times of the claims are set by the issuer.
+	Issue(claims TokenClaims, ttl time.Duration) (string, error)
+}
+
+// TokenVerifier defines the interface for verifying tokens
+type TokenVerifier interface {
+	// Verify checks a token's signature, issuer, audience and expiry and returns its claims
+	Verify(token string) (*TokenClaims, error)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// WorkflowService provides status workflow related functionality
+type WorkflowService struct {
+	workflowRepo repository.WorkflowRepository
+	todoRepo     repository.TodoRepository
+	txManager    repository.TxManager
+	logger       *logger.Logger
+}
+
+// NewWorkflowService creates a new workflow service
+func NewWorkflowService(workflowRepo repository.WorkflowRepository, todoRepo repository.TodoRepository, txManager repository.TxManager, logger *logger.Logger) *WorkflowService {
+	return &WorkflowService{
+		workflowRepo: workflowRepo,
+		todoRepo:     todoRepo,
+		txManager:    txManager,
+		logger:       logger,
+	}
+}
+
+// GetWorkflow gets a user's workflow, falling back to the default workflow
+func (s *WorkflowService) GetWorkflow(ctx context.Context, userID uuid.UUID) (*model.Workflow, error) {
+	workflow, err := s.workflowRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get workflow", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if workflow == nil {
+		return model.DefaultWorkflow(userID), nil
+	}
+
+	return workflow, nil
+}
+
+// UpdateWorkflow replaces a user's workflow.
+// The check of the statuses in use and the save run in one serializable transaction, like the
+// todo writes, so a todo cannot move into a status the new workflow drops in between.
+func (s *WorkflowService) UpdateWorkflow(ctx context.Context, userID uuid.UUID, statuses []model.TodoStatus, initialStatus model.TodoStatus, doneStatuses []model.TodoStatus, transitions map[model.TodoStatus][]model.TodoStatus) (*model.Workflow, error) {
+	workflow, err := model.NewWorkflow(userID, statuses, initialStatus, doneStatuses, transitions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		if err := s.checkStatusesInUse(ctx, workflow); err != nil {
+			return err
+		}
+
+		if err := s.workflowRepo.Save(ctx, workflow); err != nil {
+			s.logger.Error("Failed to save workflow", "userID", userID, "error", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// ResetWorkflow restores the default workflow for a user, in a serializable transaction like UpdateWorkflow
+func (s *WorkflowService) ResetWorkflow(ctx context.Context, userID uuid.UUID) (*model.Workflow, error) {
+	workflow := model.DefaultWorkflow(userID)
+
+	err := s.txManager.WithinTxOptions(ctx, repository.TxOptions{Isolation: repository.IsolationSerializable}, func(ctx context.Context) error {
+		if err := s.checkStatusesInUse(ctx, workflow); err != nil {
+			return err
+		}
+
+		if err := s.workflowRepo.Delete(ctx, userID); err != nil {
+			s.logger.Error("Failed to delete workflow", "userID", userID, "error", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// checkStatusesInUse makes sure no existing todo is left in a status the workflow drops
+func (s *WorkflowService) checkStatusesInUse(ctx context.Context, workflow *model.Workflow) error {
+	inUse, err := s.todoRepo.ListStatuses(ctx, workflow.UserID)
+	if err != nil {
+		s.logger.Error("Failed to list todo statuses", "userID", workflow.UserID, "error", err)
+		return err
+	}
+
+	for _, status := range inUse {
+		if !workflow.HasStatus(status) {
+			return model.ErrInvalidWorkflow.Errorf("status %q is still used by existing todos", status)
+		}
+	}
+
+	return nil
+}
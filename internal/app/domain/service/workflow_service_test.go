@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeWorkflowRepository keeps the workflows saved in memory
+type fakeWorkflowRepository struct {
+	repository.WorkflowRepository
+	workflows map[uuid.UUID]*model.Workflow
+}
+
+func (r *fakeWorkflowRepository) Save(ctx context.Context, workflow *model.Workflow) error {
+	r.workflows[workflow.UserID] = workflow
+	return nil
+}
+
+func TestUpdateWorkflowChecksStatusesInTransaction(t *testing.T) {
+	userID := uuid.New()
+	review := model.TodoStatus("review")
+	todo := model.NewTodo(userID, "Test Todo", "", model.TodoPriorityMedium, nil)
+	todo.Status = review
+
+	workflowRepo := &fakeWorkflowRepository{workflows: map[uuid.UUID]*model.Workflow{}}
+	txManager := &serialTxManager{}
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	workflowService := NewWorkflowService(workflowRepo, &fakeTodoRepository{todos: map[uuid.UUID]*model.Todo{todo.ID: todo}}, txManager, log)
+
+	// A workflow that drops a status in use is not saved
+	statuses := []model.TodoStatus{model.TodoStatusPending, model.TodoStatusCompleted}
+	transitions := map[model.TodoStatus][]model.TodoStatus{model.TodoStatusPending: {model.TodoStatusCompleted}}
+	if _, err := workflowService.UpdateWorkflow(context.Background(), userID, statuses, model.TodoStatusPending, []model.TodoStatus{model.TodoStatusCompleted}, transitions); !errors.Is(err, model.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if len(workflowRepo.workflows) != 0 {
+		t.Fatal("expected the workflow not to be saved")
+	}
+
+	// A workflow that keeps it is saved
+	statuses = append(statuses, review)
+	if _, err := workflowService.UpdateWorkflow(context.Background(), userID, statuses, model.TodoStatusPending, []model.TodoStatus{model.TodoStatusCompleted}, transitions); err != nil {
+		t.Fatalf("failed to update workflow: %v", err)
+	}
+	if workflowRepo.workflows[userID] == nil {
+		t.Fatal("expected the workflow to be saved")
+	}
+
+	if len(txManager.isolations) != 2 {
+		t.Fatalf("expected two transactions, got %d", len(txManager.isolations))
+	}
+	for _, isolation := range txManager.isolations {
+		if isolation != repository.IsolationSerializable {
+			t.Errorf("expected serializable transactions, got isolation %d", isolation)
+		}
+	}
+}
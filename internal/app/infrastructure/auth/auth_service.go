@@ -1,10 +1,13 @@
 package auth
 
 import (
+	"errors"
 	"time"
 
 	"github.com/sh1ro/todo-api/internal/app/domain/repository"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/config"
+	"github.com/sh1ro/todo-api/pkg/jwtkeys"
 	"github.com/sh1ro/todo-api/pkg/logger"
 )
 
@@ -12,11 +15,43 @@ import (
 // This is an adapter that creates the domain AuthService with infrastructure dependencies
 func NewAuthService(
 	userRepo repository.UserRepository,
+	txManager repository.TxManager,
+	passwords *service.PasswordService,
+	throttle *service.LoginThrottleService,
+	sessions *service.SessionService,
+	challengeRepo repository.MFAChallengeRepository,
 	logger *logger.Logger,
-	jwtSecret string,
+	tokens *TokenService,
 	jwtExpiration time.Duration,
 ) *service.AuthService {
 	// We directly use the domain AuthService implementation
 	// The infrastructure layer is just providing the dependencies
-	return service.NewAuthService(userRepo, logger, jwtSecret, jwtExpiration)
+	return service.NewAuthService(userRepo, txManager, passwords, throttle, sessions, challengeRepo, logger, tokens, tokens, jwtExpiration)
+}
+
+// LoadKeySet loads the keys tokens are signed and verified with. Without a signing key file,
+// tokens are signed with the HS256 secret and no keys are published.
+func LoadKeySet(cfg config.JWTConfig) (*jwtkeys.KeySet, error) {
+	if cfg.SigningKeyFile == "" {
+		if len(cfg.VerificationKeyFiles) > 0 {
+			return nil, errors.New("verification keys require a signing key file")
+		}
+		return jwtkeys.NewKeySet(jwtkeys.NewHMACKey([]byte(cfg.Secret)))
+	}
+
+	signing, err := jwtkeys.LoadFile(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := make([]*jwtkeys.Key, 0, len(cfg.VerificationKeyFiles))
+	for _, path := range cfg.VerificationKeyFiles {
+		key, err := jwtkeys.LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		verification = append(verification, key)
+	}
+
+	return jwtkeys.NewKeySet(signing, verification...)
 }
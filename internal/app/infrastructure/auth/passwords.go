@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"github.com/sh1ro/todo-api/pkg/config"
+	"github.com/sh1ro/todo-api/pkg/password"
+)
+
+// NewPasswordHasher creates the hasher new passwords are hashed with
+func NewPasswordHasher(cfg config.PasswordConfig) (*password.Hasher, error) {
+	return password.NewHasher(password.HasherOptions{
+		Algorithm:  cfg.HashAlgorithm,
+		BcryptCost: cfg.BcryptCost,
+		Argon2: password.Argon2Params{
+			Memory:      cfg.Argon2Memory,
+			Iterations:  cfg.Argon2Iterations,
+			Parallelism: cfg.Argon2Parallelism,
+		},
+	})
+}
+
+// LoadPasswordPolicy creates the password policy. Breached passwords are looked up in the list
+// at the configured path, or in the common passwords bundled with the password package.
+func LoadPasswordPolicy(cfg config.PasswordConfig) (*password.Policy, error) {
+	policy := &password.Policy{
+		MinLength:           cfg.MinLength,
+		MinCharacterClasses: cfg.MinCharacterClasses,
+		RejectPersonalInfo:  cfg.RejectPersonalInfo,
+	}
+
+	if !cfg.BreachedCheck {
+		return policy, nil
+	}
+
+	var source password.RangeSource = password.BundledSource()
+	if cfg.BreachedPath != "" {
+		loaded, err := password.LoadSource(cfg.BreachedPath)
+		if err != nil {
+			return nil, err
+		}
+		source = loaded
+	}
+	policy.Breached = password.NewBreachChecker(source)
+
+	return policy, nil
+}
@@ -1,125 +1,161 @@
 package auth
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
 )
 
-// TokenClaims represents the claims in a token
-type TokenClaims struct {
-	UserID   string `json:"user_id"`
-	Fullname string `json:"fullname"`
-	Email    string `json:"email"`
+// Signer signs tokens and provides the keys to verify them with
+type Signer interface {
+	// Sign signs claims into a compact JWT
+	Sign(claims jwt.Claims) (string, error)
+
+	// Keyfunc returns the key to verify a parsed token with
+	Keyfunc(token *jwt.Token) (interface{}, error)
+
+	// Algorithms returns the signing algorithms tokens may use
+	Algorithms() []string
+}
+
+// KeyPublisher is implemented by signers whose public keys can be published
+type KeyPublisher interface {
+	// JWKS returns the public keys as a JSON Web Key Set
+	JWKS() jose.JSONWebKeySet
+}
+
+// TokenOptions configures the tokens the TokenService issues and accepts
+type TokenOptions struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// tokenClaims represents the claims of a token on the wire
+type tokenClaims struct {
+	UserID    string   `json:"user_id"`
+	Email     string   `json:"email,omitempty"`
+	Fullname  string   `json:"fullname"`
+	Roles     []string `json:"roles,omitempty"`
+	Purpose   string   `json:"purpose,omitempty"`
+	SessionID string   `json:"sid,omitempty"`
+	Version   int      `json:"ver,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// TokenService handles token operations
+// The domain issues and verifies tokens through the TokenService, while publishing its keys stays in this layer
+var (
+	_ service.TokenIssuer   = (*TokenService)(nil)
+	_ service.TokenVerifier = (*TokenService)(nil)
+)
+
+// TokenService issues and verifies the API's JWT tokens
 type TokenService struct {
-	secretKey  []byte
-	expiration time.Duration
-	logger     *logger.Logger
+	signer Signer
+	opts   TokenOptions
+	logger *logger.Logger
 }
 
-// NewTokenService creates a new token service
-func NewTokenService(secretKey string, expiration time.Duration, logger *logger.Logger) *TokenService {
+// NewTokenService creates a new token service that signs with the given signer
+func NewTokenService(signer Signer, opts TokenOptions, logger *logger.Logger) *TokenService {
 	return &TokenService{
-		secretKey:  []byte(secretKey),
-		expiration: expiration,
-		logger:     logger,
+		signer: signer,
+		opts:   opts,
+		logger: logger,
 	}
 }
 
-// GenerateToken generates a token for a user
-func (s *TokenService) GenerateToken(user *model.User) (string, error) {
-	expirationTime := time.Now().Add(s.expiration)
-
-	claims := &TokenClaims{
-		UserID:   user.ID.String(),
-		Fullname: user.Fullname,
-		Email:    user.Email,
+// Issue signs a token with the given claims that expires after ttl
+func (s *TokenService) Issue(claims service.TokenClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+
+	wire := &tokenClaims{
+		UserID:   claims.UserID.String(),
+		Email:    claims.Email,
+		Fullname: claims.Fullname,
+		Roles:    claims.Roles,
+		Purpose:  claims.Purpose,
+		Version:  claims.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    "todo-api",
-			Subject:   user.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    s.opts.Issuer,
+			Audience:  jwt.ClaimStrings{s.opts.Audience},
+			Subject:   claims.UserID.String(),
 		},
 	}
+	if claims.SessionID != uuid.Nil {
+		wire.SessionID = claims.SessionID.String()
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.secretKey)
+	token, err := s.signer.Sign(wire)
 	if err != nil {
 		s.logger.Error("Failed to sign token", "error", err)
 		return "", err
 	}
 
-	return tokenString, nil
+	return token, nil
 }
 
-// ValidateToken validates a token and returns the claims
-func (s *TokenService) ValidateToken(tokenString string) (*TokenClaims, error) {
-	claims := &TokenClaims{}
-
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return s.secretKey, nil
-	})
-
+// Verify checks a token's signature, algorithm, issuer, audience and expiry and returns its claims
+func (s *TokenService) Verify(tokenString string) (*service.TokenClaims, error) {
+	claims := &tokenClaims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, s.signer.Keyfunc,
+		jwt.WithValidMethods(s.signer.Algorithms()),
+		jwt.WithIssuer(s.opts.Issuer),
+		jwt.WithAudience(s.opts.Audience),
+		jwt.WithLeeway(s.opts.Leeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 	if err != nil {
-		s.logger.Error("Failed to parse token", "error", err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", service.ErrInvalidToken, err)
 	}
 
 	if !token.Valid {
-		return nil, errors.New("invalid token")
-	}
-
-	return claims, nil
-}
-
-// ExtractUserID extracts the user ID from a token
-func (s *TokenService) ExtractUserID(tokenString string) (uuid.UUID, error) {
-	claims, err := s.ValidateToken(tokenString)
-	if err != nil {
-		return uuid.Nil, err
+		return nil, service.ErrInvalidToken
 	}
 
 	userID, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		s.logger.Error("Failed to parse user ID from token", "error", err)
-		return uuid.Nil, err
+		return nil, service.ErrInvalidToken
 	}
 
-	return userID, nil
-}
+	var sessionID uuid.UUID
+	if claims.SessionID != "" {
+		if sessionID, err = uuid.Parse(claims.SessionID); err != nil {
+			return nil, service.ErrInvalidToken
+		}
+	}
 
-// RefreshToken refreshes a token
-func (s *TokenService) RefreshToken(tokenString string) (string, error) {
-	claims, err := s.ValidateToken(tokenString)
-	if err != nil {
-		return "", err
+	result := &service.TokenClaims{
+		UserID:       userID,
+		Email:        claims.Email,
+		Fullname:     claims.Fullname,
+		Roles:        claims.Roles,
+		Purpose:      claims.Purpose,
+		SessionID:    sessionID,
+		TokenVersion: claims.Version,
+		ExpiresAt:    claims.ExpiresAt.Time,
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
 	}
 
-	// Create a new token with the same claims but a new expiration time
-	expirationTime := time.Now().Add(s.expiration)
-	claims.ExpiresAt = jwt.NewNumericDate(expirationTime)
-	claims.IssuedAt = jwt.NewNumericDate(time.Now())
-	claims.NotBefore = jwt.NewNumericDate(time.Now())
+	return result, nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	newTokenString, err := token.SignedString(s.secretKey)
-	if err != nil {
-		s.logger.Error("Failed to sign refreshed token", "error", err)
-		return "", err
+// PublicKeys returns the public keys others can verify tokens with, if the signer publishes any
+func (s *TokenService) PublicKeys() jose.JSONWebKeySet {
+	if publisher, ok := s.signer.(KeyPublisher); ok {
+		return publisher.JWKS()
 	}
-
-	return newTokenString, nil
+	return jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
 }
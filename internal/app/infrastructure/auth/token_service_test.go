@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/jwtkeys"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+func newTestTokenService(t *testing.T, keys *jwtkeys.KeySet, opts TokenOptions) *TokenService {
+	t.Helper()
+	return NewTokenService(keys, opts, logger.NewLogger("error", "json").WithOutput(io.Discard))
+}
+
+func newTestKeySet(t *testing.T) *jwtkeys.KeySet {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	key, err := jwtkeys.NewKey(private)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keys, err := jwtkeys.NewKeySet(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return keys
+}
+
+func TestTokenServiceIssueAndVerify(t *testing.T) {
+	tokens := newTestTokenService(t, newTestKeySet(t), TokenOptions{Issuer: "todo-api", Audience: "todo-api"})
+
+	claims := service.TokenClaims{
+		UserID:       uuid.New(),
+		Email:        "jane@example.com",
+		Fullname:     "Jane Doe",
+		Roles:        []string{"user"},
+		Purpose:      "mfa_challenge",
+		SessionID:    uuid.New(),
+		TokenVersion: 2,
+	}
+
+	token, err := tokens.Issue(claims, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	verified, err := tokens.Verify(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if verified.UserID != claims.UserID || verified.Email != claims.Email || verified.Fullname != claims.Fullname {
+		t.Errorf("unexpected claims: %+v", verified)
+	}
+	if len(verified.Roles) != 1 || verified.Roles[0] != "user" || verified.Purpose != claims.Purpose {
+		t.Errorf("unexpected roles or purpose: %+v", verified)
+	}
+	if verified.SessionID != claims.SessionID {
+		t.Errorf("expected session %v, got %v", claims.SessionID, verified.SessionID)
+	}
+	if verified.TokenVersion != claims.TokenVersion {
+		t.Errorf("expected token version %d, got %d", claims.TokenVersion, verified.TokenVersion)
+	}
+	if verified.IssuedAt.IsZero() || !verified.ExpiresAt.After(verified.IssuedAt) {
+		t.Errorf("unexpected times: issued %v, expires %v", verified.IssuedAt, verified.ExpiresAt)
+	}
+	if keys := tokens.PublicKeys().Keys; len(keys) != 1 {
+		t.Errorf("expected one published key, got %d", len(keys))
+	}
+}
+
+func TestTokenServiceVerifyRejectsOtherIssuerAndAudience(t *testing.T) {
+	keys := newTestKeySet(t)
+	tokens := newTestTokenService(t, keys, TokenOptions{Issuer: "todo-api", Audience: "todo-api"})
+
+	tests := []struct {
+		name string
+		opts TokenOptions
+	}{
+		{"issuer", TokenOptions{Issuer: "other", Audience: "todo-api"}},
+		{"audience", TokenOptions{Issuer: "todo-api", Audience: "other"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := newTestTokenService(t, keys, tt.opts).Issue(service.TokenClaims{UserID: uuid.New()}, time.Hour)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := tokens.Verify(token); err == nil {
+				t.Errorf("expected a token with another %s to be rejected", tt.name)
+			}
+		})
+	}
+}
+
+func TestTokenServiceVerifyLeeway(t *testing.T) {
+	keys := newTestKeySet(t)
+	strict := newTestTokenService(t, keys, TokenOptions{Issuer: "todo-api", Audience: "todo-api"})
+	lenient := newTestTokenService(t, keys, TokenOptions{Issuer: "todo-api", Audience: "todo-api", Leeway: time.Minute})
+
+	token, err := strict.Issue(service.TokenClaims{UserID: uuid.New()}, -10*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := strict.Verify(token); err == nil {
+		t.Error("expected an expired token to be rejected without leeway")
+	}
+	if _, err := lenient.Verify(token); err != nil {
+		t.Errorf("expected a token expired within the leeway to verify, got %v", err)
+	}
+}
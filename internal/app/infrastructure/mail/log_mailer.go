@@ -0,0 +1,28 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// LogMailer implements the Mailer interface by writing the recipient and subject of emails to the log.
+// Bodies are left out, as they carry verification and password reset tokens. It is meant for
+// development and cannot be used in production.
+type LogMailer struct {
+	logger *logger.Logger
+}
+
+// NewLogMailer creates a new LogMailer
+func NewLogMailer(logger *logger.Logger) service.Mailer {
+	return &LogMailer{
+		logger: logger,
+	}
+}
+
+// Send logs the recipient and subject of the email instead of sending it
+func (m *LogMailer) Send(ctx context.Context, msg service.MailMessage) error {
+	m.logger.Info("Email not sent, mail driver is log", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
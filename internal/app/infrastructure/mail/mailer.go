@@ -0,0 +1,21 @@
+package mail
+
+import (
+	"fmt"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/config"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// NewMailer creates the mailer selected by the mail driver configuration
+func NewMailer(cfg config.MailConfig, logger *logger.Logger) (service.Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "log", "":
+		return NewLogMailer(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,29 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/event"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// LogPublisher implements the event Publisher interface by writing events to the log
+type LogPublisher struct {
+	logger *logger.Logger
+}
+
+// NewLogPublisher creates a new LogPublisher
+func NewLogPublisher(logger *logger.Logger) event.Publisher {
+	return &LogPublisher{
+		logger: logger,
+	}
+}
+
+// Publish logs each event
+func (p *LogPublisher) Publish(ctx context.Context, events ...event.Event) error {
+	for _, e := range events {
+		p.logger.Info("Domain event published", "event", e.Name(), "payload", e)
+	}
+
+	return nil
+}
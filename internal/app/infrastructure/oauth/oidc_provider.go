@@ -0,0 +1,139 @@
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"golang.org/x/oauth2"
+)
+
+// httpTimeout bounds every request made to an identity provider
+const httpTimeout = 10 * time.Second
+
+// OIDCProvider is an OpenID Connect identity provider. Its endpoints and signing keys
+// are discovered from the issuer, and ID tokens are verified against its JWKS.
+type OIDCProvider struct {
+	name         string
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	client       *http.Client
+
+	mu       sync.Mutex
+	config   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCProvider creates a new OpenID Connect identity provider.
+// Discovery happens on first use, so an unreachable issuer does not keep the API from starting.
+func NewOIDCProvider(name, issuerURL, clientID, clientSecret, redirectURL string) service.IdentityProvider {
+	return &OIDCProvider{
+		name:         name,
+		issuerURL:    issuerURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		client:       &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// Name returns the name of the provider
+func (p *OIDCProvider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL returns the URL to send the user to for authorization
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	config, _, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	return config.AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	), nil
+}
+
+// Exchange exchanges an authorization code for the identity in the verified ID token
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error) {
+	config, verifier, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, p.client)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("no id_token in token response")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %w", err)
+	}
+
+	// The nonce binds the ID token to the login that was started, so it cannot be replayed
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id token claims: %w", err)
+	}
+
+	return &model.ExternalIdentity{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// discover fetches the issuer's configuration once it succeeds, retrying on later calls if it fails
+func (p *OIDCProvider) discover() (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config != nil {
+		return p.config, p.verifier, nil
+	}
+
+	// The provider keeps the context to refresh its keys, so it must outlive the request
+	provider, err := oidc.NewProvider(oidc.ClientContext(context.Background(), p.client), p.issuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover %s: %w", p.name, err)
+	}
+
+	p.config = &oauth2.Config{
+		ClientID:     p.clientID,
+		ClientSecret: p.clientSecret,
+		RedirectURL:  p.redirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.clientID})
+
+	return p.config, p.verifier, nil
+}
@@ -0,0 +1,239 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// mockIssuer is a minimal OpenID Connect provider that authorizes every request
+type mockIssuer struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+
+	mu    sync.Mutex
+	codes map[string]url.Values
+}
+
+func newMockIssuer(t *testing.T, clientID string) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	m := &mockIssuer{key: key, clientID: clientID, codes: map[string]url.Values{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/authorize",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &m.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		code := "code-" + query.Get("state")
+
+		m.mu.Lock()
+		m.codes[code] = query
+		m.mu.Unlock()
+
+		redirect := query.Get("redirect_uri") + "?code=" + url.QueryEscape(code) + "&state=" + url.QueryEscape(query.Get("state"))
+		http.Redirect(w, r, redirect, http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		m.mu.Lock()
+		authorization, ok := m.codes[r.PostForm.Get("code")]
+		delete(m.codes, r.PostForm.Get("code"))
+		m.mu.Unlock()
+
+		// Enforce PKCE the way a real provider does
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != authorization.Get("code_challenge") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     m.idToken(t, authorization.Get("nonce")),
+		})
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+// idToken signs an ID token for a fixed test user
+func (m *mockIssuer) idToken(t *testing.T, nonce string) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: m.key, KeyID: "test"}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	now := time.Now()
+	payload, _ := json.Marshal(map[string]interface{}{
+		"iss":            m.URL,
+		"sub":            "user-123",
+		"aud":            m.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+	})
+
+	signed, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("failed to sign id token: %v", err)
+	}
+
+	token, err := signed.CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to serialize id token: %v", err)
+	}
+	return token
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// authorize follows the authorization URL and returns the code the issuer redirects back with
+func authorize(t *testing.T, authURL string) string {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+	defer resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect: %v", err)
+	}
+	return location.Query().Get("code")
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	issuer := newMockIssuer(t, "todo-api")
+	provider := NewOIDCProvider("mock", issuer.URL, "todo-api", "secret", "http://localhost/oauth/callback/mock")
+
+	state, raw, err := model.NewOAuthState("mock", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, raw, state.Nonce, state.CodeChallenge())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code := authorize(t, authURL)
+
+	identity, err := provider.Exchange(ctx, code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if identity.Provider != "mock" || identity.Subject != "user-123" {
+		t.Errorf("unexpected identity: %s/%s", identity.Provider, identity.Subject)
+	}
+	if identity.Email != "jane@example.com" || !identity.EmailVerified || identity.Name != "Jane Doe" {
+		t.Errorf("unexpected claims: %+v", identity)
+	}
+}
+
+func TestOIDCProviderExchangeRejectsWrongVerifier(t *testing.T) {
+	issuer := newMockIssuer(t, "todo-api")
+	provider := NewOIDCProvider("mock", issuer.URL, "todo-api", "secret", "http://localhost/oauth/callback/mock")
+
+	state, raw, _ := model.NewOAuthState("mock", time.Minute)
+	other, _, _ := model.NewOAuthState("mock", time.Minute)
+
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, raw, state.Nonce, state.CodeChallenge())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := provider.Exchange(ctx, authorize(t, authURL), other.CodeVerifier, state.Nonce); err == nil {
+		t.Error("expected the exchange to fail with another login's verifier")
+	}
+}
+
+func TestOIDCProviderExchangeRejectsWrongNonce(t *testing.T) {
+	issuer := newMockIssuer(t, "todo-api")
+	provider := NewOIDCProvider("mock", issuer.URL, "todo-api", "secret", "http://localhost/oauth/callback/mock")
+
+	state, raw, _ := model.NewOAuthState("mock", time.Minute)
+
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, raw, state.Nonce, state.CodeChallenge())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := provider.Exchange(ctx, authorize(t, authURL), state.CodeVerifier, "another-nonce"); err == nil {
+		t.Error("expected the exchange to fail with another login's nonce")
+	}
+}
+
+func TestOIDCProviderExchangeRejectsOtherAudience(t *testing.T) {
+	issuer := newMockIssuer(t, "another-client")
+	provider := NewOIDCProvider("mock", issuer.URL, "todo-api", "secret", "http://localhost/oauth/callback/mock")
+
+	state, raw, _ := model.NewOAuthState("mock", time.Minute)
+
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, raw, state.Nonce, state.CodeChallenge())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := provider.Exchange(ctx, authorize(t, authURL), state.CodeVerifier, state.Nonce); err == nil {
+		t.Error("expected the exchange to fail for a token issued to another client")
+	}
+}
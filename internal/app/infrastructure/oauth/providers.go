@@ -0,0 +1,29 @@
+package oauth
+
+import (
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/config"
+)
+
+// googleIssuerURL is the OpenID Connect issuer of Google accounts
+const googleIssuerURL = "https://accounts.google.com"
+
+// NewProviders creates the identity providers enabled in the configuration.
+// Each provider redirects back to the callback URL followed by its name.
+func NewProviders(cfg config.OAuthConfig) []service.IdentityProvider {
+	var providers []service.IdentityProvider
+
+	if cfg.GoogleClientID != "" {
+		providers = append(providers, NewOIDCProvider("google", googleIssuerURL, cfg.GoogleClientID, cfg.GoogleClientSecret, cfg.CallbackURL+"/google"))
+	}
+
+	if cfg.GitHubClientID != "" {
+		providers = append(providers, NewGitHubProvider(cfg.GitHubClientID, cfg.GitHubClientSecret, cfg.CallbackURL+"/github"))
+	}
+
+	if cfg.OIDCClientID != "" {
+		providers = append(providers, NewOIDCProvider(cfg.OIDCName, cfg.OIDCIssuerURL, cfg.OIDCClientID, cfg.OIDCClientSecret, cfg.CallbackURL+"/"+cfg.OIDCName))
+	}
+
+	return providers
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresDependencyRepository implements the DependencyRepository interface for PostgreSQL
+type PostgresDependencyRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresDependencyRepository creates a new PostgresDependencyRepository
+func NewPostgresDependencyRepository(db *PostgresDB) repository.DependencyRepository {
+	return &PostgresDependencyRepository{
+		db: db,
+	}
+}
+
+// Create creates a new dependency
+func (r *PostgresDependencyRepository) Create(ctx context.Context, dependency *model.TodoDependency) error {
+	query := `
+		INSERT INTO todo_dependencies (todo_id, blocker_id, created_at)
+		VALUES ($1, $2, $3)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		dependency.TodoID,
+		dependency.BlockerID,
+		dependency.CreatedAt,
+	)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return model.ErrDependencyExists
+		}
+		return fmt.Errorf("failed to create dependency: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a dependency
+func (r *PostgresDependencyRepository) Delete(ctx context.Context, todoID, blockerID uuid.UUID) error {
+	query := `
+		DELETE FROM todo_dependencies
+		WHERE todo_id = $1 AND blocker_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, todoID, blockerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete dependency: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete dependency: %w", err)
+	}
+
+	if affected == 0 {
+		return model.ErrDependencyNotFound
+	}
+
+	return nil
+}
+
+// ListByUserID lists all dependencies between a user's todos
+func (r *PostgresDependencyRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.TodoDependency, error) {
+	query := `
+		SELECT d.todo_id, d.blocker_id, d.created_at
+		FROM todo_dependencies d
+		JOIN todos t ON t.id = d.todo_id
+		WHERE t.user_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	var dependencies []*model.TodoDependency
+	for rows.Next() {
+		var dependency model.TodoDependency
+		if err := rows.Scan(&dependency.TodoID, &dependency.BlockerID, &dependency.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		dependencies = append(dependencies, &dependency)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dependency rows: %w", err)
+	}
+
+	return dependencies, nil
+}
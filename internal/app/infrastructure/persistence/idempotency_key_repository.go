@@ -0,0 +1,168 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresIdempotencyKeyRepository implements the IdempotencyKeyRepository interface for PostgreSQL
+type PostgresIdempotencyKeyRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresIdempotencyKeyRepository creates a new PostgresIdempotencyKeyRepository
+func NewPostgresIdempotencyKeyRepository(db *PostgresDB) repository.IdempotencyKeyRepository {
+	return &PostgresIdempotencyKeyRepository{
+		db: db,
+	}
+}
+
+// Reserve saves a new idempotency key and reports whether it was saved.
+// Reserving happens in one statement, so only one of concurrent requests with the same key gets it.
+func (r *PostgresIdempotencyKeyRepository) Reserve(ctx context.Context, key *model.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			content_type = '',
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at < $6)
+		RETURNING key
+	`
+
+	var reserved string
+	err := r.db.QueryRowContext(ctx, query,
+		key.UserID,
+		key.Key,
+		key.Fingerprint,
+		key.CreatedAt,
+		key.ExpiresAt,
+		staleBefore,
+	).Scan(&reserved)
+	if err != nil {
+		// The key is taken, so nothing was returned
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return true, nil
+}
+
+// Get gets one of a user's idempotency keys
+func (r *PostgresIdempotencyKeyRepository) Get(ctx context.Context, userID uuid.UUID, key string) (*model.IdempotencyKey, error) {
+	query := `
+		SELECT user_id, key, fingerprint, status_code, content_type, response_body, created_at, completed_at, expires_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`
+
+	idempotencyKey, err := scanIdempotencyKey(r.db.QueryRowContext(ctx, query, userID, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return idempotencyKey, nil
+}
+
+// Complete saves the response of an idempotency key's request
+func (r *PostgresIdempotencyKeyRepository) Complete(ctx context.Context, key *model.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = $4, response_body = $5, completed_at = $6
+		WHERE user_id = $1 AND key = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		key.UserID,
+		key.Key,
+		key.StatusCode,
+		key.ContentType,
+		key.ResponseBody,
+		key.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	if affected == 0 {
+		return model.ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes one of a user's idempotency keys
+func (r *PostgresIdempotencyKeyRepository) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredByUserID deletes a user's idempotency keys that expired before the given time
+func (r *PostgresIdempotencyKeyRepository) DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND expires_at <= $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, before)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return nil
+}
+
+// scanIdempotencyKey scans an idempotency key from a row
+func scanIdempotencyKey(row rowScanner) (*model.IdempotencyKey, error) {
+	key := &model.IdempotencyKey{}
+	var statusCode sql.NullInt64
+	err := row.Scan(
+		&key.UserID,
+		&key.Key,
+		&key.Fingerprint,
+		&statusCode,
+		&key.ContentType,
+		&key.ResponseBody,
+		&key.CreatedAt,
+		&key.CompletedAt,
+		&key.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.StatusCode = int(statusCode.Int64)
+	return key, nil
+}
@@ -0,0 +1,136 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresLoginThrottleRepository implements the LoginThrottleRepository interface for PostgreSQL
+type PostgresLoginThrottleRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresLoginThrottleRepository creates a new PostgresLoginThrottleRepository
+func NewPostgresLoginThrottleRepository(db *PostgresDB) repository.LoginThrottleRepository {
+	return &PostgresLoginThrottleRepository{
+		db: db,
+	}
+}
+
+// Get gets the throttle of a key
+func (r *PostgresLoginThrottleRepository) Get(ctx context.Context, key string) (*model.LoginThrottle, error) {
+	query := `
+		SELECT key, failures, last_failed_at, locked_until
+		FROM login_throttles
+		WHERE key = $1
+	`
+
+	throttle, err := scanLoginThrottle(r.db.QueryRowContext(ctx, query, key))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrLoginThrottleNotFound
+		}
+		return nil, fmt.Errorf("failed to get login throttle: %w", err)
+	}
+
+	return throttle, nil
+}
+
+// RecordFailure counts a failed login for a key and returns its throttle.
+// Counting happens in one statement, so concurrent failures are never lost.
+func (r *PostgresLoginThrottleRepository) RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (*model.LoginThrottle, error) {
+	query := `
+		INSERT INTO login_throttles (key, failures, last_failed_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE
+				WHEN login_throttles.last_failed_at < $3
+					AND (login_throttles.locked_until IS NULL OR login_throttles.locked_until <= $2)
+				THEN 1
+				ELSE login_throttles.failures + 1
+			END,
+			locked_until = CASE
+				WHEN login_throttles.last_failed_at < $3
+					AND (login_throttles.locked_until IS NULL OR login_throttles.locked_until <= $2)
+				THEN NULL
+				ELSE login_throttles.locked_until
+			END,
+			last_failed_at = $2
+		RETURNING key, failures, last_failed_at, locked_until
+	`
+
+	throttle, err := scanLoginThrottle(r.db.QueryRowContext(ctx, query, key, now, resetBefore))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return throttle, nil
+}
+
+// Lock locks a key until the given time
+func (r *PostgresLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	query := `
+		UPDATE login_throttles
+		SET locked_until = $2
+		WHERE key = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, key, until)
+	if err != nil {
+		return fmt.Errorf("failed to lock login throttle: %w", err)
+	}
+
+	return nil
+}
+
+// Delete forgets the failures of a key
+func (r *PostgresLoginThrottleRepository) Delete(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM login_throttles
+		WHERE key = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete login throttle: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteStale deletes the unlocked throttles that failed last before the given time
+func (r *PostgresLoginThrottleRepository) DeleteStale(ctx context.Context, before, now time.Time) error {
+	query := `
+		DELETE FROM login_throttles
+		WHERE last_failed_at < $1 AND (locked_until IS NULL OR locked_until <= $2)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, before, now)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale login throttles: %w", err)
+	}
+
+	return nil
+}
+
+// scanLoginThrottle scans a login throttle from a row
+func scanLoginThrottle(row rowScanner) (*model.LoginThrottle, error) {
+	throttle := &model.LoginThrottle{}
+	err := row.Scan(
+		&throttle.Key,
+		&throttle.Failures,
+		&throttle.LastFailedAt,
+		&throttle.LockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return throttle, nil
+}
@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresMFAChallengeRepository implements the MFAChallengeRepository interface for PostgreSQL
+type PostgresMFAChallengeRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresMFAChallengeRepository creates a new PostgresMFAChallengeRepository
+func NewPostgresMFAChallengeRepository(db *PostgresDB) repository.MFAChallengeRepository {
+	return &PostgresMFAChallengeRepository{
+		db: db,
+	}
+}
+
+// Create creates a new MFA challenge
+func (r *PostgresMFAChallengeRepository) Create(ctx context.Context, challenge *model.MFAChallenge) error {
+	query := `
+		INSERT INTO mfa_challenges (id, user_id, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		challenge.ID,
+		challenge.UserID,
+		challenge.Attempts,
+		challenge.ExpiresAt,
+		challenge.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create MFA challenge: %w", err)
+	}
+
+	return nil
+}
+
+// UseAttempt counts an attempt at an unexpired challenge of a user and returns the challenge.
+// Counting and checking happen in one statement, so concurrent attempts cannot exceed the limit.
+func (r *PostgresMFAChallengeRepository) UseAttempt(ctx context.Context, userID, id uuid.UUID, now time.Time) (*model.MFAChallenge, error) {
+	query := `
+		UPDATE mfa_challenges
+		SET attempts = attempts + 1
+		WHERE id = $1 AND user_id = $2 AND expires_at > $3 AND attempts < $4
+		RETURNING id, user_id, attempts, expires_at, created_at
+	`
+
+	challenge := &model.MFAChallenge{}
+	err := r.db.QueryRowContext(ctx, query, id, userID, now, model.MFAChallengeMaxAttempts).Scan(
+		&challenge.ID,
+		&challenge.UserID,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+		&challenge.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrMFAChallengeNotFound
+		}
+		return nil, fmt.Errorf("failed to use MFA challenge attempt: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// Delete deletes a challenge, returning ErrMFAChallengeNotFound if it was already deleted
+func (r *PostgresMFAChallengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM mfa_challenges
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete MFA challenge: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete MFA challenge: %w", err)
+	}
+
+	if affected == 0 {
+		return model.ErrMFAChallengeNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the challenges that expired before the given time
+func (r *PostgresMFAChallengeRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := `
+		DELETE FROM mfa_challenges
+		WHERE expires_at <= $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired MFA challenges: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresOAuthStateRepository implements the OAuthStateRepository interface for PostgreSQL
+type PostgresOAuthStateRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresOAuthStateRepository creates a new PostgresOAuthStateRepository
+func NewPostgresOAuthStateRepository(db *PostgresDB) repository.OAuthStateRepository {
+	return &PostgresOAuthStateRepository{
+		db: db,
+	}
+}
+
+// Create creates a new OAuth state
+func (r *PostgresOAuthStateRepository) Create(ctx context.Context, state *model.OAuthState) error {
+	query := `
+		INSERT INTO oauth_states (state_hash, provider, code_verifier, nonce, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		state.StateHash,
+		state.Provider,
+		state.CodeVerifier,
+		state.Nonce,
+		state.ExpiresAt,
+		state.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create oauth state: %w", err)
+	}
+
+	return nil
+}
+
+// Consume deletes an unexpired OAuth state and returns it.
+// Deleting and reading happen in one statement, so a state can only be used once.
+func (r *PostgresOAuthStateRepository) Consume(ctx context.Context, stateHash string, now time.Time) (*model.OAuthState, error) {
+	query := `
+		DELETE FROM oauth_states
+		WHERE state_hash = $1 AND expires_at > $2
+		RETURNING state_hash, provider, code_verifier, nonce, expires_at, created_at
+	`
+
+	state := &model.OAuthState{}
+	err := r.db.QueryRowContext(ctx, query, stateHash, now).Scan(
+		&state.StateHash,
+		&state.Provider,
+		&state.CodeVerifier,
+		&state.Nonce,
+		&state.ExpiresAt,
+		&state.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvalidOAuthState
+		}
+		return nil, fmt.Errorf("failed to consume oauth state: %w", err)
+	}
+
+	return state, nil
+}
+
+// DeleteExpired deletes the OAuth states that expired before the given time
+func (r *PostgresOAuthStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := `
+		DELETE FROM oauth_states
+		WHERE expires_at <= $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired oauth states: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresPersonalAccessTokenRepository implements the PersonalAccessTokenRepository interface for PostgreSQL
+type PostgresPersonalAccessTokenRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresPersonalAccessTokenRepository creates a new PostgresPersonalAccessTokenRepository
+func NewPostgresPersonalAccessTokenRepository(db *PostgresDB) repository.PersonalAccessTokenRepository {
+	return &PostgresPersonalAccessTokenRepository{
+		db: db,
+	}
+}
+
+// Create creates a new personal access token
+func (r *PostgresPersonalAccessTokenRepository) Create(ctx context.Context, token *model.PersonalAccessToken) error {
+	query := `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Name,
+		token.TokenPrefix,
+		token.TokenHash,
+		pq.Array(scopeStrings(token.Scopes)),
+		token.ExpiresAt,
+		token.LastUsedAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash gets a personal access token by the hash of its raw value
+func (r *PostgresPersonalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE token_hash = $1
+	`
+
+	token, err := scanPersonalAccessToken(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccessTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetByUserIDAndID gets a personal access token by user ID and token ID
+func (r *PostgresPersonalAccessTokenRepository) GetByUserIDAndID(ctx context.Context, userID, id uuid.UUID) (*model.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE id = $1 AND user_id = $2
+	`
+
+	token, err := scanPersonalAccessToken(r.db.QueryRowContext(ctx, query, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAccessTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListByUserID lists a user's personal access tokens, newest first
+func (r *PostgresPersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.PersonalAccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_prefix, token_hash, scopes, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]*model.PersonalAccessToken, 0)
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan personal access token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating personal access token rows: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Delete deletes a user's personal access token
+func (r *PostgresPersonalAccessTokenRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		DELETE FROM personal_access_tokens
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access token: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access token: %w", err)
+	}
+
+	if affected == 0 {
+		return model.ErrAccessTokenNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records when a personal access token was last used
+func (r *PostgresPersonalAccessTokenRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `
+		UPDATE personal_access_tokens
+		SET last_used_at = $2
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update personal access token last use: %w", err)
+	}
+
+	return nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPersonalAccessToken scans a personal access token from a row
+func scanPersonalAccessToken(row rowScanner) (*model.PersonalAccessToken, error) {
+	var token model.PersonalAccessToken
+	var scopes []string
+	var expiresAt sql.NullTime
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenPrefix,
+		&token.TokenHash,
+		pq.Array(&scopes),
+		&expiresAt,
+		&lastUsedAt,
+		&token.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	token.Scopes = make([]model.TokenScope, len(scopes))
+	for i, scope := range scopes {
+		token.Scopes[i] = model.TokenScope(scope)
+	}
+
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return &token, nil
+}
+
+// scopeStrings converts token scopes to strings for array parameters
+func scopeStrings(scopes []model.TokenScope) []string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+	return values
+}
@@ -3,22 +3,32 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/sh1ro/todo-api/pkg/config"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// PostgresDB represents a PostgreSQL database connection
+// PostgresDB represents a PostgreSQL database connection.
+// Every query runs under the caller's context, so a cancelled request or shutdown stops its SQL,
+// and under the default query timeout if the context has no earlier deadline. Each statement gets
+// a span with its SQL, without the values of its literals.
 type PostgresDB struct {
-	DB     *sql.DB
-	logger *logger.Logger
+	DB           *sql.DB
+	logger       *logger.Logger
+	queryTimeout time.Duration
+	name         string
 }
 
 // NewPostgresDB creates a new PostgreSQL database connection
-func NewPostgresDB(cfg config.DatabaseConfig) (*PostgresDB, error) {
+func NewPostgresDB(ctx context.Context, cfg config.DatabaseConfig) (*PostgresDB, error) {
 	// Construct connection string
 	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode)
@@ -34,14 +44,19 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*PostgresDB, error) {
 	db.SetMaxIdleConns(cfg.MaxIdleConnections)
 	db.SetConnMaxLifetime(cfg.ConnectionMaxLifetime)
 
+	postgresDB := &PostgresDB{
+		DB:           db,
+		queryTimeout: cfg.QueryTimeout,
+		name:         cfg.Name,
+	}
+
 	// Test connection
-	if err := db.Ping(); err != nil {
+	if err := postgresDB.Ping(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &PostgresDB{
-		DB: db,
-	}, nil
+	return postgresDB, nil
 }
 
 // Close closes the database connection
@@ -54,69 +69,164 @@ func (db *PostgresDB) SetLogger(logger *logger.Logger) {
 	db.logger = logger
 }
 
-// Begin starts a new transaction
-func (db *PostgresDB) Begin() (*sql.Tx, error) {
-	return db.DB.Begin()
+// Ping checks the database connection
+func (db *PostgresDB) Ping(ctx context.Context) error {
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+	return db.DB.PingContext(ctx)
+}
+
+// MigrationVersion returns the version of the schema recorded by the migrate tool, and whether its
+// last migration failed halfway. A schema no migration ran on has version 0.
+func (db *PostgresDB) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
+
+// BeginTx starts a new transaction. The transaction is rolled back if the context is cancelled
+// before it is committed, so the context must outlive it; it gets no query timeout.
+func (db *PostgresDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return db.DB.BeginTx(ctx, opts)
 }
 
-// Query executes a query that returns rows
-func (db *PostgresDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+// ExecContext executes a query without returning any rows, inside the context's transaction if it carries one
+func (db *PostgresDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	if db.logger != nil {
-		db.logger.Debug("Executing query", "query", query, "args", args)
+		db.logger.Debug("Executing statement", "query", query, "args", args)
+	}
+
+	ctx, span := db.startSpan(ctx, query)
+	ctx, cancel := db.withQueryTimeout(ctx)
+	defer cancel()
+
+	var result sql.Result
+	var err error
+	if tx := txFromContext(ctx); tx != nil {
+		result, err = tx.ExecContext(ctx, query, args...)
+	} else {
+		result, err = db.DB.ExecContext(ctx, query, args...)
 	}
-	return db.DB.Query(query, args...)
+	tracing.End(span, err)
+
+	return result, err
 }
 
-// QueryRow executes a query that is expected to return at most one row
-func (db *PostgresDB) QueryRow(query string, args ...interface{}) *sql.Row {
+// QueryContext executes a query that returns rows, inside the context's transaction if it carries one.
+// The query timeout lasts until the rows are closed.
+func (db *PostgresDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
 	if db.logger != nil {
-		db.logger.Debug("Executing query row", "query", query, "args", args)
+		db.logger.Debug("Executing query", "query", query, "args", args)
 	}
-	return db.DB.QueryRow(query, args...)
+
+	ctx, span := db.startSpan(ctx, query)
+	ctx, cancel := db.withQueryTimeout(ctx)
+
+	var rows *sql.Rows
+	var err error
+	if tx := txFromContext(ctx); tx != nil {
+		rows, err = tx.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = db.DB.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		cancel()
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	return &Rows{Rows: rows, cancel: cancel, span: span}, nil
 }
 
-// Exec executes a query without returning any rows
-func (db *PostgresDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+// QueryRowContext executes a query that is expected to return at most one row, inside the context's transaction if it carries one.
+// The query timeout lasts until the row is scanned.
+func (db *PostgresDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
 	if db.logger != nil {
-		db.logger.Debug("Executing statement", "query", query, "args", args)
+		db.logger.Debug("Executing query row", "query", query, "args", args)
+	}
+
+	ctx, span := db.startSpan(ctx, query)
+	ctx, cancel := db.withQueryTimeout(ctx)
+
+	if tx := txFromContext(ctx); tx != nil {
+		return &Row{row: tx.QueryRowContext(ctx, query, args...), cancel: cancel, span: span}
 	}
-	return db.DB.Exec(query, args...)
+	return &Row{row: db.DB.QueryRowContext(ctx, query, args...), cancel: cancel, span: span}
 }
 
-// ExecWithTimeout executes a query with a timeout
-func (db *PostgresDB) ExecWithTimeout(timeout time.Duration, query string, args ...interface{}) (sql.Result, error) {
-	ctx, cancel := db.createTimeoutContext(timeout)
-	defer cancel()
+// startSpan starts the span of a statement, named after its operation, such as SELECT
+func (db *PostgresDB) startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	statement := tracing.SanitizeSQL(query)
+	operation := tracing.SQLOperation(statement)
+
+	return otel.Tracer(tracing.TracerName).Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBNamespace(db.name),
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(statement),
+		),
+	)
+}
 
-	if db.logger != nil {
-		db.logger.Debug("Executing statement with timeout", "query", query, "args", args, "timeout", timeout)
+// withQueryTimeout bounds the context by the default query timeout, if there is one.
+// An earlier deadline of the context is kept.
+func (db *PostgresDB) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
 	}
-	return db.DB.ExecContext(ctx, query, args...)
+	return context.WithTimeout(ctx, db.queryTimeout)
 }
 
-// QueryWithTimeout executes a query with a timeout
-func (db *PostgresDB) QueryWithTimeout(timeout time.Duration, query string, args ...interface{}) (*sql.Rows, error) {
-	ctx, cancel := db.createTimeoutContext(timeout)
-	defer cancel()
+// Rows is the result of a query. Closing it releases the query's context and ends its span.
+type Rows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+	span   trace.Span
+}
 
-	if db.logger != nil {
-		db.logger.Debug("Executing query with timeout", "query", query, "args", args, "timeout", timeout)
+// Close closes the rows, releases the query's context and ends its span
+func (r *Rows) Close() error {
+	defer r.cancel()
+	err := r.Rows.Close()
+	if r.span != nil {
+		tracing.End(r.span, errors.Join(r.Rows.Err(), err))
 	}
-	return db.DB.QueryContext(ctx, query, args...)
+	return err
 }
 
-// QueryRowWithTimeout executes a query with a timeout
-func (db *PostgresDB) QueryRowWithTimeout(timeout time.Duration, query string, args ...interface{}) *sql.Row {
-	ctx, cancel := db.createTimeoutContext(timeout)
-	defer cancel()
+// Row is the result of a query for a single row. Scanning it releases the query's context and ends its span.
+type Row struct {
+	row    *sql.Row
+	cancel context.CancelFunc
+	span   trace.Span
+}
 
-	if db.logger != nil {
-		db.logger.Debug("Executing query row with timeout", "query", query, "args", args, "timeout", timeout)
+// Scan copies the columns of the row into dest, releases the query's context and ends its span.
+// Finding no row is not an error of the span.
+func (r *Row) Scan(dest ...interface{}) error {
+	defer r.cancel()
+	err := r.row.Scan(dest...)
+	if r.span != nil {
+		spanErr := err
+		if errors.Is(err, sql.ErrNoRows) {
+			spanErr = nil
+		}
+		tracing.End(r.span, spanErr)
 	}
-	return db.DB.QueryRowContext(ctx, query, args...)
+	return err
 }
 
-// createTimeoutContext creates a context with a timeout
-func (db *PostgresDB) createTimeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), timeout)
+// Err returns the error of the query, if any, without scanning the row
+func (r *Row) Err() error {
+	return r.row.Err()
 }
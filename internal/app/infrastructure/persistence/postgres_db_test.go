@@ -0,0 +1,294 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+)
+
+// blockingQuery is run by the fake driver until its context is done
+const blockingQuery = "SELECT pg_sleep(60)"
+
+// fakeConnector opens connections to a fake database that records the context of the last query
+// and the statements run, including transaction control
+type fakeConnector struct {
+	mu         sync.Mutex
+	lastCtx    context.Context
+	statements []string
+
+	// err is returned by every statement, if set
+	err error
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (c *fakeConnector) record(ctx context.Context, statement string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastCtx = ctx
+	c.statements = append(c.statements, statement)
+}
+
+func (c *fakeConnector) recorded() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.statements...)
+}
+
+func (c *fakeConnector) last() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastCtx
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.connector.record(ctx, "BEGIN "+sql.IsolationLevel(opts.Isolation).String())
+	return &fakeTx{connector: c.connector}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.run(ctx, query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.run(ctx, query); err != nil {
+		return nil, err
+	}
+	return &fakeRows{values: []int64{1, 2, 3}}, nil
+}
+
+func (c *fakeConn) run(ctx context.Context, query string) error {
+	c.connector.record(ctx, query)
+	if query == blockingQuery {
+		<-ctx.Done()
+	}
+	if c.connector.err != nil {
+		return c.connector.err
+	}
+	return ctx.Err()
+}
+
+type fakeTx struct {
+	connector *fakeConnector
+}
+
+func (t *fakeTx) Commit() error {
+	t.connector.record(context.Background(), "COMMIT")
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.connector.record(context.Background(), "ROLLBACK")
+	return nil
+}
+
+type fakeRows struct {
+	values []int64
+	next   int
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"n"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	dest[0] = r.values[r.next]
+	r.next++
+	return nil
+}
+
+func newTestDB(t *testing.T, queryTimeout time.Duration) (*PostgresDB, *fakeConnector) {
+	t.Helper()
+
+	connector := &fakeConnector{}
+	db := &PostgresDB{DB: sql.OpenDB(connector), queryTimeout: queryTimeout}
+	t.Cleanup(func() { db.Close() })
+	return db, connector
+}
+
+func TestPostgresDBCancelsQueriesWithTheContext(t *testing.T) {
+	db, _ := newTestDB(t, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	if _, err := db.ExecContext(ctx, blockingQuery); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPostgresDBAppliesQueryTimeout(t *testing.T) {
+	db, _ := newTestDB(t, 20*time.Millisecond)
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{
+			name: "exec",
+			run: func(ctx context.Context) error {
+				_, err := db.ExecContext(ctx, blockingQuery)
+				return err
+			},
+		},
+		{
+			name: "query",
+			run: func(ctx context.Context) error {
+				_, err := db.QueryContext(ctx, blockingQuery)
+				return err
+			},
+		},
+		{
+			name: "query row",
+			run: func(ctx context.Context) error {
+				var n int64
+				return db.QueryRowContext(ctx, blockingQuery).Scan(&n)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresDBKeepsEarlierDeadline(t *testing.T) {
+	db, _ := newTestDB(t, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := db.ExecContext(ctx, blockingQuery); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the query to stop at the context deadline, took %v", elapsed)
+	}
+}
+
+func TestPostgresDBRowsOutliveQueryCall(t *testing.T) {
+	db, connector := newTestDB(t, time.Minute)
+
+	rows, err := db.QueryContext(context.Background(), "SELECT n FROM numbers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sum int64
+	for rows.Next() {
+		var n int64
+		if err := rows.Scan(&n); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sum += n
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum != 6 {
+		t.Fatalf("expected the sum of all rows to be 6, got %d", sum)
+	}
+
+	if connector.last().Err() != nil {
+		t.Fatal("expected the query context to stay open until the rows are closed")
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if connector.last().Err() == nil {
+		t.Fatal("expected closing the rows to release the query context")
+	}
+}
+
+func TestPostgresDBRowOutlivesQueryCall(t *testing.T) {
+	db, connector := newTestDB(t, time.Minute)
+
+	row := db.QueryRowContext(context.Background(), "SELECT n FROM numbers LIMIT 1")
+	if connector.last().Err() != nil {
+		t.Fatal("expected the query context to stay open until the row is scanned")
+	}
+
+	var n int64
+	if err := row.Scan(&n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1, got %d", n)
+	}
+	if connector.last().Err() == nil {
+		t.Fatal("expected scanning the row to release the query context")
+	}
+}
+
+func TestRepositoryStopsOnCancelledContext(t *testing.T) {
+	db, _ := newTestDB(t, time.Minute)
+	repo := NewPostgresTodoRepository(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestUserRepositoryReportsTakenEmail(t *testing.T) {
+	db, connector := newTestDB(t, time.Minute)
+	connector.err = &pq.Error{Code: "23505", Constraint: "users_email_key"}
+	repo := NewPostgresUserRepository(db)
+	user := &model.User{ID: uuid.New(), Email: "taken@example.com"}
+
+	if err := repo.Create(context.Background(), user); !errors.Is(err, model.ErrEmailTaken) {
+		t.Errorf("expected ErrEmailTaken from Create, got %v", err)
+	}
+	if err := repo.Update(context.Background(), user); !errors.Is(err, model.ErrEmailTaken) {
+		t.Errorf("expected ErrEmailTaken from Update, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresRecoveryCodeRepository implements the RecoveryCodeRepository interface for PostgreSQL
+type PostgresRecoveryCodeRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresRecoveryCodeRepository creates a new PostgresRecoveryCodeRepository
+func NewPostgresRecoveryCodeRepository(db *PostgresDB) repository.RecoveryCodeRepository {
+	return &PostgresRecoveryCodeRepository{
+		db: db,
+	}
+}
+
+// Create creates a new recovery code
+func (r *PostgresRecoveryCodeRepository) Create(ctx context.Context, code *model.RecoveryCode) error {
+	query := `
+		INSERT INTO mfa_recovery_codes (id, user_id, code_hash, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		code.ID,
+		code.UserID,
+		code.CodeHash,
+		code.UsedAt,
+		code.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create recovery code: %w", err)
+	}
+
+	return nil
+}
+
+// Consume marks an unused recovery code of a user as used.
+// Marking and checking happen in one statement, so a code can only be consumed once.
+func (r *PostgresRecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) error {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash, now)
+	if err != nil {
+		return fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+
+	if affected == 0 {
+		return model.ErrInvalidRecoveryCode
+	}
+
+	return nil
+}
+
+// DeleteByUserID deletes all recovery codes of a user
+func (r *PostgresRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		DELETE FROM mfa_recovery_codes
+		WHERE user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+// CountUnused counts the recovery codes a user has left
+func (r *PostgresRecoveryCodeRepository) CountUnused(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM mfa_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return count, nil
+}
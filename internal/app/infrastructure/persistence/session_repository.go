@@ -0,0 +1,179 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresSessionRepository implements the SessionRepository interface for PostgreSQL
+type PostgresSessionRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresSessionRepository creates a new PostgresSessionRepository
+func NewPostgresSessionRepository(db *PostgresDB) repository.SessionRepository {
+	return &PostgresSessionRepository{
+		db: db,
+	}
+}
+
+// Create creates a new session
+func (r *PostgresSessionRepository) Create(ctx context.Context, session *model.Session) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.UserAgent,
+		session.IP,
+		session.CreatedAt,
+		session.LastSeenAt,
+		session.ExpiresAt,
+		session.RevokedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveByUserID lists a user's active sessions, most recently seen first
+func (r *PostgresSessionRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*model.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*model.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating session rows: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch records that an active session was seen, failing if it has been revoked or has expired
+func (r *PostgresSessionRepository) Touch(ctx context.Context, userID, id uuid.UUID, now time.Time) error {
+	query := `
+		UPDATE user_sessions
+		SET last_seen_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > $3
+	`
+
+	return r.updateOne(ctx, "touch", query, id, userID, now)
+}
+
+// Revoke revokes one of a user's active sessions
+func (r *PostgresSessionRepository) Revoke(ctx context.Context, userID, id uuid.UUID, now time.Time) error {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > $3
+	`
+
+	return r.updateOne(ctx, "revoke", query, id, userID, now)
+}
+
+// RevokeAllByUserID revokes all of a user's active sessions
+func (r *PostgresSessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, now)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredByUserID deletes a user's sessions that expired before the given time
+func (r *PostgresSessionRepository) DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	query := `
+		DELETE FROM user_sessions
+		WHERE user_id = $1 AND expires_at <= $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, before)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	return nil
+}
+
+// updateOne runs an update of a single active session, reporting a missing one as not found
+func (r *PostgresSessionRepository) updateOne(ctx context.Context, action, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s session: %w", action, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to %s session: %w", action, err)
+	}
+
+	if affected == 0 {
+		return model.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// scanSession scans a session from a row
+func scanSession(row rowScanner) (*model.Session, error) {
+	var session model.Session
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return &session, nil
+}
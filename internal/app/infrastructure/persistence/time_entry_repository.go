@@ -0,0 +1,269 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresTimeEntryRepository implements the TimeEntryRepository interface for PostgreSQL
+type PostgresTimeEntryRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresTimeEntryRepository creates a new PostgresTimeEntryRepository
+func NewPostgresTimeEntryRepository(db *PostgresDB) repository.TimeEntryRepository {
+	return &PostgresTimeEntryRepository{
+		db: db,
+	}
+}
+
+// Create creates a new time entry
+func (r *PostgresTimeEntryRepository) Create(ctx context.Context, entry *model.TimeEntry) error {
+	query := `
+		INSERT INTO time_entries (id, user_id, todo_id, started_at, ended_at, note, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.UserID,
+		entry.TodoID,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Note,
+		entry.Source,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return model.ErrTimerAlreadyRunning
+		}
+		return fmt.Errorf("failed to create time entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserIDAndID gets a time entry by user ID and entry ID
+func (r *PostgresTimeEntryRepository) GetByUserIDAndID(ctx context.Context, userID, entryID uuid.UUID) (*model.TimeEntry, error) {
+	query := `
+		SELECT id, user_id, todo_id, started_at, ended_at, note, source, created_at, updated_at
+		FROM time_entries
+		WHERE user_id = $1 AND id = $2
+	`
+
+	entry, err := r.scanTimeEntry(r.db.QueryRowContext(ctx, query, userID, entryID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrTimeEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get time entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetRunningByUserID gets a user's running timer, or nil if none is running
+func (r *PostgresTimeEntryRepository) GetRunningByUserID(ctx context.Context, userID uuid.UUID) (*model.TimeEntry, error) {
+	query := `
+		SELECT id, user_id, todo_id, started_at, ended_at, note, source, created_at, updated_at
+		FROM time_entries
+		WHERE user_id = $1 AND ended_at IS NULL
+	`
+
+	entry, err := r.scanTimeEntry(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// ListByTodoID lists the time entries of a todo
+func (r *PostgresTimeEntryRepository) ListByTodoID(ctx context.Context, todoID uuid.UUID) ([]*model.TimeEntry, error) {
+	query := `
+		SELECT id, user_id, todo_id, started_at, ended_at, note, source, created_at, updated_at
+		FROM time_entries
+		WHERE todo_id = $1
+		ORDER BY started_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list time entries by todo ID: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTimeEntries(rows)
+}
+
+// ListByUserID lists a user's time entries started within [from, to)
+func (r *PostgresTimeEntryRepository) ListByUserID(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*model.TimeEntry, error) {
+	query := `
+		SELECT id, user_id, todo_id, started_at, ended_at, note, source, created_at, updated_at
+		FROM time_entries
+		WHERE user_id = $1 AND started_at >= $2 AND started_at < $3
+		ORDER BY started_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list time entries by user ID: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTimeEntries(rows)
+}
+
+// Timesheet aggregates a user's time entries started within [from, to) per day and todo
+func (r *PostgresTimeEntryRepository) Timesheet(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*model.TimesheetRow, error) {
+	query := `
+		SELECT
+			TO_CHAR(e.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day,
+			e.todo_id,
+			t.title,
+			FLOOR(SUM(EXTRACT(EPOCH FROM (COALESCE(e.ended_at, NOW()) - e.started_at))))::BIGINT AS seconds
+		FROM time_entries e
+		JOIN todos t ON t.id = e.todo_id
+		WHERE e.user_id = $1 AND e.started_at >= $2 AND e.started_at < $3
+		GROUP BY day, e.todo_id, t.title
+		ORDER BY day, t.title
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build timesheet: %w", err)
+	}
+	defer rows.Close()
+
+	timesheet := []*model.TimesheetRow{}
+	for rows.Next() {
+		var row model.TimesheetRow
+		if err := rows.Scan(&row.Date, &row.TodoID, &row.TodoTitle, &row.Seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan timesheet row: %w", err)
+		}
+		timesheet = append(timesheet, &row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating timesheet rows: %w", err)
+	}
+
+	return timesheet, nil
+}
+
+// Update updates a time entry
+func (r *PostgresTimeEntryRepository) Update(ctx context.Context, entry *model.TimeEntry) error {
+	query := `
+		UPDATE time_entries
+		SET started_at = $1, ended_at = $2, note = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Note,
+		time.Now().UTC(),
+		entry.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update time entry: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a time entry
+func (r *PostgresTimeEntryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `
+		DELETE FROM time_entries
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete time entry: %w", err)
+	}
+
+	return nil
+}
+
+// scanTimeEntry scans a time entry from a row
+func (r *PostgresTimeEntryRepository) scanTimeEntry(row rowScanner) (*model.TimeEntry, error) {
+	var entry model.TimeEntry
+	var endedAt sql.NullTime
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.TodoID,
+		&entry.StartedAt,
+		&endedAt,
+		&entry.Note,
+		&entry.Source,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if endedAt.Valid {
+		entry.EndedAt = &endedAt.Time
+	}
+
+	return &entry, nil
+}
+
+// scanTimeEntries scans all time entries from rows
+func (r *PostgresTimeEntryRepository) scanTimeEntries(rows *Rows) ([]*model.TimeEntry, error) {
+	entries := []*model.TimeEntry{}
+	for rows.Next() {
+		var entry model.TimeEntry
+		var endedAt sql.NullTime
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.TodoID,
+			&entry.StartedAt,
+			&endedAt,
+			&entry.Note,
+			&entry.Source,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+
+		if endedAt.Valid {
+			entry.EndedAt = &endedAt.Time
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating time entry rows: %w", err)
+	}
+
+	return entries, nil
+}
@@ -9,10 +9,22 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/repository"
 )
 
+// sortColumns are the columns todos can be sorted by; other values never reach the SQL
+var sortColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"due_date":   true,
+	"priority":   true,
+	"title":      true,
+	"status":     true,
+	"position":   true,
+}
+
 // PostgresTodoRepository implements the TodoRepository interface for PostgreSQL
 type PostgresTodoRepository struct {
 	db *PostgresDB
@@ -28,11 +40,11 @@ func NewPostgresTodoRepository(db *PostgresDB) repository.TodoRepository {
 // Create creates a new todo
 func (r *PostgresTodoRepository) Create(ctx context.Context, todo *model.Todo) error {
 	query := `
-		INSERT INTO todos (id, user_id, title, description, status, priority, due_date, created_at, updated_at, completed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO todos (id, user_id, title, description, status, priority, due_date, position, estimate_minutes, snoozed_until, created_at, updated_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	_, err := r.db.Exec(query,
+	_, err := r.db.ExecContext(ctx, query,
 		todo.ID,
 		todo.UserID,
 		todo.Title,
@@ -40,6 +52,9 @@ func (r *PostgresTodoRepository) Create(ctx context.Context, todo *model.Todo) e
 		todo.Status,
 		todo.Priority,
 		todo.DueDate,
+		todo.Position,
+		todo.EstimateMinutes,
+		todo.SnoozedUntil,
 		todo.CreatedAt,
 		todo.UpdatedAt,
 		todo.CompletedAt,
@@ -55,16 +70,16 @@ func (r *PostgresTodoRepository) Create(ctx context.Context, todo *model.Todo) e
 // GetByID gets a todo by ID
 func (r *PostgresTodoRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Todo, error) {
 	query := `
-		SELECT id, user_id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+		SELECT id, user_id, title, description, status, priority, due_date, position, estimate_minutes, snoozed_until, created_at, updated_at, completed_at
 		FROM todos
 		WHERE id = $1
 	`
 
-	row := r.db.QueryRow(query, id)
+	row := r.db.QueryRowContext(ctx, query, id)
 	todo, err := r.scanTodo(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("todo not found")
+			return nil, model.ErrTodoNotFound
 		}
 		return nil, fmt.Errorf("failed to get todo by ID: %w", err)
 	}
@@ -75,16 +90,16 @@ func (r *PostgresTodoRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 // GetByUserIDAndID gets a todo by user ID and todo ID
 func (r *PostgresTodoRepository) GetByUserIDAndID(ctx context.Context, userID, todoID uuid.UUID) (*model.Todo, error) {
 	query := `
-		SELECT id, user_id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+		SELECT id, user_id, title, description, status, priority, due_date, position, estimate_minutes, snoozed_until, created_at, updated_at, completed_at
 		FROM todos
 		WHERE user_id = $1 AND id = $2
 	`
 
-	row := r.db.QueryRow(query, userID, todoID)
+	row := r.db.QueryRowContext(ctx, query, userID, todoID)
 	todo, err := r.scanTodo(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("todo not found")
+			return nil, model.ErrTodoNotFound
 		}
 		return nil, fmt.Errorf("failed to get todo by user ID and todo ID: %w", err)
 	}
@@ -96,7 +111,7 @@ func (r *PostgresTodoRepository) GetByUserIDAndID(ctx context.Context, userID, t
 func (r *PostgresTodoRepository) List(ctx context.Context, filter repository.TodoFilter) ([]*model.Todo, error) {
 	query, args := r.buildListQuery(filter)
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list todos: %w", err)
 	}
@@ -129,7 +144,7 @@ func (r *PostgresTodoRepository) Count(ctx context.Context, filter repository.To
 	`, whereClause)
 
 	var count int
-	err := r.db.QueryRow(query, args...).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count todos: %w", err)
 	}
@@ -141,16 +156,19 @@ func (r *PostgresTodoRepository) Count(ctx context.Context, filter repository.To
 func (r *PostgresTodoRepository) Update(ctx context.Context, todo *model.Todo) error {
 	query := `
 		UPDATE todos
-		SET title = $1, description = $2, status = $3, priority = $4, due_date = $5, updated_at = $6, completed_at = $7
-		WHERE id = $8
+		SET title = $1, description = $2, status = $3, priority = $4, due_date = $5, position = $6, estimate_minutes = $7, snoozed_until = $8, updated_at = $9, completed_at = $10
+		WHERE id = $11
 	`
 
-	_, err := r.db.Exec(query,
+	_, err := r.db.ExecContext(ctx, query,
 		todo.Title,
 		todo.Description,
 		todo.Status,
 		todo.Priority,
 		todo.DueDate,
+		todo.Position,
+		todo.EstimateMinutes,
+		todo.SnoozedUntil,
 		time.Now().UTC(),
 		todo.CompletedAt,
 		todo.ID,
@@ -170,7 +188,7 @@ func (r *PostgresTodoRepository) Delete(ctx context.Context, id uuid.UUID) error
 		WHERE id = $1
 	`
 
-	_, err := r.db.Exec(query, id)
+	_, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete todo: %w", err)
 	}
@@ -185,7 +203,7 @@ func (r *PostgresTodoRepository) DeleteByUserID(ctx context.Context, userID uuid
 		WHERE user_id = $1
 	`
 
-	_, err := r.db.Exec(query, userID)
+	_, err := r.db.ExecContext(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete todos by user ID: %w", err)
 	}
@@ -193,11 +211,155 @@ func (r *PostgresTodoRepository) DeleteByUserID(ctx context.Context, userID uuid
 	return nil
 }
 
+// ListBlockers lists the todos blocking a todo
+func (r *PostgresTodoRepository) ListBlockers(ctx context.Context, todoID uuid.UUID, doneStatuses []model.TodoStatus) ([]*model.Todo, error) {
+	query := fmt.Sprintf(`
+		SELECT t.id, t.user_id, t.title, t.description, t.status, t.priority, t.due_date, t.position, t.estimate_minutes, t.snoozed_until, t.created_at, t.updated_at, t.completed_at, %s AS blocked
+		FROM todo_dependencies dep
+		JOIN todos t ON t.id = dep.blocker_id
+		WHERE dep.todo_id = $1
+		ORDER BY dep.created_at
+	`, blockedExpression("t", 2))
+
+	rows, err := r.db.QueryContext(ctx, query, todoID, pq.Array(statusStrings(doneStatuses)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blockers: %w", err)
+	}
+	defer rows.Close()
+
+	todos := []*model.Todo{}
+	for rows.Next() {
+		todo, err := r.scanTodoFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blocker: %w", err)
+		}
+		todos = append(todos, todo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blocker rows: %w", err)
+	}
+
+	return todos, nil
+}
+
+// WakeSnoozed clears the snooze of up to limit todos whose snooze ended at or before now.
+// The returned todos keep the time they were snoozed until. Rows locked by a concurrent
+// caller are skipped, so several instances can run the scheduler side by side.
+func (r *PostgresTodoRepository) WakeSnoozed(ctx context.Context, now time.Time, limit int) ([]*model.Todo, error) {
+	query := `
+		WITH due AS (
+			SELECT id, snoozed_until
+			FROM todos
+			WHERE snoozed_until <= $1
+			ORDER BY snoozed_until
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE todos t
+		SET snoozed_until = NULL
+		FROM due
+		WHERE t.id = due.id
+		RETURNING t.id, t.user_id, t.title, t.description, t.status, t.priority, t.due_date, t.position, t.estimate_minutes, due.snoozed_until, t.created_at, t.updated_at, t.completed_at, FALSE AS blocked
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wake snoozed todos: %w", err)
+	}
+	defer rows.Close()
+
+	var todos []*model.Todo
+	for rows.Next() {
+		todo, err := r.scanTodoFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan woken todo: %w", err)
+		}
+		todos = append(todos, todo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating woken todo rows: %w", err)
+	}
+
+	return todos, nil
+}
+
+// MaxPosition gets the highest position in a user's status column
+func (r *PostgresTodoRepository) MaxPosition(ctx context.Context, userID uuid.UUID, status model.TodoStatus) (float64, error) {
+	query := `
+		SELECT COALESCE(MAX(position), 0)
+		FROM todos
+		WHERE user_id = $1 AND status = $2
+	`
+
+	var position float64
+	err := r.db.QueryRowContext(ctx, query, userID, status).Scan(&position)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get max todo position: %w", err)
+	}
+
+	return position, nil
+}
+
+// ListStatuses lists the distinct statuses in use by a user's todos
+func (r *PostgresTodoRepository) ListStatuses(ctx context.Context, userID uuid.UUID) ([]model.TodoStatus, error) {
+	query := `
+		SELECT DISTINCT status
+		FROM todos
+		WHERE user_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todo statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []model.TodoStatus
+	for rows.Next() {
+		var status model.TodoStatus
+		if err := rows.Scan(&status); err != nil {
+			return nil, fmt.Errorf("failed to scan todo status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating todo status rows: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// RebalancePositions spreads the positions of a user's status column evenly, keeping their order
+func (r *PostgresTodoRepository) RebalancePositions(ctx context.Context, userID uuid.UUID, status model.TodoStatus) error {
+	query := `
+		UPDATE todos
+		SET position = ranked.rn * $3
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY position, created_at) AS rn
+			FROM todos
+			WHERE user_id = $1 AND status = $2
+		) AS ranked
+		WHERE todos.id = ranked.id
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, status, model.PositionStep)
+	if err != nil {
+		return fmt.Errorf("failed to rebalance todo positions: %w", err)
+	}
+
+	return nil
+}
+
 // scanTodo scans a todo from a row
-func (r *PostgresTodoRepository) scanTodo(row *sql.Row) (*model.Todo, error) {
+func (r *PostgresTodoRepository) scanTodo(row rowScanner) (*model.Todo, error) {
 	var todo model.Todo
 	var dueDate sql.NullTime
 	var completedAt sql.NullTime
+	var estimateMinutes sql.NullInt64
+	var snoozedUntil sql.NullTime
 
 	err := row.Scan(
 		&todo.ID,
@@ -207,6 +369,9 @@ func (r *PostgresTodoRepository) scanTodo(row *sql.Row) (*model.Todo, error) {
 		&todo.Status,
 		&todo.Priority,
 		&dueDate,
+		&todo.Position,
+		&estimateMinutes,
+		&snoozedUntil,
 		&todo.CreatedAt,
 		&todo.UpdatedAt,
 		&completedAt,
@@ -224,14 +389,25 @@ func (r *PostgresTodoRepository) scanTodo(row *sql.Row) (*model.Todo, error) {
 		todo.CompletedAt = &completedAt.Time
 	}
 
+	if estimateMinutes.Valid {
+		minutes := int(estimateMinutes.Int64)
+		todo.EstimateMinutes = &minutes
+	}
+
+	if snoozedUntil.Valid {
+		todo.SnoozedUntil = &snoozedUntil.Time
+	}
+
 	return &todo, nil
 }
 
-// scanTodoFromRows scans a todo from rows
-func (r *PostgresTodoRepository) scanTodoFromRows(rows *sql.Rows) (*model.Todo, error) {
+// scanTodoFromRows scans a todo, followed by its computed blocked flag, from rows
+func (r *PostgresTodoRepository) scanTodoFromRows(rows *Rows) (*model.Todo, error) {
 	var todo model.Todo
 	var dueDate sql.NullTime
 	var completedAt sql.NullTime
+	var estimateMinutes sql.NullInt64
+	var snoozedUntil sql.NullTime
 
 	err := rows.Scan(
 		&todo.ID,
@@ -241,9 +417,13 @@ func (r *PostgresTodoRepository) scanTodoFromRows(rows *sql.Rows) (*model.Todo,
 		&todo.Status,
 		&todo.Priority,
 		&dueDate,
+		&todo.Position,
+		&estimateMinutes,
+		&snoozedUntil,
 		&todo.CreatedAt,
 		&todo.UpdatedAt,
 		&completedAt,
+		&todo.Blocked,
 	)
 
 	if err != nil {
@@ -258,6 +438,15 @@ func (r *PostgresTodoRepository) scanTodoFromRows(rows *sql.Rows) (*model.Todo,
 		todo.CompletedAt = &completedAt.Time
 	}
 
+	if estimateMinutes.Valid {
+		minutes := int(estimateMinutes.Int64)
+		todo.EstimateMinutes = &minutes
+	}
+
+	if snoozedUntil.Valid {
+		todo.SnoozedUntil = &snoozedUntil.Time
+	}
+
 	return &todo, nil
 }
 
@@ -265,9 +454,13 @@ func (r *PostgresTodoRepository) scanTodoFromRows(rows *sql.Rows) (*model.Todo,
 func (r *PostgresTodoRepository) buildListQuery(filter repository.TodoFilter) (string, []interface{}) {
 	whereClause, args := r.buildWhereClause(filter)
 
+	// Add the computed blocked flag
+	args = append(args, pq.Array(statusStrings(filter.DoneStatuses)))
+	blocked := blockedExpression("todos", len(args))
+
 	// Add sorting
 	orderBy := "created_at DESC"
-	if filter.SortBy != "" {
+	if sortColumns[filter.SortBy] {
 		direction := "ASC"
 		if strings.ToLower(filter.SortOrder) == "desc" {
 			direction = "DESC"
@@ -286,12 +479,12 @@ func (r *PostgresTodoRepository) buildListQuery(filter repository.TodoFilter) (s
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+		SELECT id, user_id, title, description, status, priority, due_date, position, estimate_minutes, snoozed_until, created_at, updated_at, completed_at, %s AS blocked
 		FROM todos
 		%s
 		ORDER BY %s
 		LIMIT %d OFFSET %d
-	`, whereClause, orderBy, limit, offset)
+	`, blocked, whereClause, orderBy, limit, offset)
 
 	return query, args
 }
@@ -344,6 +537,18 @@ func (r *PostgresTodoRepository) buildWhereClause(filter repository.TodoFilter)
 		argIndex++
 	}
 
+	// Add actionable filter, which hides done and blocked todos
+	if filter.OnlyActionable {
+		conditions = append(conditions, fmt.Sprintf("NOT (status = ANY($%d)) AND NOT %s", argIndex, blockedExpression("todos", argIndex)))
+		args = append(args, pq.Array(statusStrings(filter.DoneStatuses)))
+		argIndex++
+	}
+
+	// Hide snoozed todos unless asked for
+	if !filter.IncludeSnoozed {
+		conditions = append(conditions, "(snoozed_until IS NULL OR snoozed_until <= NOW())")
+	}
+
 	// Build WHERE clause
 	whereClause := ""
 	if len(conditions) > 0 {
@@ -352,3 +557,21 @@ func (r *PostgresTodoRepository) buildWhereClause(filter repository.TodoFilter)
 
 	return whereClause, args
 }
+
+// blockedExpression returns a SQL expression that is true while the todo has blockers outside the done statuses in $argIndex
+func blockedExpression(table string, argIndex int) string {
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM todo_dependencies d
+			JOIN todos b ON b.id = d.blocker_id
+			WHERE d.todo_id = %s.id AND NOT (b.status = ANY($%d))
+		)`, table, argIndex)
+}
+
+// statusStrings converts statuses to strings for use as a SQL array
+func statusStrings(statuses []model.TodoStatus) []string {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	return values
+}
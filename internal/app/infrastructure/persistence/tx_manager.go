@@ -0,0 +1,181 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/config"
+)
+
+const (
+	// serializationFailure and deadlockDetected are the PostgreSQL error codes of transactions
+	// that may succeed if they are run again
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+
+	// retryBackoff is the longest wait before the first retry; it doubles with every retry
+	retryBackoff = 20 * time.Millisecond
+)
+
+// txKey is the context key of the transaction started by PostgresTxManager
+type txKey struct{}
+
+// txState is the transaction carried by a context
+type txState struct {
+	tx        *sql.Tx
+	isolation sql.IsolationLevel
+	depth     int
+}
+
+// PostgresTxManager implements the TxManager interface for PostgreSQL
+type PostgresTxManager struct {
+	db               *PostgresDB
+	defaultIsolation sql.IsolationLevel
+	maxRetries       int
+}
+
+// NewPostgresTxManager creates a new PostgresTxManager
+func NewPostgresTxManager(db *PostgresDB, cfg config.DatabaseConfig) repository.TxManager {
+	return &PostgresTxManager{
+		db:               db,
+		defaultIsolation: isolationFromConfig(cfg.TxIsolation),
+		maxRetries:       cfg.TxMaxRetries,
+	}
+}
+
+// WithinTx runs fn in a transaction with the default options
+func (m *PostgresTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.WithinTxOptions(ctx, repository.TxOptions{}, fn)
+}
+
+// WithinTxOptions runs fn in a transaction carried by the context, or in a savepoint of the
+// transaction the context already carries. Transactions that fail to serialize are retried.
+func (m *PostgresTxManager) WithinTxOptions(ctx context.Context, opts repository.TxOptions, fn func(ctx context.Context) error) error {
+	isolation := m.isolation(opts.Isolation)
+
+	if state := txStateFromContext(ctx); state != nil {
+		if isolation > state.isolation {
+			return fmt.Errorf("nested transaction cannot raise the isolation level from %s to %s", state.isolation, isolation)
+		}
+		return m.withinSavepoint(ctx, state, fn)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.run(ctx, &sql.TxOptions{Isolation: isolation, ReadOnly: opts.ReadOnly}, fn)
+		if err == nil || !isRetryable(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		if m.db.logger != nil {
+			m.db.logger.Debug("Retrying transaction", "attempt", attempt+1, "error", err)
+		}
+
+		// Wait a random time, so conflicting transactions don't collide again
+		wait := time.Duration(rand.Int63n(int64(retryBackoff << attempt)))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// run runs fn once in a new transaction
+func (m *PostgresTxManager) run(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	tx, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	state := &txState{tx: tx, isolation: opts.Isolation}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// withinSavepoint runs fn in a savepoint of the outer transaction
+func (m *PostgresTxManager) withinSavepoint(ctx context.Context, outer *txState, fn func(ctx context.Context) error) error {
+	state := &txState{tx: outer.tx, isolation: outer.isolation, depth: outer.depth + 1}
+	savepoint := fmt.Sprintf("sp_%d", state.depth)
+
+	if _, err := m.db.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if _, rbErr := m.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if _, err := m.db.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// isolation resolves the isolation level of a transaction
+func (m *PostgresTxManager) isolation(level repository.IsolationLevel) sql.IsolationLevel {
+	switch level {
+	case repository.IsolationReadCommitted:
+		return sql.LevelReadCommitted
+	case repository.IsolationRepeatableRead:
+		return sql.LevelRepeatableRead
+	case repository.IsolationSerializable:
+		return sql.LevelSerializable
+	default:
+		return m.defaultIsolation
+	}
+}
+
+// isolationFromConfig maps the configured isolation level to the SQL one
+func isolationFromConfig(name string) sql.IsolationLevel {
+	switch name {
+	case "repeatable_read":
+		return sql.LevelRepeatableRead
+	case "serializable":
+		return sql.LevelSerializable
+	default:
+		return sql.LevelReadCommitted
+	}
+}
+
+// isRetryable checks if a transaction failed because of a concurrent one, and may succeed if run again
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected
+}
+
+// txStateFromContext returns the transaction state carried by the context, if any
+func txStateFromContext(ctx context.Context) *txState {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	return state
+}
+
+// txFromContext returns the transaction carried by the context, if any
+func txFromContext(ctx context.Context) *sql.Tx {
+	if state := txStateFromContext(ctx); state != nil {
+		return state.tx
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/pkg/config"
+)
+
+func newTestTxManager(t *testing.T, cfg config.DatabaseConfig) (repository.TxManager, *PostgresDB, *fakeConnector) {
+	t.Helper()
+
+	db, connector := newTestDB(t, time.Minute)
+	return NewPostgresTxManager(db, cfg), db, connector
+}
+
+func TestTxManagerCommitsAndRollsBack(t *testing.T) {
+	txManager, db, connector := newTestTxManager(t, config.DatabaseConfig{})
+	ctx := context.Background()
+
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := db.ExecContext(ctx, "UPDATE todos SET title = 'a'")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	errFailed := errors.New("failed")
+	err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := db.ExecContext(ctx, "UPDATE todos SET title = 'b'"); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected errFailed, got %v", err)
+	}
+
+	expected := []string{
+		"BEGIN Read Committed", "UPDATE todos SET title = 'a'", "COMMIT",
+		"BEGIN Read Committed", "UPDATE todos SET title = 'b'", "ROLLBACK",
+	}
+	if got := connector.recorded(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestTxManagerNestsSavepoints(t *testing.T) {
+	txManager, db, connector := newTestTxManager(t, config.DatabaseConfig{})
+	errFailed := errors.New("failed")
+
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return txManager.WithinTx(ctx, func(ctx context.Context) error {
+				_, err := db.ExecContext(ctx, "INSERT INTO todos")
+				return err
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		// A failed nested call only undoes its own changes
+		err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := db.ExecContext(ctx, "DELETE FROM todos"); err != nil {
+				return err
+			}
+			return errFailed
+		})
+		if !errors.Is(err, errFailed) {
+			t.Fatalf("expected errFailed, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"BEGIN Read Committed",
+		"SAVEPOINT sp_1", "SAVEPOINT sp_2", "INSERT INTO todos", "RELEASE SAVEPOINT sp_2", "RELEASE SAVEPOINT sp_1",
+		"SAVEPOINT sp_1", "DELETE FROM todos", "ROLLBACK TO SAVEPOINT sp_1",
+		"COMMIT",
+	}
+	if got := connector.recorded(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestTxManagerIsolationLevels(t *testing.T) {
+	txManager, _, connector := newTestTxManager(t, config.DatabaseConfig{TxIsolation: "repeatable_read"})
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := txManager.WithinTx(context.Background(), noop); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serializable := repository.TxOptions{Isolation: repository.IsolationSerializable}
+	if err := txManager.WithinTxOptions(context.Background(), serializable, noop); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"BEGIN Repeatable Read", "COMMIT", "BEGIN Serializable", "COMMIT"}
+	if got := connector.recorded(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	// A nested call can't raise the isolation level of the transaction it joins
+	err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+		return txManager.WithinTxOptions(ctx, serializable, noop)
+	})
+	if err == nil {
+		t.Fatal("expected an error raising the isolation level of a nested transaction")
+	}
+}
+
+func TestTxManagerRetriesSerializationFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		failures     int
+		maxRetries   int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "serialization failure", err: &pq.Error{Code: serializationFailure}, failures: 2, maxRetries: 3, wantAttempts: 3},
+		{name: "deadlock", err: &pq.Error{Code: deadlockDetected}, failures: 1, maxRetries: 3, wantAttempts: 2},
+		{name: "too many failures", err: &pq.Error{Code: serializationFailure}, failures: 5, maxRetries: 2, wantAttempts: 3, wantErr: true},
+		{name: "other error", err: &pq.Error{Code: "23505"}, failures: 1, maxRetries: 3, wantAttempts: 1, wantErr: true},
+		{name: "retries disabled", err: &pq.Error{Code: serializationFailure}, failures: 1, maxRetries: 0, wantAttempts: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			txManager, _, _ := newTestTxManager(t, config.DatabaseConfig{TxMaxRetries: tt.maxRetries})
+
+			attempts := 0
+			err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+				// Failures in a nested call are retried by the outermost one
+				return txManager.WithinTx(ctx, func(ctx context.Context) error {
+					attempts++
+					if attempts <= tt.failures {
+						return tt.err
+					}
+					return nil
+				})
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresUserIdentityRepository implements the UserIdentityRepository interface for PostgreSQL
+type PostgresUserIdentityRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresUserIdentityRepository creates a new PostgresUserIdentityRepository
+func NewPostgresUserIdentityRepository(db *PostgresDB) repository.UserIdentityRepository {
+	return &PostgresUserIdentityRepository{
+		db: db,
+	}
+}
+
+// Create creates a new user identity
+func (r *PostgresUserIdentityRepository) Create(ctx context.Context, identity *model.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, last_login_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.LastLoginAt,
+		identity.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProviderAndSubject gets the identity a provider knows by the given subject
+func (r *PostgresUserIdentityRepository) GetByProviderAndSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	identity, err := scanUserIdentity(r.db.QueryRowContext(ctx, query, provider, subject))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// ListByUserID lists the identities linked to a user
+func (r *PostgresUserIdentityRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []*model.UserIdentity{}
+	for rows.Next() {
+		identity, err := scanUserIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// TouchLastLogin records a login through an identity, refreshing the email the provider reported
+func (r *PostgresUserIdentityRepository) TouchLastLogin(ctx context.Context, id uuid.UUID, email string, now time.Time) error {
+	query := `
+		UPDATE user_identities
+		SET email = $2, last_login_at = $3
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, email, now)
+	if err != nil {
+		return fmt.Errorf("failed to update user identity: %w", err)
+	}
+
+	return nil
+}
+
+// scanUserIdentity scans a user identity from a row
+func scanUserIdentity(row rowScanner) (*model.UserIdentity, error) {
+	identity := &model.UserIdentity{}
+	err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.LastLoginAt,
+		&identity.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
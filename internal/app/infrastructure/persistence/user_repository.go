@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/repository"
 )
@@ -27,20 +28,34 @@ func NewPostgresUserRepository(db *PostgresDB) repository.UserRepository {
 // Create creates a new user
 func (r *PostgresUserRepository) Create(ctx context.Context, user *model.User) error {
 	query := `
-		INSERT INTO users (id, fullname, email, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (id, fullname, email, password_hash, email_verified_at, tokens_revoked_at, token_version, timezone, locale, deletion_scheduled_at, mfa_secret, mfa_enabled_at, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err := r.db.Exec(query,
+	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
 		user.Fullname,
 		user.Email,
 		user.PasswordHash,
+		user.EmailVerifiedAt,
+		user.TokensRevokedAt,
+		user.TokenVersion,
+		user.Timezone,
+		user.Locale,
+		user.DeletionScheduledAt,
+		nullString(user.MFASecret),
+		user.MFAEnabledAt,
+		user.Role,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
 
 	if err != nil {
+		// The email is the only unique column that can be taken
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return model.ErrEmailTaken
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -50,82 +65,96 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *model.User) e
 // GetByID gets a user by ID
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	query := `
-		SELECT id, fullname, email, password_hash, created_at, updated_at
+		SELECT id, fullname, email, password_hash, email_verified_at, tokens_revoked_at, token_version, timezone, locale, deletion_scheduled_at, mfa_secret, mfa_enabled_at, role, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 
-	var user model.User
-	err := r.db.QueryRow(query, id).Scan(
-		&user.ID,
-		&user.Fullname,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := r.scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("user not found")
+			return nil, model.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // GetByEmail gets a user by email
 func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	query := `
-		SELECT id, fullname, email, password_hash, created_at, updated_at
+		SELECT id, fullname, email, password_hash, email_verified_at, tokens_revoked_at, token_version, timezone, locale, deletion_scheduled_at, mfa_secret, mfa_enabled_at, role, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
-	var user model.User
-	err := r.db.QueryRow(query, email).Scan(
-		&user.ID,
-		&user.Fullname,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := r.scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("user not found")
+			return nil, model.ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
-// Update updates a user
+// Update updates a user. The token version never goes back, so an update from a stale copy
+// of the user cannot undo a revocation.
 func (r *PostgresUserRepository) Update(ctx context.Context, user *model.User) error {
 	query := `
 		UPDATE users
-		SET fullname = $1, email = $2, password_hash = $3, updated_at = $4
-		WHERE id = $5
+		SET fullname = $1, email = $2, password_hash = $3, email_verified_at = $4, tokens_revoked_at = $5,
+			token_version = GREATEST(token_version, $6), timezone = $7, locale = $8, deletion_scheduled_at = $9,
+			mfa_secret = $10, mfa_enabled_at = $11, updated_at = $12
+		WHERE id = $13
 	`
 
-	_, err := r.db.Exec(query,
+	_, err := r.db.ExecContext(ctx, query,
 		user.Fullname,
 		user.Email,
 		user.PasswordHash,
+		user.EmailVerifiedAt,
+		user.TokensRevokedAt,
+		user.TokenVersion,
+		user.Timezone,
+		user.Locale,
+		user.DeletionScheduledAt,
+		nullString(user.MFASecret),
+		user.MFAEnabledAt,
 		time.Now().UTC(),
 		user.ID,
 	)
 
 	if err != nil {
+		// The email is the only unique column that can be taken
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return model.ErrEmailTaken
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
 	return nil
 }
 
+// UpdatePasswordHash replaces a user's password hash, unless it changed since oldHash was read
+func (r *PostgresUserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	query := `
+		UPDATE users
+		SET password_hash = $3, updated_at = $4
+		WHERE id = $1 AND password_hash = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, oldHash, newHash, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to update password hash: %w", err)
+	}
+
+	return nil
+}
+
 // Delete deletes a user
 func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `
@@ -133,7 +162,7 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 		WHERE id = $1
 	`
 
-	_, err := r.db.Exec(query, id)
+	_, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -151,10 +180,141 @@ func (r *PostgresUserRepository) Exists(ctx context.Context, email string) (bool
 	`
 
 	var exists bool
-	err := r.db.QueryRow(query, email).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, email).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check if user exists: %w", err)
 	}
 
 	return exists, nil
 }
+
+// ListDueForDeletion lists the IDs of users whose scheduled deletion is due
+func (r *PostgresUserRepository) ListDueForDeletion(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users due for deletion: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+// LockDueForDeletion locks a user for the rest of the transaction if their scheduled deletion is due
+func (r *PostgresUserRepository) LockDueForDeletion(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE id = $1 AND deletion_scheduled_at <= $2
+		FOR UPDATE
+	`
+
+	var lockedID uuid.UUID
+	err := r.db.QueryRowContext(ctx, query, id, now).Scan(&lockedID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to lock user for deletion: %w", err)
+	}
+
+	return true, nil
+}
+
+// UseMFAStep records a TOTP time step as used, unless the same or a later step was used before.
+// Checking and recording happen in one statement, so a code can only be accepted once.
+func (r *PostgresUserRepository) UseMFAStep(ctx context.Context, id uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE users
+		SET mfa_last_used_step = $2
+		WHERE id = $1 AND mfa_last_used_step < $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use mfa step: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanUser scans a user from a row
+func (r *PostgresUserRepository) scanUser(row rowScanner) (*model.User, error) {
+	var user model.User
+	var emailVerifiedAt sql.NullTime
+	var tokensRevokedAt sql.NullTime
+	var deletionScheduledAt sql.NullTime
+	var mfaSecret sql.NullString
+	var mfaEnabledAt sql.NullTime
+
+	err := row.Scan(
+		&user.ID,
+		&user.Fullname,
+		&user.Email,
+		&user.PasswordHash,
+		&emailVerifiedAt,
+		&tokensRevokedAt,
+		&user.TokenVersion,
+		&user.Timezone,
+		&user.Locale,
+		&deletionScheduledAt,
+		&mfaSecret,
+		&mfaEnabledAt,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if emailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+
+	if tokensRevokedAt.Valid {
+		user.TokensRevokedAt = &tokensRevokedAt.Time
+	}
+
+	if deletionScheduledAt.Valid {
+		user.DeletionScheduledAt = &deletionScheduledAt.Time
+	}
+
+	user.MFASecret = mfaSecret.String
+	if mfaEnabledAt.Valid {
+		user.MFAEnabledAt = &mfaEnabledAt.Time
+	}
+
+	return &user, nil
+}
+
+// nullString converts an empty string to NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresUserTokenRepository implements the UserTokenRepository interface for PostgreSQL
+type PostgresUserTokenRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresUserTokenRepository creates a new PostgresUserTokenRepository
+func NewPostgresUserTokenRepository(db *PostgresDB) repository.UserTokenRepository {
+	return &PostgresUserTokenRepository{
+		db: db,
+	}
+}
+
+// Create creates a new user token
+func (r *PostgresUserTokenRepository) Create(ctx context.Context, token *model.UserToken) error {
+	query := `
+		INSERT INTO user_tokens (id, user_id, purpose, token_hash, email, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Purpose,
+		token.TokenHash,
+		token.Email,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+
+	return nil
+}
+
+// Consume marks an unused, unexpired token as used and returns it.
+// Marking and checking happen in one statement, so a token can only be consumed once.
+func (r *PostgresUserTokenRepository) Consume(ctx context.Context, purpose model.UserTokenPurpose, tokenHash string, now time.Time) (*model.UserToken, error) {
+	query := `
+		UPDATE user_tokens
+		SET used_at = $3
+		WHERE purpose = $1 AND token_hash = $2 AND used_at IS NULL AND expires_at > $3
+		RETURNING id, user_id, purpose, token_hash, email, expires_at, used_at, created_at
+	`
+
+	var token model.UserToken
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, purpose, tokenHash, now).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.Email,
+		&token.ExpiresAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrInvalidUserToken
+		}
+		return nil, fmt.Errorf("failed to consume user token: %w", err)
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
+
+// InvalidateByUserID marks all of a user's unused tokens for a purpose as used
+func (r *PostgresUserTokenRepository) InvalidateByUserID(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose) error {
+	query := `
+		UPDATE user_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, purpose)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate user tokens: %w", err)
+	}
+
+	return nil
+}
+
+// CountSince counts the tokens created for a user and purpose since the given time
+func (r *PostgresUserTokenRepository) CountSince(ctx context.Context, userID uuid.UUID, purpose model.UserTokenPurpose, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_tokens
+		WHERE user_id = $1 AND purpose = $2 AND created_at >= $3
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID, purpose, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user tokens: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+)
+
+// PostgresWorkflowRepository implements the WorkflowRepository interface for PostgreSQL
+type PostgresWorkflowRepository struct {
+	db *PostgresDB
+}
+
+// NewPostgresWorkflowRepository creates a new PostgresWorkflowRepository
+func NewPostgresWorkflowRepository(db *PostgresDB) repository.WorkflowRepository {
+	return &PostgresWorkflowRepository{
+		db: db,
+	}
+}
+
+// GetByUserID gets a user's custom workflow, or nil if the user has none
+func (r *PostgresWorkflowRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.Workflow, error) {
+	query := `
+		SELECT user_id, statuses, initial_status, done_statuses, transitions, created_at, updated_at
+		FROM workflows
+		WHERE user_id = $1
+	`
+
+	var workflow model.Workflow
+	var statuses, doneStatuses, transitions []byte
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&workflow.UserID,
+		&statuses,
+		&workflow.InitialStatus,
+		&doneStatuses,
+		&transitions,
+		&workflow.CreatedAt,
+		&workflow.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workflow by user ID: %w", err)
+	}
+
+	if err := json.Unmarshal(statuses, &workflow.Statuses); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow statuses: %w", err)
+	}
+	if err := json.Unmarshal(doneStatuses, &workflow.DoneStatuses); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow done statuses: %w", err)
+	}
+	if err := json.Unmarshal(transitions, &workflow.Transitions); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow transitions: %w", err)
+	}
+
+	return &workflow, nil
+}
+
+// Save creates or replaces a user's custom workflow
+func (r *PostgresWorkflowRepository) Save(ctx context.Context, workflow *model.Workflow) error {
+	query := `
+		INSERT INTO workflows (user_id, statuses, initial_status, done_statuses, transitions, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET statuses = EXCLUDED.statuses,
+			initial_status = EXCLUDED.initial_status,
+			done_statuses = EXCLUDED.done_statuses,
+			transitions = EXCLUDED.transitions,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	statuses, err := json.Marshal(workflow.Statuses)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow statuses: %w", err)
+	}
+	doneStatuses, err := json.Marshal(workflow.DoneStatuses)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow done statuses: %w", err)
+	}
+	transitions, err := json.Marshal(workflow.Transitions)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow transitions: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		workflow.UserID,
+		statuses,
+		workflow.InitialStatus,
+		doneStatuses,
+		transitions,
+		workflow.CreatedAt,
+		time.Now().UTC(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a user's custom workflow
+func (r *PostgresWorkflowRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		DELETE FROM workflows
+		WHERE user_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// AccessTokenHandler handles personal access token requests
+type AccessTokenHandler struct {
+	BaseHandler
+	createAccessTokenHandler *command.CreateAccessTokenHandler
+	deleteAccessTokenHandler *command.DeleteAccessTokenHandler
+	listAccessTokensHandler  *query.ListAccessTokensHandler
+	getAccessTokenHandler    *query.GetAccessTokenHandler
+	validator                *validator.Validator
+}
+
+// NewAccessTokenHandler creates a new AccessTokenHandler
+func NewAccessTokenHandler(
+	createAccessTokenHandler *command.CreateAccessTokenHandler,
+	deleteAccessTokenHandler *command.DeleteAccessTokenHandler,
+	listAccessTokensHandler *query.ListAccessTokensHandler,
+	getAccessTokenHandler *query.GetAccessTokenHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		BaseHandler:              NewBaseHandler(logger),
+		createAccessTokenHandler: createAccessTokenHandler,
+		deleteAccessTokenHandler: deleteAccessTokenHandler,
+		listAccessTokensHandler:  listAccessTokensHandler,
+		getAccessTokenHandler:    getAccessTokenHandler,
+		validator:                validator,
+	}
+}
+
+// CreateToken handles creating a personal access token
+func (h *AccessTokenHandler) CreateToken(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.CreateAccessTokenCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for create access token", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	token, err := h.createAccessTokenHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to create personal access token", "error", err)
+		return err
+	}
+
+	// Return the token, whose raw value is only shown this once
+	return response.RespondWithCreated(c, "Personal access token created successfully", token)
+}
+
+// ListTokens handles listing the current user's personal access tokens
+func (h *AccessTokenHandler) ListTokens(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.ListAccessTokensQuery{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the query
+	tokens, err := h.listAccessTokensHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to list personal access tokens", "error", err)
+		return err
+	}
+
+	// Return the tokens
+	return response.RespondWithOK(c, "Personal access tokens retrieved successfully", tokens)
+}
+
+// GetToken handles getting one of the current user's personal access tokens
+func (h *AccessTokenHandler) GetToken(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse token ID
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid token ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.GetAccessTokenQuery{
+		UserID:  userID.(uuid.UUID),
+		TokenID: tokenID,
+	}
+
+	// Handle the query
+	token, err := h.getAccessTokenHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to get personal access token", "error", err)
+		return err
+	}
+
+	// Return the token
+	return response.RespondWithOK(c, "Personal access token retrieved successfully", token)
+}
+
+// DeleteToken handles revoking one of the current user's personal access tokens
+func (h *AccessTokenHandler) DeleteToken(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse token ID
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid token ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.DeleteAccessTokenCommand{
+		UserID:  userID.(uuid.UUID),
+		TokenID: tokenID,
+	}
+
+	// Handle the command
+	if err := h.deleteAccessTokenHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to revoke personal access token", "error", err)
+		return err
+	}
+
+	// Return success with no content
+	return response.RespondWithNoContent(c)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/password"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// AccountHandler handles email verification and password recovery requests
+type AccountHandler struct {
+	BaseHandler
+	verifyEmailHandler        *command.VerifyEmailHandler
+	resendVerificationHandler *command.ResendVerificationHandler
+	forgotPasswordHandler     *command.ForgotPasswordHandler
+	resetPasswordHandler      *command.ResetPasswordHandler
+	validator                 *validator.Validator
+}
+
+// NewAccountHandler creates a new AccountHandler
+func NewAccountHandler(
+	verifyEmailHandler *command.VerifyEmailHandler,
+	resendVerificationHandler *command.ResendVerificationHandler,
+	forgotPasswordHandler *command.ForgotPasswordHandler,
+	resetPasswordHandler *command.ResetPasswordHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *AccountHandler {
+	return &AccountHandler{
+		BaseHandler:               NewBaseHandler(logger),
+		verifyEmailHandler:        verifyEmailHandler,
+		resendVerificationHandler: resendVerificationHandler,
+		forgotPasswordHandler:     forgotPasswordHandler,
+		resetPasswordHandler:      resetPasswordHandler,
+		validator:                 validator,
+	}
+}
+
+// VerifyEmail handles verifying an email with a verification token
+func (h *AccountHandler) VerifyEmail(c echo.Context) error {
+	var cmd command.VerifyEmailCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for email verification", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	user, err := h.verifyEmailHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to verify email", "error", err)
+		return err
+	}
+
+	// Return the verified user
+	return response.RespondWithOK(c, "Email verified successfully", user)
+}
+
+// ResendVerification handles sending a new email verification link to the current user
+func (h *AccountHandler) ResendVerification(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.ResendVerificationCommand{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the command
+	if err := h.resendVerificationHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to resend verification email", "error", err)
+		return err
+	}
+
+	return response.RespondWithSuccess(c, http.StatusAccepted, "Verification email sent", nil)
+}
+
+// ForgotPassword handles requesting a password reset link
+func (h *AccountHandler) ForgotPassword(c echo.Context) error {
+	var cmd command.ForgotPasswordCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for forgot password", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	if err := h.forgotPasswordHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to request password reset", "error", err)
+		return err
+	}
+
+	// The response is the same whether or not the email is registered
+	return response.RespondWithSuccess(c, http.StatusAccepted, "If the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword handles setting a new password with a reset token
+func (h *AccountHandler) ResetPassword(c echo.Context) error {
+	var cmd command.ResetPasswordCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for password reset", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	if err := h.resetPasswordHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to reset password", "error", err)
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return response.RespondWithValidationError(c, "Validation failed", []validator.ValidationError{
+				{Field: "password", Message: "password " + policyErr.Message},
+			})
+		}
+		return err
+	}
+
+	return response.RespondWithOK(c, "Password reset successfully, please log in again", nil)
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// AdminHandler handles requests administering other accounts
+type AdminHandler struct {
+	BaseHandler
+	unlockLoginHandler *command.UnlockLoginHandler
+	validator          *validator.Validator
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(
+	unlockLoginHandler *command.UnlockLoginHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		BaseHandler:        NewBaseHandler(logger),
+		unlockLoginHandler: unlockLoginHandler,
+		validator:          validator,
+	}
+}
+
+// UnlockLogin handles lifting the login lockout of an email or client IP
+func (h *AdminHandler) UnlockLogin(c echo.Context) error {
+	// Parse request body
+	var cmd command.UnlockLoginCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for login unlock", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	if err := h.unlockLoginHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to unlock login", "error", err)
+		return err
+	}
+
+	return response.RespondWithOK(c, "Login unlocked", nil)
+}
@@ -1,10 +1,15 @@
 package api
 
 import (
+	"errors"
+
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/password"
 	"github.com/sh1ro/todo-api/pkg/response"
 	"github.com/sh1ro/todo-api/pkg/validator"
 )
@@ -55,7 +60,13 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	user, err := h.registerUserHandler.Handle(c, cmd)
 	if err != nil {
 		log.Error("Failed to register user", "error", err)
-		return response.RespondWithInternalError(c, err.Error())
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return response.RespondWithValidationError(c, "Validation failed", []validator.ValidationError{
+				{Field: "password", Message: "password " + policyErr.Message},
+			})
+		}
+		return err
 	}
 
 	// Return the user with a JWT token
@@ -73,6 +84,9 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	log := h.GetLogger(c)
     
 
+	// Set the client, which failed logins are throttled by and sessions are recorded with
+	cmd.Client = h.GetClientInfo(c)
+
 	// Validate the command
 	if errors := h.validator.Validate(cmd); errors != nil {
 		log.Error("Validation failed for user login", "errors", errors)
@@ -80,17 +94,23 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	}
 
 	// Handle the command
-	user, err := h.loginUserHandler.Handle(c, cmd)
+	result, err := h.loginUserHandler.Handle(c, cmd)
 	if err != nil {
 		log.Error("Failed to login user", "error", err)
-		if err.Error() == "invalid credentials" {
-			return response.RespondWithUnauthorized(c, "Invalid email or password")
+		var throttled *service.LoginThrottledError
+		if errors.As(err, &throttled) {
+			return h.RespondWithLoginThrottled(c, throttled)
 		}
-		return response.RespondWithInternalError(c, err.Error())
+		return err
+	}
+
+	// Users with 2FA enabled have to exchange the challenge token at /auth/mfa/verify
+	if result.MFARequired {
+		return response.RespondWithOK(c, "Two-factor authentication required", result)
 	}
 
 	// Return the user with a JWT token
-	return response.RespondWithOK(c, "User logged in successfully", user)
+	return response.RespondWithOK(c, "User logged in successfully", result)
 }
 
 // Me handles getting the current user
@@ -106,17 +126,14 @@ func (h *AuthHandler) Me(c echo.Context) error {
 
 	// Create command
 	cmd := command.GetUserCommand{
-		UserID: userID.(string),
+		UserID: userID.(uuid.UUID).String(),
 	}
 
 	// Handle the command
 	user, err := h.getUserHandler.Handle(c, cmd)
 	if err != nil {
 		log.Error("Failed to get user", "error", err)
-		if err.Error() == "user not found" {
-			return response.RespondWithNotFound(c, "User not found")
-		}
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Return the user
@@ -1,8 +1,15 @@
 package api
 
 import (
+	"math"
+	"net/http"
+	"strconv"
+
 	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
 )
 
 // BaseHandler provides common functionality for all handlers
@@ -30,6 +37,22 @@ func (h *BaseHandler) GetRequestID(c echo.Context) string {
 	return c.Response().Header().Get(echo.HeaderXRequestID)
 }
 
+// GetClientInfo returns the user agent and IP of the client making the request
+func (h *BaseHandler) GetClientInfo(c echo.Context) model.ClientInfo {
+	return model.ClientInfo{
+		UserAgent: c.Request().UserAgent(),
+		IP:        c.RealIP(),
+	}
+}
+
+// RespondWithLoginThrottled responds to a login that has to wait after too many failures,
+// telling the client how long in a Retry-After header
+func (h *BaseHandler) RespondWithLoginThrottled(c echo.Context, throttled *service.LoginThrottledError) error {
+	retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	return response.RespondWithError(c, http.StatusTooManyRequests, "Too many failed login attempts, please try again later")
+}
+
 // GetUserID returns the user ID from the context
 func (h *BaseHandler) GetUserID(c echo.Context) interface{} {
 	userID := c.Get("user_id")
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// DependencyHandler handles todo dependency requests
+type DependencyHandler struct {
+	BaseHandler
+	addBlockerHandler    *command.AddBlockerHandler
+	removeBlockerHandler *command.RemoveBlockerHandler
+	listBlockersHandler  *query.ListBlockersHandler
+	validator            *validator.Validator
+}
+
+// NewDependencyHandler creates a new DependencyHandler
+func NewDependencyHandler(
+	addBlockerHandler *command.AddBlockerHandler,
+	removeBlockerHandler *command.RemoveBlockerHandler,
+	listBlockersHandler *query.ListBlockersHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *DependencyHandler {
+	return &DependencyHandler{
+		BaseHandler:          NewBaseHandler(logger),
+		addBlockerHandler:    addBlockerHandler,
+		removeBlockerHandler: removeBlockerHandler,
+		listBlockersHandler:  listBlockersHandler,
+		validator:            validator,
+	}
+}
+
+// ListBlockers handles listing the blockers of a todo
+func (h *DependencyHandler) ListBlockers(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.ListBlockersQuery{
+		UserID: userID.(uuid.UUID),
+		TodoID: todoID,
+	}
+
+	// Handle the query
+	blockers, err := h.listBlockersHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to list blockers", "error", err)
+		return err
+	}
+
+	// Return the blockers
+	return response.RespondWithOK(c, "Blockers retrieved successfully", blockers)
+}
+
+// AddBlocker handles marking a todo as blocked by another todo
+func (h *DependencyHandler) AddBlocker(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Parse request body
+	var cmd command.AddBlockerCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the todo ID and user ID
+	cmd.TodoID = todoID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for add blocker", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	dependency, err := h.addBlockerHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to add blocker", "error", err)
+		if errors.Is(err, model.ErrDependencyCycle) {
+			return response.RespondWithUnprocessableEntity(c, "Blocker would create a dependency cycle")
+		}
+		return err
+	}
+
+	// Return the dependency
+	return response.RespondWithCreated(c, "Blocker added successfully", dependency)
+}
+
+// RemoveBlocker handles removing a blocker from a todo
+func (h *DependencyHandler) RemoveBlocker(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Parse blocker ID
+	blockerID, err := uuid.Parse(c.Param("blockerId"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid blocker ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.RemoveBlockerCommand{
+		UserID:    userID.(uuid.UUID),
+		TodoID:    todoID,
+		BlockerID: blockerID,
+	}
+
+	// Handle the command
+	err = h.removeBlockerHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to remove blocker", "error", err)
+		return err
+	}
+
+	// Return success with no content
+	return response.RespondWithNoContent(c)
+}
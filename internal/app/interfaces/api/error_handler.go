@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// internalErrorMessage is shown instead of the details of unexpected errors when they are hidden
+const internalErrorMessage = "Internal server error"
+
+// NewHTTPErrorHandler creates the error handler that turns errors returned by handlers and
+// middleware into error responses. Domain errors get the status of their kind and their own
+// error code; any other error is a 500, whose detail is only shown if exposeInternal is set.
+func NewHTTPErrorHandler(log *logger.Logger, exposeInternal bool) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		status, errorCode, message := describeError(err, exposeInternal)
+		if status >= http.StatusInternalServerError {
+			logger.FromContext(c).Error("Request failed", "error", err)
+		}
+
+		var respErr error
+		if c.Request().Method == http.MethodHead {
+			respErr = c.NoContent(status)
+		} else {
+			respErr = response.RespondWithErrorCode(c, status, errorCode, message)
+		}
+		if respErr != nil {
+			log.Error("Failed to send error response", "error", respErr)
+		}
+	}
+}
+
+// describeError gets the status, error code and message of the response to an error
+func describeError(err error, exposeInternal bool) (int, string, string) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message := fmt.Sprint(httpErr.Message)
+		if httpErr.Code >= http.StatusInternalServerError && !exposeInternal {
+			message = internalErrorMessage
+		}
+		return httpErr.Code, response.StatusErrorCode(httpErr.Code), message
+	}
+
+	var coded model.CodedError
+	if errors.As(err, &coded) {
+		if status, ok := domainErrorStatus(coded); ok {
+			return status, coded.ErrorCode(), capitalize(coded.Error())
+		}
+	}
+
+	message := internalErrorMessage
+	if exposeInternal {
+		message = err.Error()
+	}
+	return http.StatusInternalServerError, response.StatusErrorCode(http.StatusInternalServerError), message
+}
+
+// domainErrorStatus maps the kind of a domain error to an HTTP status
+func domainErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, model.ErrConflict):
+		return http.StatusConflict, true
+	case errors.Is(err, model.ErrValidation):
+		return http.StatusUnprocessableEntity, true
+	case errors.Is(err, model.ErrForbidden):
+		return http.StatusForbidden, true
+	case errors.Is(err, model.ErrUnauthorized):
+		return http.StatusUnauthorized, true
+	default:
+		return 0, false
+	}
+}
+
+// capitalize upper-cases the first letter of a domain error message for the response
+func capitalize(message string) string {
+	r, size := utf8.DecodeRuneInString(message)
+	if r == utf8.RuneError {
+		return message
+	}
+	return string(unicode.ToUpper(r)) + message[size:]
+}
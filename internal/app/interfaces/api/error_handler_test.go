@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		exposeInternal bool
+		wantStatus     int
+		wantCode       string
+		wantMessage    string
+	}{
+		{
+			name:        "not found",
+			err:         fmt.Errorf("failed to get todo: %w", model.ErrTodoNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "todo_not_found",
+			wantMessage: "Todo not found",
+		},
+		{
+			name:        "conflict",
+			err:         model.ErrEmailTaken,
+			wantStatus:  http.StatusConflict,
+			wantCode:    "email_taken",
+			wantMessage: "User with this email already exists",
+		},
+		{
+			name:        "validation",
+			err:         model.ErrInvalidWorkflow.Errorf("no initial status"),
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    "invalid_workflow",
+			wantMessage: "Invalid workflow: no initial status",
+		},
+		{
+			name:        "unauthorized",
+			err:         model.ErrSessionRevoked,
+			wantStatus:  http.StatusUnauthorized,
+			wantCode:    "session_revoked",
+			wantMessage: "Session revoked",
+		},
+		{
+			name:        "echo error",
+			err:         echo.NewHTTPError(http.StatusForbidden, "The admin role is required"),
+			wantStatus:  http.StatusForbidden,
+			wantCode:    "forbidden",
+			wantMessage: "The admin role is required",
+		},
+		{
+			name:        "internal error hidden",
+			err:         errors.New("pq: connection refused"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    "internal_server_error",
+			wantMessage: "Internal server error",
+		},
+		{
+			name:           "internal error exposed",
+			err:            errors.New("pq: connection refused"),
+			exposeInternal: true,
+			wantStatus:     http.StatusInternalServerError,
+			wantCode:       "internal_server_error",
+			wantMessage:    "pq: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+			handler := NewHTTPErrorHandler(log, tt.exposeInternal)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			handler(tt.err, c)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			var resp response.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.ErrorCode != tt.wantCode {
+				t.Fatalf("expected error code %q, got %q", tt.wantCode, resp.ErrorCode)
+			}
+			if resp.Message != tt.wantMessage {
+				t.Fatalf("expected message %q, got %q", tt.wantMessage, resp.Message)
+			}
+			if resp.Code != tt.wantStatus {
+				t.Fatalf("expected code %d, got %d", tt.wantStatus, resp.Code)
+			}
+		})
+	}
+}
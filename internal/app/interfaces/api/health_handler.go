@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/health"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// HealthHandler handles liveness and readiness probes
+type HealthHandler struct {
+	BaseHandler
+	checks *health.Registry
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(checks *health.Registry, logger *logger.Logger) *HealthHandler {
+	return &HealthHandler{
+		BaseHandler: NewBaseHandler(logger),
+		checks:      checks,
+	}
+}
+
+// Livez handles the liveness probe, which only fails if the process cannot serve requests at all
+func (h *HealthHandler) Livez(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return response.RespondWithGenericOK(c, "Service is alive", h.checks.Live())
+}
+
+// Readyz handles the readiness probe, reporting each check. A degraded service is still ready,
+// while a failed critical check or a shutdown responds with 503 Service Unavailable.
+func (h *HealthHandler) Readyz(c echo.Context) error {
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	report := h.checks.Ready(c.Request().Context())
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	switch report.Status {
+	case health.StatusDown:
+		log.Warn("Service is not ready", "checks", report.Checks)
+		return response.RespondWithGenericError(c, http.StatusServiceUnavailable, "Service is not ready", report)
+	case health.StatusDegraded:
+		return response.RespondWithGenericOK(c, "Service is degraded", report)
+	default:
+		return response.RespondWithGenericOK(c, "Service is healthy", report)
+	}
+}
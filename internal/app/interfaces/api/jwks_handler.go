@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// jwksCacheControl lets verifiers cache the key set for a while; keys are published
+// ahead of use during rotation, so a cached set never misses a signing key
+const jwksCacheControl = "public, max-age=300"
+
+// JWKSHandler handles requests for the keys tokens are signed with
+type JWKSHandler struct {
+	BaseHandler
+	getJWKSHandler *query.GetJWKSHandler
+}
+
+// NewJWKSHandler creates a new JWKSHandler
+func NewJWKSHandler(getJWKSHandler *query.GetJWKSHandler, logger *logger.Logger) *JWKSHandler {
+	return &JWKSHandler{
+		BaseHandler:    NewBaseHandler(logger),
+		getJWKSHandler: getJWKSHandler,
+	}
+}
+
+// GetJWKS handles publishing the public keys as a JSON Web Key Set.
+// The set is returned as is rather than in the response envelope, as verifiers expect RFC 7517.
+func (h *JWKSHandler) GetJWKS(c echo.Context) error {
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Handle the query
+	set, err := h.getJWKSHandler.Handle(c, query.GetJWKSQuery{})
+	if err != nil {
+		log.Error("Failed to get JWKS", "error", err)
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, jwksCacheControl)
+	return c.JSON(http.StatusOK, set)
+}
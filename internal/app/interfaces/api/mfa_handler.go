@@ -0,0 +1,176 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// MFAHandler handles two-factor authentication requests
+type MFAHandler struct {
+	BaseHandler
+	startMFAEnrollmentHandler   *command.StartMFAEnrollmentHandler
+	confirmMFAEnrollmentHandler *command.ConfirmMFAEnrollmentHandler
+	disableMFAHandler           *command.DisableMFAHandler
+	verifyMFAHandler            *command.VerifyMFAHandler
+	validator                   *validator.Validator
+}
+
+// NewMFAHandler creates a new MFAHandler
+func NewMFAHandler(
+	startMFAEnrollmentHandler *command.StartMFAEnrollmentHandler,
+	confirmMFAEnrollmentHandler *command.ConfirmMFAEnrollmentHandler,
+	disableMFAHandler *command.DisableMFAHandler,
+	verifyMFAHandler *command.VerifyMFAHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *MFAHandler {
+	return &MFAHandler{
+		BaseHandler:                 NewBaseHandler(logger),
+		startMFAEnrollmentHandler:   startMFAEnrollmentHandler,
+		confirmMFAEnrollmentHandler: confirmMFAEnrollmentHandler,
+		disableMFAHandler:           disableMFAHandler,
+		verifyMFAHandler:            verifyMFAHandler,
+		validator:                   validator,
+	}
+}
+
+// StartEnrollment handles generating a TOTP secret and provisioning URI for the current user
+func (h *MFAHandler) StartEnrollment(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.StartMFAEnrollmentCommand{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the command
+	enrollment, err := h.startMFAEnrollmentHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to start MFA enrollment", "error", err)
+		return err
+	}
+
+	// Return the secret, which the client shows as a QR code of the provisioning URI
+	return response.RespondWithOK(c, "Scan the provisioning URI and confirm with a code", enrollment)
+}
+
+// ConfirmEnrollment handles enabling 2FA with a code from the enrolled authenticator
+func (h *MFAHandler) ConfirmEnrollment(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.ConfirmMFAEnrollmentCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for MFA confirmation", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	result, err := h.confirmMFAEnrollmentHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to confirm MFA enrollment", "error", err)
+		return err
+	}
+
+	// Return the recovery codes, which are only shown once
+	return response.RespondWithOK(c, "Two-factor authentication enabled", result)
+}
+
+// Disable handles turning 2FA off for the current user
+func (h *MFAHandler) Disable(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.DisableMFACommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for disabling MFA", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	if err := h.disableMFAHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to disable MFA", "error", err)
+		return err
+	}
+
+	return response.RespondWithOK(c, "Two-factor authentication disabled", nil)
+}
+
+// Verify handles completing a login with an MFA challenge token and a TOTP or recovery code
+func (h *MFAHandler) Verify(c echo.Context) error {
+	var cmd command.VerifyMFACommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the client the session is recorded with
+	cmd.Client = h.GetClientInfo(c)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for MFA verification", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	result, err := h.verifyMFAHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to verify MFA login", "error", err)
+		var throttled *service.LoginThrottledError
+		if errors.As(err, &throttled) {
+			return h.RespondWithLoginThrottled(c, throttled)
+		}
+		return err
+	}
+
+	// Return the user with a JWT token
+	return response.RespondWithOK(c, "User logged in successfully", result)
+}
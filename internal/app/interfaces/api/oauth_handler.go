@@ -0,0 +1,135 @@
+package api
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// oauthStateCookie holds the state hash of the login a browser started, so it can only be completed there
+const oauthStateCookie = "oauth_state"
+
+// OAuthHandler handles login requests through external identity providers
+type OAuthHandler struct {
+	BaseHandler
+	authorizeOAuthHandler     *command.AuthorizeOAuthHandler
+	oauthCallbackHandler      *command.OAuthCallbackHandler
+	listOAuthProvidersHandler *query.ListOAuthProvidersHandler
+	validator                 *validator.Validator
+}
+
+// NewOAuthHandler creates a new OAuthHandler
+func NewOAuthHandler(
+	authorizeOAuthHandler *command.AuthorizeOAuthHandler,
+	oauthCallbackHandler *command.OAuthCallbackHandler,
+	listOAuthProvidersHandler *query.ListOAuthProvidersHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *OAuthHandler {
+	return &OAuthHandler{
+		BaseHandler:               NewBaseHandler(logger),
+		authorizeOAuthHandler:     authorizeOAuthHandler,
+		oauthCallbackHandler:      oauthCallbackHandler,
+		listOAuthProvidersHandler: listOAuthProvidersHandler,
+		validator:                 validator,
+	}
+}
+
+// ListProviders handles listing the identity providers users can log in with
+func (h *OAuthHandler) ListProviders(c echo.Context) error {
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Handle the query
+	providers, err := h.listOAuthProvidersHandler.Handle(c, query.ListOAuthProvidersQuery{})
+	if err != nil {
+		log.Error("Failed to list OAuth providers", "error", err)
+		return err
+	}
+
+	return response.RespondWithOK(c, "OAuth providers retrieved successfully", providers)
+}
+
+// Authorize handles starting a login with an identity provider
+func (h *OAuthHandler) Authorize(c echo.Context) error {
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.AuthorizeOAuthCommand{
+		Provider: c.Param("provider"),
+	}
+
+	// Handle the command
+	result, err := h.authorizeOAuthHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to start OAuth login", "error", err)
+		return err
+	}
+
+	// Bind the login to this browser; the cookie is sent back with the callback, which is under the same path
+	h.setStateCookie(c, result.StateHash, int(service.OAuthStateTTL.Seconds()))
+
+	// Return the URL the client sends the user to
+	return response.RespondWithOK(c, "Redirect the user to the authorization URL", result)
+}
+
+// Callback handles completing a login with the code and state an identity provider redirected back with
+func (h *OAuthHandler) Callback(c echo.Context) error {
+	// Parse request body
+	var cmd command.OAuthCallbackCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the provider, the state the browser kept and the client the session is recorded with
+	cmd.Provider = c.Param("provider")
+	cmd.Client = h.GetClientInfo(c)
+	if cookie, err := c.Cookie(oauthStateCookie); err == nil {
+		cmd.BoundState = cookie.Value
+	}
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for OAuth callback", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command. The state is single use, so the cookie is cleared either way.
+	result, err := h.oauthCallbackHandler.Handle(c, cmd)
+	h.setStateCookie(c, "", -1)
+	if err != nil {
+		log.Error("Failed to complete OAuth login", "error", err)
+		return err
+	}
+
+	if result.MFARequired {
+		return response.RespondWithOK(c, "Two-factor authentication required", result)
+	}
+
+	// Return the user with a JWT token
+	return response.RespondWithOK(c, "User logged in successfully", result)
+}
+
+// setStateCookie sets the state cookie for the login routes of the request's provider, or clears it with a negative maxAge
+func (h *OAuthHandler) setStateCookie(c echo.Context, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    value,
+		Path:     path.Dir(c.Request().URL.Path),
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,353 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/health"
+	"github.com/sh1ro/todo-api/pkg/openapi"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// access is who may call a route
+type access int
+
+const (
+	// accessInfrastructure routes are for probes, monitoring and tooling
+	accessInfrastructure access = iota
+	// accessPublic routes need no authentication, but are rate limited per client IP
+	accessPublic
+	// accessSession routes need a JWT
+	accessSession
+	// accessAdmin routes need the JWT of an admin
+	accessAdmin
+	// accessToken routes take a JWT or a personal access token with the route's scope
+	accessToken
+)
+
+// apiRoute documents a route registered by RegisterRoutes
+type apiRoute struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+	access  access
+	scope   model.TokenScope
+
+	// body is the command the request body is bound to, and query the query whose fields are query parameters
+	body   interface{}
+	query  interface{}
+	params []openapi.Parameter
+
+	// status is the status of success responses, whose envelope holds data, if it is not nil
+	status int
+	data   interface{}
+}
+
+// wellKnownRoute documents a route of the well-known group, outside the API base path
+type wellKnownRoute struct {
+	path    string
+	summary string
+	schema  *openapi.Schema
+}
+
+// Tags of the documented operations
+const (
+	tagAuth         = "Auth"
+	tagUsers        = "Users"
+	tagAdmin        = "Admin"
+	tagTodos        = "Todos"
+	tagTimeTracking = "Time Tracking"
+	tagSystem       = "System"
+)
+
+// dateRangeParams are the date range of time tracking reports
+var dateRangeParams = []openapi.Parameter{
+	{Name: "from", In: "query", Description: "First day of the range, a week ago by default", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+	{Name: "to", In: "query", Description: "Last day of the range, today by default", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+}
+
+// timesheetParams are the date range and format of a timesheet
+var timesheetParams = append(append([]openapi.Parameter{}, dateRangeParams...), openapi.Parameter{
+	Name: "format", In: "query", Description: "csv for a CSV download", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"json", "csv"}},
+})
+
+// apiRoutes are the routes registered by RegisterRoutes under the API base path
+var apiRoutes = []apiRoute{
+	// Auth routes
+	{method: http.MethodPost, path: "/auth/register", tag: tagAuth, summary: "Register a user", access: accessPublic, body: command.RegisterUserCommand{}, status: http.StatusCreated, data: model.User{}},
+	{method: http.MethodPost, path: "/auth/login", tag: tagAuth, summary: "Log in with email and password", access: accessPublic, body: command.LoginUserCommand{}, status: http.StatusOK, data: command.LoginResult{}},
+	{method: http.MethodPost, path: "/auth/mfa/verify", tag: tagAuth, summary: "Complete a login with an MFA code", access: accessPublic, body: command.VerifyMFACommand{}, status: http.StatusOK, data: command.LoginResult{}},
+	{method: http.MethodGet, path: "/auth/oauth/providers", tag: tagAuth, summary: "List the enabled OAuth providers", access: accessPublic, status: http.StatusOK, data: []string{}},
+	{method: http.MethodPost, path: "/auth/oauth/:provider/authorize", tag: tagAuth, summary: "Start an OAuth login", access: accessPublic, status: http.StatusOK, data: command.AuthorizationResult{}},
+	{method: http.MethodPost, path: "/auth/oauth/:provider/callback", tag: tagAuth, summary: "Complete an OAuth login", access: accessPublic, body: command.OAuthCallbackCommand{}, status: http.StatusOK, data: command.LoginResult{}},
+	{method: http.MethodPost, path: "/auth/verify-email", tag: tagAuth, summary: "Verify an email address", access: accessPublic, body: command.VerifyEmailCommand{}, status: http.StatusOK, data: model.User{}},
+	{method: http.MethodPost, path: "/auth/forgot-password", tag: tagAuth, summary: "Email a password reset link", access: accessPublic, body: command.ForgotPasswordCommand{}, status: http.StatusAccepted},
+	{method: http.MethodPost, path: "/auth/reset-password", tag: tagAuth, summary: "Reset a password", access: accessPublic, body: command.ResetPasswordCommand{}, status: http.StatusOK},
+
+	// User routes
+	{method: http.MethodGet, path: "/users/me", tag: tagUsers, summary: "Get the current user", access: accessSession, status: http.StatusOK, data: model.User{}},
+	{method: http.MethodPatch, path: "/users/me", tag: tagUsers, summary: "Update the profile", access: accessSession, body: command.UpdateProfileCommand{}, status: http.StatusOK, data: model.User{}},
+	{method: http.MethodDelete, path: "/users/me", tag: tagUsers, summary: "Schedule the deletion of the account", access: accessSession, body: command.DeleteAccountCommand{}, status: http.StatusAccepted, data: model.User{}},
+	{method: http.MethodPost, path: "/users/me/deletion/cancel", tag: tagUsers, summary: "Cancel the deletion of the account", access: accessSession, status: http.StatusOK, data: model.User{}},
+	{method: http.MethodPatch, path: "/users/me/preferences", tag: tagUsers, summary: "Update the preferences", access: accessSession, body: command.UpdatePreferencesCommand{}, status: http.StatusOK, data: model.User{}},
+	{method: http.MethodPost, path: "/users/me/password", tag: tagUsers, summary: "Change the password", access: accessSession, body: command.ChangePasswordCommand{}, status: http.StatusOK, data: command.LoginResult{}},
+	{method: http.MethodPost, path: "/users/me/mfa/enroll", tag: tagUsers, summary: "Start enrolling in two-factor authentication", access: accessSession, status: http.StatusOK, data: service.MFAEnrollment{}},
+	{method: http.MethodPost, path: "/users/me/mfa/confirm", tag: tagUsers, summary: "Confirm two-factor authentication", access: accessSession, body: command.ConfirmMFAEnrollmentCommand{}, status: http.StatusOK, data: command.RecoveryCodesResult{}},
+	{method: http.MethodPost, path: "/users/me/mfa/disable", tag: tagUsers, summary: "Disable two-factor authentication", access: accessSession, body: command.DisableMFACommand{}, status: http.StatusOK},
+	{method: http.MethodGet, path: "/users/me/tokens", tag: tagUsers, summary: "List personal access tokens", access: accessSession, status: http.StatusOK, data: []model.PersonalAccessToken{}},
+	{method: http.MethodPost, path: "/users/me/tokens", tag: tagUsers, summary: "Create a personal access token", access: accessSession, body: command.CreateAccessTokenCommand{}, status: http.StatusCreated, data: command.CreatedAccessToken{}},
+	{method: http.MethodGet, path: "/users/me/tokens/:id", tag: tagUsers, summary: "Get a personal access token", access: accessSession, status: http.StatusOK, data: model.PersonalAccessToken{}},
+	{method: http.MethodDelete, path: "/users/me/tokens/:id", tag: tagUsers, summary: "Delete a personal access token", access: accessSession, status: http.StatusNoContent},
+	{method: http.MethodGet, path: "/users/me/sessions", tag: tagUsers, summary: "List sessions", access: accessSession, status: http.StatusOK, data: []model.Session{}},
+	{method: http.MethodDelete, path: "/users/me/sessions/:id", tag: tagUsers, summary: "Revoke a session", access: accessSession, status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/users/me/verification-email", tag: tagUsers, summary: "Resend the verification email", access: accessSession, status: http.StatusAccepted},
+	{method: http.MethodGet, path: "/users/me/workflow", tag: tagUsers, summary: "Get the workflow", access: accessSession, status: http.StatusOK, data: model.Workflow{}},
+	{method: http.MethodPut, path: "/users/me/workflow", tag: tagUsers, summary: "Replace the workflow", access: accessSession, body: command.UpdateWorkflowCommand{}, status: http.StatusOK, data: model.Workflow{}},
+	{method: http.MethodDelete, path: "/users/me/workflow", tag: tagUsers, summary: "Reset the workflow to the default", access: accessSession, status: http.StatusOK, data: model.Workflow{}},
+
+	// Admin routes
+	{method: http.MethodPost, path: "/admin/login-unlocks", tag: tagAdmin, summary: "Lift a login lockout", access: accessAdmin, body: command.UnlockLoginCommand{}, status: http.StatusOK},
+
+	// Todo routes
+	{method: http.MethodPost, path: "/todos", tag: tagTodos, summary: "Create a todo", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.CreateTodoCommand{}, status: http.StatusCreated, data: model.Todo{}},
+	{method: http.MethodGet, path: "/todos", tag: tagTodos, summary: "List todos", access: accessToken, scope: model.TokenScopeTodosRead, query: query.ListTodosQuery{}, status: http.StatusOK, data: query.TodosResult{}},
+	{method: http.MethodGet, path: "/todos/overdue", tag: tagTodos, summary: "List overdue todos", access: accessToken, scope: model.TokenScopeTodosRead, status: http.StatusOK, data: []model.Todo{}},
+	{method: http.MethodGet, path: "/todos/board", tag: tagTodos, summary: "Get the todos as a board", access: accessToken, scope: model.TokenScopeTodosRead, status: http.StatusOK, data: query.BoardResult{}},
+	{method: http.MethodGet, path: "/todos/:id", tag: tagTodos, summary: "Get a todo", access: accessToken, scope: model.TokenScopeTodosRead, status: http.StatusOK, data: model.Todo{}},
+	{method: http.MethodPut, path: "/todos/:id", tag: tagTodos, summary: "Update a todo", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.UpdateTodoCommand{}, status: http.StatusOK, data: model.Todo{}},
+	{method: http.MethodDelete, path: "/todos/:id", tag: tagTodos, summary: "Delete a todo", access: accessToken, scope: model.TokenScopeTodosWrite, status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/todos/:id/move", tag: tagTodos, summary: "Move a todo on the board", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.MoveTodoCommand{}, status: http.StatusOK, data: model.Todo{}},
+	{method: http.MethodPost, path: "/todos/:id/snooze", tag: tagTodos, summary: "Snooze a todo", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.SnoozeTodoCommand{}, status: http.StatusOK, data: model.Todo{}},
+	{method: http.MethodDelete, path: "/todos/:id/snooze", tag: tagTodos, summary: "Wake a snoozed todo", access: accessToken, scope: model.TokenScopeTodosWrite, status: http.StatusOK, data: model.Todo{}},
+	{method: http.MethodGet, path: "/todos/:id/blockers", tag: tagTodos, summary: "List the blockers of a todo", access: accessToken, scope: model.TokenScopeTodosRead, status: http.StatusOK, data: []model.Todo{}},
+	{method: http.MethodPost, path: "/todos/:id/blockers", tag: tagTodos, summary: "Add a blocker to a todo", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.AddBlockerCommand{}, status: http.StatusCreated, data: model.TodoDependency{}},
+	{method: http.MethodDelete, path: "/todos/:id/blockers/:blockerId", tag: tagTodos, summary: "Remove a blocker from a todo", access: accessToken, scope: model.TokenScopeTodosWrite, status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/todos/:id/timer/start", tag: tagTimeTracking, summary: "Start a timer on a todo", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.StartTimerCommand{}, status: http.StatusCreated, data: model.TimeEntry{}},
+	{method: http.MethodGet, path: "/todos/:id/time-entries", tag: tagTimeTracking, summary: "Get the time spent on a todo", access: accessToken, scope: model.TokenScopeTodosRead, status: http.StatusOK, data: model.TodoTimeSummary{}},
+	{method: http.MethodPost, path: "/todos/:id/time-entries", tag: tagTimeTracking, summary: "Add a time entry to a todo", access: accessToken, scope: model.TokenScopeTodosWrite, body: command.AddTimeEntryCommand{}, status: http.StatusCreated, data: model.TimeEntry{}},
+
+	// Time tracking routes
+	{method: http.MethodGet, path: "/time-entries", tag: tagTimeTracking, summary: "List time entries in a date range", access: accessToken, scope: model.TokenScopeTodosRead, params: dateRangeParams, status: http.StatusOK, data: model.TimeReport{}},
+	{method: http.MethodGet, path: "/time-entries/running", tag: tagTimeTracking, summary: "Get the running timer", access: accessToken, scope: model.TokenScopeTodosRead, status: http.StatusOK, data: model.TimeEntry{}},
+	{method: http.MethodPost, path: "/time-entries/stop", tag: tagTimeTracking, summary: "Stop the running timer", access: accessToken, scope: model.TokenScopeTodosWrite, status: http.StatusOK, data: model.TimeEntry{}},
+	{method: http.MethodGet, path: "/time-entries/timesheet", tag: tagTimeTracking, summary: "Export time per day and todo", access: accessToken, scope: model.TokenScopeTodosRead, params: timesheetParams, status: http.StatusOK, data: []model.TimesheetRow{}},
+	{method: http.MethodDelete, path: "/time-entries/:id", tag: tagTimeTracking, summary: "Delete a time entry", access: accessToken, scope: model.TokenScopeTodosWrite, status: http.StatusNoContent},
+
+	// System routes
+	{method: http.MethodGet, path: "/livez", tag: tagSystem, summary: "Liveness probe", status: http.StatusOK, data: health.Report{}},
+	{method: http.MethodGet, path: "/readyz", tag: tagSystem, summary: "Readiness probe, with the status of each check", status: http.StatusOK, data: health.Report{}},
+	{method: http.MethodGet, path: "/health", tag: tagSystem, summary: "Readiness probe, kept for existing probes", status: http.StatusOK, data: health.Report{}},
+	{method: http.MethodGet, path: "/metrics", tag: tagSystem, summary: "Prometheus metrics", status: http.StatusOK},
+	{method: http.MethodGet, path: "/openapi.json", tag: tagSystem, summary: "This OpenAPI document", status: http.StatusOK},
+	{method: http.MethodGet, path: "/docs", tag: tagSystem, summary: "API documentation", status: http.StatusOK},
+}
+
+// wellKnownRoutes are the routes registered by RegisterRoutes in the well-known group
+var wellKnownRoutes = []wellKnownRoute{
+	{
+		path:    "/.well-known/jwks.json",
+		summary: "The public keys tokens are signed with, as a JSON Web Key Set (RFC 7517)",
+		schema: &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"keys": {Type: "array", Items: &openapi.Schema{Type: "object"}}},
+			Required:   []string{"keys"},
+		},
+	},
+}
+
+// NewOpenAPIDocument documents the routes registered by RegisterRoutes, with the API under basePath.
+// Request and response schemas are built from the commands, queries and models they bind.
+func NewOpenAPIDocument(basePath string) *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "Todo API",
+		Version:     path.Base(basePath),
+		Description: "Responses are wrapped in the standard response envelope. Errors can also be requested as RFC 7807 problem details with `Accept: application/problem+json`.",
+	})
+	doc.Servers = []openapi.Server{{URL: "/"}}
+	doc.Components.SecuritySchemes["bearerAuth"] = &openapi.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "A JWT from logging in, or a personal access token where the operation allows one",
+	}
+	for _, tag := range []string{tagAuth, tagUsers, tagAdmin, tagTodos, tagTimeTracking, tagSystem} {
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: tag})
+	}
+
+	schemas := openapi.NewSchemas(doc)
+	envelope := schemas.For(response.Response{})
+	errorResponse := errorResponse(schemas)
+
+	for _, route := range apiRoutes {
+		doc.AddOperation(route.method, openapi.RoutePath(basePath+route.path), route.operation(schemas, envelope, errorResponse))
+	}
+
+	for _, route := range wellKnownRoutes {
+		doc.AddOperation(http.MethodGet, route.path, &openapi.Operation{
+			OperationID: operationID(http.MethodGet, route.path),
+			Summary:     route.summary,
+			Tags:        []string{tagSystem},
+			Responses: map[string]*openapi.Response{
+				"200": {Description: "OK", Content: jsonContent(route.schema)},
+			},
+		})
+	}
+
+	return doc
+}
+
+// operation documents a route
+func (r apiRoute) operation(schemas *openapi.Schemas, envelope *openapi.Schema, errorResponse *openapi.Response) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: operationID(r.method, r.path),
+		Summary:     r.summary,
+		Tags:        []string{r.tag},
+		Responses:   map[string]*openapi.Response{},
+	}
+
+	for _, name := range openapi.RouteParams(r.path) {
+		param := openapi.Parameter{Name: name, In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}}
+		if name == "id" || strings.HasSuffix(name, "Id") {
+			param.Schema.Format = "uuid"
+		}
+		op.Parameters = append(op.Parameters, param)
+	}
+	if r.query != nil {
+		op.Parameters = append(op.Parameters, schemas.Parameters(r.query, "query")...)
+	}
+	op.Parameters = append(op.Parameters, r.params...)
+
+	if r.idempotent() {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name:        middleware.HeaderIdempotencyKey,
+			In:          "header",
+			Description: "Makes the request safe to retry: repeats with the same key get the first response replayed",
+			Schema:      &openapi.Schema{Type: "string", MaxLength: intPtr(model.MaxIdempotencyKeyLength)},
+		})
+	}
+
+	if r.body != nil {
+		op.RequestBody = &openapi.RequestBody{Required: true, Content: jsonContent(schemas.For(r.body))}
+		op.Responses["400"] = errorResponse
+		op.Responses["422"] = errorResponse
+	}
+
+	switch r.access {
+	case accessSession, accessAdmin:
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+		op.Description = "Needs a JWT; personal access tokens are not accepted."
+		op.Responses["401"] = errorResponse
+	case accessToken:
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+		op.Description = fmt.Sprintf("Personal access tokens need the `%s` scope.", r.scope)
+		op.Responses["401"] = errorResponse
+		op.Responses["403"] = errorResponse
+	}
+	if r.access == accessAdmin {
+		op.Description = "Needs the JWT of an admin."
+		op.Responses["403"] = errorResponse
+	}
+	if len(openapi.RouteParams(r.path)) > 0 {
+		op.Responses["404"] = errorResponse
+	}
+	if r.access != accessInfrastructure {
+		op.Responses["429"] = errorResponse
+		op.Responses["default"] = errorResponse
+	}
+
+	op.Responses[fmt.Sprint(r.status)] = r.successResponse(schemas, envelope)
+	return op
+}
+
+// successResponse documents the response of a route that succeeded
+func (r apiRoute) successResponse(schemas *openapi.Schemas, envelope *openapi.Schema) *openapi.Response {
+	resp := &openapi.Response{Description: http.StatusText(r.status)}
+
+	switch {
+	case r.status == http.StatusNoContent:
+		return resp
+	case r.path == "/metrics":
+		resp.Content = map[string]*openapi.MediaType{"text/plain": {Schema: &openapi.Schema{Type: "string"}}}
+		return resp
+	case r.path == "/docs":
+		resp.Content = map[string]*openapi.MediaType{"text/html": {Schema: &openapi.Schema{Type: "string"}}}
+		return resp
+	case r.path == "/openapi.json":
+		resp.Content = jsonContent(&openapi.Schema{Type: "object"})
+		return resp
+	}
+
+	schema := envelope
+	if r.data != nil {
+		schema = &openapi.Schema{AllOf: []*openapi.Schema{envelope, {
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"data": schemas.For(r.data)},
+		}}}
+	}
+	resp.Content = jsonContent(schema)
+
+	if r.path == "/time-entries/timesheet" {
+		resp.Content["text/csv"] = &openapi.MediaType{Schema: &openapi.Schema{Type: "string"}}
+	}
+	if r.access != accessInfrastructure {
+		resp.Headers = rateLimitHeaders()
+	}
+
+	return resp
+}
+
+// idempotent reports whether a route takes an Idempotency-Key header
+func (r apiRoute) idempotent() bool {
+	if r.method != http.MethodPost && r.method != http.MethodPatch {
+		return false
+	}
+	return strings.HasPrefix(r.path, "/todos") || strings.HasPrefix(r.path, "/time-entries")
+}
+
+// errorResponse documents error responses, in the envelope or as problem details
+func errorResponse(schemas *openapi.Schemas) *openapi.Response {
+	return &openapi.Response{
+		Description: "Error",
+		Content: map[string]*openapi.MediaType{
+			"application/json":          {Schema: schemas.For(response.ErrorResponse{})},
+			response.ProblemContentType: {Schema: schemas.For(response.Problem{})},
+		},
+	}
+}
+
+// rateLimitHeaders documents the headers of rate limited responses
+func rateLimitHeaders() map[string]*openapi.Header {
+	integer := &openapi.Schema{Type: "integer"}
+	return map[string]*openapi.Header{
+		middleware.HeaderRateLimitLimit:     {Description: "Requests the bucket holds", Schema: integer},
+		middleware.HeaderRateLimitRemaining: {Description: "Requests left in the bucket", Schema: integer},
+		middleware.HeaderRateLimitReset:     {Description: "Seconds until the bucket is full again", Schema: integer},
+		middleware.HeaderRateLimitPolicy:    {Description: "Limit and window in seconds, as `limit;w=window`", Schema: &openapi.Schema{Type: "string"}},
+	}
+}
+
+// jsonContent is a JSON body of the given schema
+func jsonContent(schema *openapi.Schema) map[string]*openapi.MediaType {
+	return map[string]*openapi.MediaType{"application/json": {Schema: schema}}
+}
+
+// operationID derives a unique operation ID from a method and route path, such as "post_todos_id_move"
+func operationID(method, routePath string) string {
+	id := strings.ToLower(method)
+	for _, part := range strings.FieldsFunc(routePath, func(r rune) bool { return r == '/' || r == '-' || r == '.' || r == ':' }) {
+		id += "_" + strings.ToLower(part)
+	}
+	return id
+}
+
+// intPtr returns a pointer to an int
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/openapi"
+)
+
+// docsPage renders the OpenAPI document next to it, without loading anything from other origins
+//
+//go:embed docs/index.html
+var docsPage []byte
+
+// OpenAPIHandler serves the OpenAPI document of the API and its docs UI
+type OpenAPIHandler struct {
+	BaseHandler
+	document *openapi.Document
+}
+
+// NewOpenAPIHandler creates a new OpenAPIHandler
+func NewOpenAPIHandler(document *openapi.Document, logger *logger.Logger) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		BaseHandler: NewBaseHandler(logger),
+		document:    document,
+	}
+}
+
+// GetSpec handles the request for the OpenAPI document
+func (h *OpenAPIHandler) GetSpec(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.document)
+}
+
+// GetDocs handles the request for the docs UI
+func (h *OpenAPIHandler) GetDocs(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMETextHTMLCharsetUTF8, docsPage)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/infrastructure/persistence"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/config"
+	"github.com/sh1ro/todo-api/pkg/health"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/openapi"
+	"github.com/sh1ro/todo-api/pkg/ratelimit"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// documentedMethods are the methods of routes that must be documented, leaving out those Echo
+// registers itself, such as the not found routes of groups with middleware
+var documentedMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// newTestRouter registers the routes of the API on a new Echo instance
+func newTestRouter(t *testing.T) *echo.Echo {
+	t.Helper()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	e := echo.New()
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	store := ratelimit.NewMemoryStore()
+	t.Cleanup(func() { store.Close() })
+
+	err = RegisterRoutes(e.Group("/api/v1"), e.Group("/.well-known"), &persistence.PostgresDB{}, store, health.NewRegistry(time.Second), log, cfg)
+	if err != nil {
+		t.Fatalf("failed to register routes: %v", err)
+	}
+	return e
+}
+
+func TestOpenAPIDocumentsAllRoutes(t *testing.T) {
+	e := newTestRouter(t)
+	doc := NewOpenAPIDocument("/api/v1")
+
+	registered := make(map[string]bool)
+	for _, route := range e.Routes() {
+		if !slices.Contains(documentedMethods, route.Method) {
+			continue
+		}
+
+		path := openapi.RoutePath(route.Path)
+		registered[route.Method+" "+path] = true
+		if doc.Operation(route.Method, path) == nil {
+			t.Errorf("route %s %s is not documented in openapi.go", route.Method, route.Path)
+		}
+	}
+
+	// Documented routes must exist too, so removed routes are not left in the document
+	for path, item := range doc.Paths {
+		for method := range item {
+			if !registered[strings.ToUpper(method)+" "+path] {
+				t.Errorf("documented route %s %s is not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+func TestOpenAPIDocumentSchemas(t *testing.T) {
+	doc := NewOpenAPIDocument("/api/v1")
+
+	create := doc.Operation(http.MethodPost, "/api/v1/todos")
+	if create == nil || create.RequestBody == nil {
+		t.Fatal("expected creating a todo to take a request body")
+	}
+	if ref := create.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/CreateTodoCommand" {
+		t.Fatalf("expected the request body to reference CreateTodoCommand, got %q", ref)
+	}
+
+	command := doc.Components.Schemas["CreateTodoCommand"]
+	if !slices.Contains(command.Required, "title") {
+		t.Errorf("expected title to be required, got %v", command.Required)
+	}
+	if title := command.Properties["title"]; title.MaxLength == nil || *title.MaxLength != 255 {
+		t.Errorf("expected the max length of title from its validate tag, got %+v", title)
+	}
+	if priority := command.Properties["priority"]; len(priority.Enum) == 0 {
+		t.Errorf("expected the values of priority from its validate tag, got %+v", priority)
+	}
+
+	list := doc.Operation(http.MethodGet, "/api/v1/todos")
+	if !slices.ContainsFunc(list.Parameters, func(p openapi.Parameter) bool { return p.Name == "status" && p.In == "query" }) {
+		t.Errorf("expected the fields of ListTodosQuery as query parameters, got %+v", list.Parameters)
+	}
+	if list.Security == nil || list.Responses["401"] == nil {
+		t.Error("expected listing todos to need authentication")
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	e := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to decode document: %v", err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("expected OpenAPI %s, got %s", openapi.Version, doc.OpenAPI)
+	}
+	if _, exists := doc.Paths["/api/v1/todos/{id}"]; !exists {
+		t.Error("expected paths under the base path of the API")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get(echo.HeaderContentType), "text/html") {
+		t.Errorf("expected the docs page, got %d %s", rec.Code, rec.Header().Get(echo.HeaderContentType))
+	}
+}
+
+func TestValidateRequestWithOpenAPIDocument(t *testing.T) {
+	e := echo.New()
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	validate := middleware.ValidateRequest(NewOpenAPIDocument("/api/v1"))
+	e.GET("/api/v1/todos", ok, validate)
+	e.POST("/api/v1/todos", ok, validate)
+	e.PUT("/api/v1/todos/:id", ok, validate)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantErrors []string
+	}{
+		{name: "valid list", method: http.MethodGet, target: "/api/v1/todos?status=pending&page=2&sort_by=due_date", wantStatus: http.StatusOK},
+		{name: "custom status", method: http.MethodGet, target: "/api/v1/todos?status=review", wantStatus: http.StatusOK},
+		{name: "status too long", method: http.MethodGet, target: "/api/v1/todos?status=" + strings.Repeat("a", 21), wantStatus: http.StatusBadRequest, wantErrors: []string{"status"}},
+		{name: "sort column not allowed", method: http.MethodGet, target: "/api/v1/todos?sort_by=password_hash", wantStatus: http.StatusBadRequest, wantErrors: []string{"sort_by"}},
+		{name: "valid create", method: http.MethodPost, target: "/api/v1/todos", body: `{"title":"Buy milk","priority":"low"}`, wantStatus: http.StatusOK},
+		{name: "unknown field", method: http.MethodPost, target: "/api/v1/todos", body: `{"title":"Buy milk","priority":"low","owner":"bob"}`, wantStatus: http.StatusBadRequest, wantErrors: []string{"owner"}},
+		{name: "all errors at once", method: http.MethodPut, target: "/api/v1/todos/abc", body: `{"priority":"urgent"}`, wantStatus: http.StatusBadRequest, wantErrors: []string{"id", "priority"}},
+		{name: "invalid JSON", method: http.MethodPost, target: "/api/v1/todos", body: `{"title":`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+
+			var resp response.ErrorResponse
+			if len(tt.wantErrors) > 0 {
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+			}
+			for _, field := range tt.wantErrors {
+				if resp.Errors[field] == "" {
+					t.Errorf("expected an error for %s, got %v", field, resp.Errors)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/password"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// ProfileHandler handles profile, preference and account deletion requests of the current user
+type ProfileHandler struct {
+	BaseHandler
+	updateProfileHandler         *command.UpdateProfileHandler
+	updatePreferencesHandler     *command.UpdatePreferencesHandler
+	changePasswordHandler        *command.ChangePasswordHandler
+	deleteAccountHandler         *command.DeleteAccountHandler
+	cancelAccountDeletionHandler *command.CancelAccountDeletionHandler
+	validator                    *validator.Validator
+}
+
+// NewProfileHandler creates a new ProfileHandler
+func NewProfileHandler(
+	updateProfileHandler *command.UpdateProfileHandler,
+	updatePreferencesHandler *command.UpdatePreferencesHandler,
+	changePasswordHandler *command.ChangePasswordHandler,
+	deleteAccountHandler *command.DeleteAccountHandler,
+	cancelAccountDeletionHandler *command.CancelAccountDeletionHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *ProfileHandler {
+	return &ProfileHandler{
+		BaseHandler:                  NewBaseHandler(logger),
+		updateProfileHandler:         updateProfileHandler,
+		updatePreferencesHandler:     updatePreferencesHandler,
+		changePasswordHandler:        changePasswordHandler,
+		deleteAccountHandler:         deleteAccountHandler,
+		cancelAccountDeletionHandler: cancelAccountDeletionHandler,
+		validator:                    validator,
+	}
+}
+
+// UpdateProfile handles updating the current user's name and email
+func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.UpdateProfileCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for update profile", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	user, err := h.updateProfileHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to update profile", "error", err)
+		return err
+	}
+
+	// Return the updated user
+	return response.RespondWithOK(c, "Profile updated successfully", user)
+}
+
+// UpdatePreferences handles updating the current user's timezone and locale
+func (h *ProfileHandler) UpdatePreferences(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.UpdatePreferencesCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for update preferences", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	user, err := h.updatePreferencesHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to update preferences", "error", err)
+		return err
+	}
+
+	// Return the updated user
+	return response.RespondWithOK(c, "Preferences updated successfully", user)
+}
+
+// ChangePassword handles changing the current user's password
+func (h *ProfileHandler) ChangePassword(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.ChangePasswordCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID and the client the new session is recorded with
+	cmd.UserID = userID.(uuid.UUID)
+	cmd.Client = h.GetClientInfo(c)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for change password", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	result, err := h.changePasswordHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to change password", "error", err)
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return response.RespondWithValidationError(c, "Validation failed", []validator.ValidationError{
+				{Field: "new_password", Message: "new_password " + policyErr.Message},
+			})
+		}
+		return err
+	}
+
+	// Return the new token, since every earlier token has been revoked
+	return response.RespondWithOK(c, "Password changed successfully", result)
+}
+
+// DeleteAccount handles scheduling the deletion of the current user's account
+func (h *ProfileHandler) DeleteAccount(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.DeleteAccountCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for delete account", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	user, err := h.deleteAccountHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to schedule account deletion", "error", err)
+		return err
+	}
+
+	// The account is only deleted once the grace period has passed
+	return response.RespondWithSuccess(c, http.StatusAccepted, "Account deletion scheduled", user)
+}
+
+// CancelAccountDeletion handles cancelling the scheduled deletion of the current user's account
+func (h *ProfileHandler) CancelAccountDeletion(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.CancelAccountDeletionCommand{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the command
+	user, err := h.cancelAccountDeletionHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to cancel account deletion", "error", err)
+		return err
+	}
+
+	return response.RespondWithOK(c, "Account deletion cancelled", user)
+}
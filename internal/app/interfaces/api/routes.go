@@ -1,108 +1,384 @@
 package api
 
 import (
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sh1ro/todo-api/internal/app/application/command"
 	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/internal/app/infrastructure/auth"
+	"github.com/sh1ro/todo-api/internal/app/infrastructure/mail"
+	"github.com/sh1ro/todo-api/internal/app/infrastructure/oauth"
 	"github.com/sh1ro/todo-api/internal/app/infrastructure/persistence"
 	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
 	"github.com/sh1ro/todo-api/pkg/config"
+	"github.com/sh1ro/todo-api/pkg/health"
 	"github.com/sh1ro/todo-api/pkg/logger"
 	"github.com/sh1ro/todo-api/pkg/metrics"
-	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/ratelimit"
 	"github.com/sh1ro/todo-api/pkg/validator"
 )
 
-// RegisterRoutes registers all routes for the API
-func RegisterRoutes(router *echo.Group, db *persistence.PostgresDB, log *logger.Logger, cfg *config.Config) {
+// Rate limit policies of auth endpoints, per client IP and stricter than the policy of their group
+var (
+	forgotPasswordPolicy = ratelimit.NewPolicy("forgot_password", 5, 15*time.Minute)
+	mfaVerifyPolicy      = ratelimit.NewPolicy("mfa_verify", 10, 5*time.Minute)
+	oauthPolicy          = ratelimit.NewPolicy("oauth", 20, 5*time.Minute)
+)
+
+// RegisterRoutes registers all routes for the API, and the well-known routes for other services
+func RegisterRoutes(router *echo.Group, wellKnown *echo.Group, db *persistence.PostgresDB, rateLimitStore ratelimit.Store, healthChecks *health.Registry, log *logger.Logger, cfg *config.Config) error {
 	// Create validator
 	validator := validator.NewValidator()
 
 	// Register metrics middleware
 	router.Use(metrics.MetricsMiddleware())
 
+	// Limit request bodies before any middleware reads them
+	router.Use(middleware.BodyLimit(cfg.MaxBodyBytes))
+
 	// Register metrics endpoint
 	metrics.RegisterMetricsEndpoint(router)
 
 	// Create repositories
 	userRepo := persistence.NewPostgresUserRepository(db)
 	todoRepo := persistence.NewPostgresTodoRepository(db)
+	workflowRepo := persistence.NewPostgresWorkflowRepository(db)
+	dependencyRepo := persistence.NewPostgresDependencyRepository(db)
+	timeEntryRepo := persistence.NewPostgresTimeEntryRepository(db)
+	userTokenRepo := persistence.NewPostgresUserTokenRepository(db)
+	recoveryCodeRepo := persistence.NewPostgresRecoveryCodeRepository(db)
+	mfaChallengeRepo := persistence.NewPostgresMFAChallengeRepository(db)
+	accessTokenRepo := persistence.NewPostgresPersonalAccessTokenRepository(db)
+	userIdentityRepo := persistence.NewPostgresUserIdentityRepository(db)
+	oauthStateRepo := persistence.NewPostgresOAuthStateRepository(db)
+	loginThrottleRepo := persistence.NewPostgresLoginThrottleRepository(db)
+	sessionRepo := persistence.NewPostgresSessionRepository(db)
+	idempotencyKeyRepo := persistence.NewPostgresIdempotencyKeyRepository(db)
+	txManager := persistence.NewPostgresTxManager(db, cfg.Database)
+
+	// Create mailer
+	mailer, err := mail.NewMailer(cfg.Mail, log)
+	if err != nil {
+		return err
+	}
+
+	// Load token signing keys
+	keys, err := auth.LoadKeySet(cfg.JWT)
+	if err != nil {
+		return err
+	}
+
+	// Load the password policy and hasher
+	passwordPolicy, err := auth.LoadPasswordPolicy(cfg.Password)
+	if err != nil {
+		return err
+	}
+	if err := validator.RegisterPasswordPolicy(passwordPolicy); err != nil {
+		return err
+	}
+	passwordHasher, err := auth.NewPasswordHasher(cfg.Password)
+	if err != nil {
+		return err
+	}
 
 	// Create services
-	authService := auth.NewAuthService(userRepo, log, cfg.JWT.Secret, cfg.JWT.Expiration)
-	todoService := service.NewTodoService(todoRepo, log)
+	passwordService := service.NewPasswordService(userRepo, passwordHasher, passwordPolicy, log)
+	tokenService := auth.NewTokenService(keys, auth.TokenOptions{
+		Issuer:   cfg.JWT.Issuer,
+		Audience: cfg.JWT.Audience,
+		Leeway:   cfg.JWT.Leeway,
+	}, log)
+	loginThrottleService := service.NewLoginThrottleService(loginThrottleRepo, service.LoginThrottleOptions{
+		EmailMaxFailures: cfg.Account.LoginMaxFailures,
+		IPMaxFailures:    cfg.Account.LoginIPMaxFailures,
+		LockoutDuration:  cfg.Account.LoginLockoutDuration,
+	}, log)
+	sessionService := service.NewSessionService(sessionRepo, cfg.JWT.SessionCacheTTL, log)
+	authService := auth.NewAuthService(userRepo, txManager, passwordService, loginThrottleService, sessionService, mfaChallengeRepo, log, tokenService, cfg.JWT.Expiration)
+	accountService := service.NewAccountService(userRepo, userTokenRepo, txManager, passwordService, sessionService, mailer, service.AccountOptions{
+		AppURL:               cfg.Account.AppURL,
+		EmailVerificationTTL: cfg.Account.EmailVerificationTTL,
+		PasswordResetTTL:     cfg.Account.PasswordResetTTL,
+	}, log)
+	accessTokenService := service.NewAccessTokenService(accessTokenRepo, userRepo, log)
+	mfaService := service.NewMFAService(userRepo, recoveryCodeRepo, txManager, passwordService, authService, cfg.Account.MFAIssuer, log)
+	oauthService := service.NewOAuthService(oauth.NewProviders(cfg.OAuth), oauthStateRepo, userIdentityRepo, userRepo, txManager, passwordService, authService, log)
+	profileService := service.NewProfileService(userRepo, passwordService, authService, sessionService, accountService, cfg.Account.DeletionGracePeriod, log)
+	workflowService := service.NewWorkflowService(workflowRepo, todoRepo, txManager, log)
+	timeTrackingService := service.NewTimeTrackingService(todoRepo, timeEntryRepo, txManager, log)
+	todoService := service.NewTodoService(todoRepo, userRepo, txManager, workflowService, timeTrackingService, log)
+	dependencyService := service.NewDependencyService(todoRepo, dependencyRepo, txManager, workflowService, log)
+	idempotencyService := service.NewIdempotencyService(idempotencyKeyRepo, cfg.Idempotency.KeyTTL, log)
 
 	// Create command handlers
-	registerUserHandler := command.NewRegisterUserHandler(authService, log)
+	registerUserHandler := command.NewRegisterUserHandler(authService, accountService, log)
 	loginUserHandler := command.NewLoginUserHandler(authService, log)
 	getUserHandler := command.NewGetUserHandler(authService, log)
+	verifyEmailHandler := command.NewVerifyEmailHandler(accountService, log)
+	resendVerificationHandler := command.NewResendVerificationHandler(accountService, log)
+	forgotPasswordHandler := command.NewForgotPasswordHandler(accountService, log)
+	resetPasswordHandler := command.NewResetPasswordHandler(accountService, log)
+	startMFAEnrollmentHandler := command.NewStartMFAEnrollmentHandler(mfaService, log)
+	confirmMFAEnrollmentHandler := command.NewConfirmMFAEnrollmentHandler(mfaService, log)
+	disableMFAHandler := command.NewDisableMFAHandler(mfaService, log)
+	verifyMFAHandler := command.NewVerifyMFAHandler(mfaService, log)
+	authorizeOAuthHandler := command.NewAuthorizeOAuthHandler(oauthService, log)
+	oauthCallbackHandler := command.NewOAuthCallbackHandler(oauthService, log)
+	createAccessTokenHandler := command.NewCreateAccessTokenHandler(accessTokenService, log)
+	deleteAccessTokenHandler := command.NewDeleteAccessTokenHandler(accessTokenService, log)
+	revokeSessionHandler := command.NewRevokeSessionHandler(sessionService, log)
+	updateProfileHandler := command.NewUpdateProfileHandler(profileService, log)
+	updatePreferencesHandler := command.NewUpdatePreferencesHandler(profileService, log)
+	changePasswordHandler := command.NewChangePasswordHandler(profileService, log)
+	deleteAccountHandler := command.NewDeleteAccountHandler(profileService, log)
+	cancelAccountDeletionHandler := command.NewCancelAccountDeletionHandler(profileService, log)
+	unlockLoginHandler := command.NewUnlockLoginHandler(loginThrottleService, log)
 	createTodoHandler := command.NewCreateTodoHandler(todoService, log)
 	updateTodoHandler := command.NewUpdateTodoHandler(todoService, log)
 	deleteTodoHandler := command.NewDeleteTodoHandler(todoService, log)
+	moveTodoHandler := command.NewMoveTodoHandler(todoService, log)
+	snoozeTodoHandler := command.NewSnoozeTodoHandler(todoService, log)
+	unsnoozeTodoHandler := command.NewUnsnoozeTodoHandler(todoService, log)
+	updateWorkflowHandler := command.NewUpdateWorkflowHandler(workflowService, log)
+	resetWorkflowHandler := command.NewResetWorkflowHandler(workflowService, log)
+	addBlockerHandler := command.NewAddBlockerHandler(dependencyService, log)
+	removeBlockerHandler := command.NewRemoveBlockerHandler(dependencyService, log)
+	startTimerHandler := command.NewStartTimerHandler(timeTrackingService, log)
+	stopTimerHandler := command.NewStopTimerHandler(timeTrackingService, log)
+	addTimeEntryHandler := command.NewAddTimeEntryHandler(timeTrackingService, log)
+	deleteTimeEntryHandler := command.NewDeleteTimeEntryHandler(timeTrackingService, log)
 
 	// Create query handlers
 	getTodoHandler := query.NewGetTodoHandler(todoService, log)
 	listTodosHandler := query.NewListTodosHandler(todoService, log)
 	getOverdueTodosHandler := query.NewGetOverdueTodosHandler(todoService, log)
+	getBoardHandler := query.NewGetBoardHandler(todoService, log)
+	getWorkflowHandler := query.NewGetWorkflowHandler(workflowService, log)
+	listBlockersHandler := query.NewListBlockersHandler(dependencyService, log)
+	getTodoTimeHandler := query.NewGetTodoTimeHandler(timeTrackingService, log)
+	getRunningTimerHandler := query.NewGetRunningTimerHandler(timeTrackingService, log)
+	listTimeEntriesHandler := query.NewListTimeEntriesHandler(timeTrackingService, log)
+	getTimesheetHandler := query.NewGetTimesheetHandler(timeTrackingService, log)
+	listAccessTokensHandler := query.NewListAccessTokensHandler(accessTokenService, log)
+	getAccessTokenHandler := query.NewGetAccessTokenHandler(accessTokenService, log)
+	listSessionsHandler := query.NewListSessionsHandler(sessionService, log)
+	listOAuthProvidersHandler := query.NewListOAuthProvidersHandler(oauthService, log)
+	getJWKSHandler := query.NewGetJWKSHandler(tokenService, log)
 
 	// Create API handlers
 	authHandler := NewAuthHandler(registerUserHandler, loginUserHandler, getUserHandler, validator, log)
+	accountHandler := NewAccountHandler(
+		verifyEmailHandler,
+		resendVerificationHandler,
+		forgotPasswordHandler,
+		resetPasswordHandler,
+		validator,
+		log,
+	)
+	mfaHandler := NewMFAHandler(
+		startMFAEnrollmentHandler,
+		confirmMFAEnrollmentHandler,
+		disableMFAHandler,
+		verifyMFAHandler,
+		validator,
+		log,
+	)
+	jwksHandler := NewJWKSHandler(getJWKSHandler, log)
+	healthHandler := NewHealthHandler(healthChecks, log)
+	adminHandler := NewAdminHandler(unlockLoginHandler, validator, log)
+	oauthHandler := NewOAuthHandler(
+		authorizeOAuthHandler,
+		oauthCallbackHandler,
+		listOAuthProvidersHandler,
+		validator,
+		log,
+	)
+	accessTokenHandler := NewAccessTokenHandler(
+		createAccessTokenHandler,
+		deleteAccessTokenHandler,
+		listAccessTokensHandler,
+		getAccessTokenHandler,
+		validator,
+		log,
+	)
+	sessionHandler := NewSessionHandler(revokeSessionHandler, listSessionsHandler, log)
+	profileHandler := NewProfileHandler(
+		updateProfileHandler,
+		updatePreferencesHandler,
+		changePasswordHandler,
+		deleteAccountHandler,
+		cancelAccountDeletionHandler,
+		validator,
+		log,
+	)
 	todoHandler := NewTodoHandler(
 		createTodoHandler,
 		updateTodoHandler,
 		deleteTodoHandler,
+		moveTodoHandler,
+		snoozeTodoHandler,
+		unsnoozeTodoHandler,
 		getTodoHandler,
 		listTodosHandler,
 		getOverdueTodosHandler,
+		getBoardHandler,
 		validator,
 		log,
 	)
 
+	workflowHandler := NewWorkflowHandler(
+		getWorkflowHandler,
+		updateWorkflowHandler,
+		resetWorkflowHandler,
+		validator,
+		log,
+	)
+
+	dependencyHandler := NewDependencyHandler(
+		addBlockerHandler,
+		removeBlockerHandler,
+		listBlockersHandler,
+		validator,
+		log,
+	)
+
+	timeEntryHandler := NewTimeEntryHandler(
+		startTimerHandler,
+		stopTimerHandler,
+		addTimeEntryHandler,
+		deleteTimeEntryHandler,
+		getTodoTimeHandler,
+		getRunningTimerHandler,
+		listTimeEntriesHandler,
+		getTimesheetHandler,
+		validator,
+		log,
+	)
+
+	// Register health check routes; /health is kept for existing probes and reports readiness
+	livez := router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+	router.GET("/health", healthHandler.Readyz)
+
+	// Register API documentation routes. Every route must be documented in openapi.go, under the
+	// base path of the router, which Echo only exposes on its routes.
+	spec := NewOpenAPIDocument(strings.TrimSuffix(livez.Path, "/livez"))
+	openAPIHandler := NewOpenAPIHandler(spec, log)
+	router.GET("/openapi.json", openAPIHandler.GetSpec)
+	router.GET("/docs", openAPIHandler.GetDocs)
+
 	// Create middleware
-	authMiddleware := middleware.NewAuthMiddleware(authService, log)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, authService, accessTokenService, log)
+	readTodos := func(route *echo.Route) { authMiddleware.RequireScope(route, model.TokenScopeTodosRead) }
+	writeTodos := func(route *echo.Route) { authMiddleware.RequireScope(route, model.TokenScopeTodosWrite) }
+	rateLimit := newRateLimiter(rateLimitStore, cfg.RateLimit, log)
+	authLimit := rateLimit(ratelimit.NewPolicy("auth", cfg.RateLimit.AuthRequests, cfg.RateLimit.AuthWindow))
+	apiLimit := rateLimit(ratelimit.NewPolicy("api", cfg.RateLimit.APIRequests, cfg.RateLimit.APIWindow))
+	oauthLimit := rateLimit(oauthPolicy)
+	// Responses of user and auth routes hold secrets such as tokens, so they are not kept for replays
+	idempotency := middleware.Idempotency(idempotencyService)
+	// Requests are checked against the schemas of the document, after authentication and rate limiting
+	validateRequest := middleware.ValidateRequest(spec)
 
 	// Register auth routes
 	authRoutes := router.Group("/auth")
+	authRoutes.Use(authLimit, validateRequest)
 	{
 		authRoutes.POST("/register", authHandler.Register)
 		authRoutes.POST("/login", authHandler.Login)
+		authRoutes.POST("/mfa/verify", mfaHandler.Verify, rateLimit(mfaVerifyPolicy))
+		authRoutes.GET("/oauth/providers", oauthHandler.ListProviders)
+		authRoutes.POST("/oauth/:provider/authorize", oauthHandler.Authorize, oauthLimit)
+		authRoutes.POST("/oauth/:provider/callback", oauthHandler.Callback, oauthLimit)
+		authRoutes.POST("/verify-email", accountHandler.VerifyEmail)
+		authRoutes.POST("/forgot-password", accountHandler.ForgotPassword, rateLimit(forgotPasswordPolicy))
+		authRoutes.POST("/reset-password", accountHandler.ResetPassword)
 	}
 
+	// Register user routes (protected by auth middleware, not reachable with personal access tokens)
 	userRoutes := router.Group("/users")
-	userRoutes.Use(authMiddleware.Authenticate())
+	userRoutes.Use(authMiddleware.Authenticate(), authMiddleware.RequireSession(), apiLimit, validateRequest)
 	{
 		userRoutes.GET("/me", authHandler.Me)
+		userRoutes.PATCH("/me", profileHandler.UpdateProfile)
+		userRoutes.DELETE("/me", profileHandler.DeleteAccount)
+		userRoutes.POST("/me/deletion/cancel", profileHandler.CancelAccountDeletion)
+		userRoutes.PATCH("/me/preferences", profileHandler.UpdatePreferences)
+		userRoutes.POST("/me/password", profileHandler.ChangePassword)
+		userRoutes.POST("/me/mfa/enroll", mfaHandler.StartEnrollment)
+		userRoutes.POST("/me/mfa/confirm", mfaHandler.ConfirmEnrollment)
+		userRoutes.POST("/me/mfa/disable", mfaHandler.Disable)
+		userRoutes.GET("/me/tokens", accessTokenHandler.ListTokens)
+		userRoutes.POST("/me/tokens", accessTokenHandler.CreateToken)
+		userRoutes.GET("/me/tokens/:id", accessTokenHandler.GetToken)
+		userRoutes.DELETE("/me/tokens/:id", accessTokenHandler.DeleteToken)
+		userRoutes.GET("/me/sessions", sessionHandler.ListSessions)
+		userRoutes.DELETE("/me/sessions/:id", sessionHandler.RevokeSession)
+		userRoutes.POST("/me/verification-email", accountHandler.ResendVerification)
+		userRoutes.GET("/me/workflow", workflowHandler.GetWorkflow)
+		userRoutes.PUT("/me/workflow", workflowHandler.UpdateWorkflow)
+		userRoutes.DELETE("/me/workflow", workflowHandler.ResetWorkflow)
+	}
+
+	// Register admin routes (protected by auth middleware, only for admins using a JWT)
+	adminRoutes := router.Group("/admin")
+	adminRoutes.Use(authMiddleware.Authenticate(), authMiddleware.RequireSession(), authMiddleware.RequireRole(model.RoleAdmin), apiLimit, validateRequest)
+	{
+		adminRoutes.POST("/login-unlocks", adminHandler.UnlockLogin)
 	}
 
-	// Register todo routes (protected by auth middleware)
+	// Register todo routes (protected by auth middleware, reachable with scoped personal access tokens)
 	todoRoutes := router.Group("/todos")
-	todoRoutes.Use(authMiddleware.Authenticate())
+	todoRoutes.Use(authMiddleware.Authenticate(), apiLimit, validateRequest, idempotency)
 	{
-		todoRoutes.POST("", todoHandler.CreateTodo)
-		todoRoutes.GET("", todoHandler.ListTodos)
-		todoRoutes.GET("/overdue", todoHandler.GetOverdueTodos)
-		todoRoutes.GET("/:id", todoHandler.GetTodo)
-		todoRoutes.PUT("/:id", todoHandler.UpdateTodo)
-		todoRoutes.DELETE("/:id", todoHandler.DeleteTodo)
+		writeTodos(todoRoutes.POST("", todoHandler.CreateTodo))
+		readTodos(todoRoutes.GET("", todoHandler.ListTodos))
+		readTodos(todoRoutes.GET("/overdue", todoHandler.GetOverdueTodos))
+		readTodos(todoRoutes.GET("/board", todoHandler.GetBoard))
+		readTodos(todoRoutes.GET("/:id", todoHandler.GetTodo))
+		writeTodos(todoRoutes.PUT("/:id", todoHandler.UpdateTodo))
+		writeTodos(todoRoutes.DELETE("/:id", todoHandler.DeleteTodo))
+		writeTodos(todoRoutes.POST("/:id/move", todoHandler.MoveTodo))
+		writeTodos(todoRoutes.POST("/:id/snooze", todoHandler.SnoozeTodo))
+		writeTodos(todoRoutes.DELETE("/:id/snooze", todoHandler.UnsnoozeTodo))
+		readTodos(todoRoutes.GET("/:id/blockers", dependencyHandler.ListBlockers))
+		writeTodos(todoRoutes.POST("/:id/blockers", dependencyHandler.AddBlocker))
+		writeTodos(todoRoutes.DELETE("/:id/blockers/:blockerId", dependencyHandler.RemoveBlocker))
+		writeTodos(todoRoutes.POST("/:id/timer/start", timeEntryHandler.StartTimer))
+		readTodos(todoRoutes.GET("/:id/time-entries", timeEntryHandler.GetTodoTime))
+		writeTodos(todoRoutes.POST("/:id/time-entries", timeEntryHandler.AddTimeEntry))
 	}
 
-	// Register health check route
-	router.GET("/health", func(c echo.Context) error {
-		// Create a strongly typed health response
-		type HealthResponse struct {
-			Status string    `json:"status"`
-			Time   time.Time `json:"time"`
-		}
+	// Register time tracking routes (protected by auth middleware, reachable with scoped personal access tokens)
+	timeEntryRoutes := router.Group("/time-entries")
+	timeEntryRoutes.Use(authMiddleware.Authenticate(), apiLimit, validateRequest, idempotency)
+	{
+		readTodos(timeEntryRoutes.GET("", timeEntryHandler.ListTimeEntries))
+		readTodos(timeEntryRoutes.GET("/running", timeEntryHandler.GetRunningTimer))
+		writeTodos(timeEntryRoutes.POST("/stop", timeEntryHandler.StopTimer))
+		readTodos(timeEntryRoutes.GET("/timesheet", timeEntryHandler.GetTimesheet))
+		writeTodos(timeEntryRoutes.DELETE("/:id", timeEntryHandler.DeleteTimeEntry))
+	}
 
-		healthData := HealthResponse{
-			Status: "ok",
-			Time:   time.Now().UTC(),
-		}
+	// Register well-known routes
+	wellKnown.GET("/jwks.json", jwksHandler.GetJWKS)
+
+	return nil
+}
 
-		return response.RespondWithGenericOK(c, "Service is healthy", healthData)
-	})
+// newRateLimiter returns a function creating the rate limit middleware of a policy,
+// which lets all requests through if rate limiting is disabled
+func newRateLimiter(store ratelimit.Store, cfg config.RateLimitConfig, log *logger.Logger) func(ratelimit.Policy) echo.MiddlewareFunc {
+	return func(policy ratelimit.Policy) echo.MiddlewareFunc {
+		if !cfg.Enabled {
+			return func(next echo.HandlerFunc) echo.HandlerFunc {
+				return next
+			}
+		}
+		return middleware.RateLimit(store, policy, log)
+	}
 }
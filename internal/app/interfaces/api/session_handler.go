@@ -0,0 +1,93 @@
+package api
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// SessionHandler handles requests for the devices a user is logged in on
+type SessionHandler struct {
+	BaseHandler
+	revokeSessionHandler *command.RevokeSessionHandler
+	listSessionsHandler  *query.ListSessionsHandler
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(
+	revokeSessionHandler *command.RevokeSessionHandler,
+	listSessionsHandler *query.ListSessionsHandler,
+	logger *logger.Logger,
+) *SessionHandler {
+	return &SessionHandler{
+		BaseHandler:          NewBaseHandler(logger),
+		revokeSessionHandler: revokeSessionHandler,
+		listSessionsHandler:  listSessionsHandler,
+	}
+}
+
+// ListSessions handles listing the current user's active sessions
+func (h *SessionHandler) ListSessions(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query, marking the session of this request
+	sessionID, _ := middleware.GetSessionID(c)
+	q := query.ListSessionsQuery{
+		UserID:    userID.(uuid.UUID),
+		SessionID: sessionID,
+	}
+
+	// Handle the query
+	sessions, err := h.listSessionsHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to list sessions", "error", err)
+		return err
+	}
+
+	// Return the sessions
+	return response.RespondWithOK(c, "Sessions retrieved successfully", sessions)
+}
+
+// RevokeSession handles signing one of the current user's sessions out
+func (h *SessionHandler) RevokeSession(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse session ID
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid session ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.RevokeSessionCommand{
+		UserID:    userID.(uuid.UUID),
+		SessionID: sessionID,
+	}
+
+	// Handle the command
+	if err := h.revokeSessionHandler.Handle(c, cmd); err != nil {
+		log.Error("Failed to revoke session", "error", err)
+		return err
+	}
+
+	// Return success with no content
+	return response.RespondWithNoContent(c)
+}
@@ -0,0 +1,422 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// defaultReportDays is the number of days a time report covers when no range is given
+const defaultReportDays = 7
+
+// TimeEntryHandler handles time tracking requests
+type TimeEntryHandler struct {
+	BaseHandler
+	startTimerHandler      *command.StartTimerHandler
+	stopTimerHandler       *command.StopTimerHandler
+	addTimeEntryHandler    *command.AddTimeEntryHandler
+	deleteTimeEntryHandler *command.DeleteTimeEntryHandler
+	getTodoTimeHandler     *query.GetTodoTimeHandler
+	getRunningTimerHandler *query.GetRunningTimerHandler
+	listTimeEntriesHandler *query.ListTimeEntriesHandler
+	getTimesheetHandler    *query.GetTimesheetHandler
+	validator              *validator.Validator
+}
+
+// NewTimeEntryHandler creates a new TimeEntryHandler
+func NewTimeEntryHandler(
+	startTimerHandler *command.StartTimerHandler,
+	stopTimerHandler *command.StopTimerHandler,
+	addTimeEntryHandler *command.AddTimeEntryHandler,
+	deleteTimeEntryHandler *command.DeleteTimeEntryHandler,
+	getTodoTimeHandler *query.GetTodoTimeHandler,
+	getRunningTimerHandler *query.GetRunningTimerHandler,
+	listTimeEntriesHandler *query.ListTimeEntriesHandler,
+	getTimesheetHandler *query.GetTimesheetHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		BaseHandler:            NewBaseHandler(logger),
+		startTimerHandler:      startTimerHandler,
+		stopTimerHandler:       stopTimerHandler,
+		addTimeEntryHandler:    addTimeEntryHandler,
+		deleteTimeEntryHandler: deleteTimeEntryHandler,
+		getTodoTimeHandler:     getTodoTimeHandler,
+		getRunningTimerHandler: getRunningTimerHandler,
+		listTimeEntriesHandler: listTimeEntriesHandler,
+		getTimesheetHandler:    getTimesheetHandler,
+		validator:              validator,
+	}
+}
+
+// StartTimer handles starting a timer on a todo
+func (h *TimeEntryHandler) StartTimer(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Parse request body, which is optional
+	var cmd command.StartTimerCommand
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(&cmd); err != nil {
+			return response.RespondWithBadRequest(c, "Invalid JSON format")
+		}
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the todo ID and user ID
+	cmd.TodoID = todoID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for start timer", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	entry, err := h.startTimerHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to start timer", "error", err)
+		return err
+	}
+
+	// Return the running time entry
+	return response.RespondWithCreated(c, "Timer started successfully", entry)
+}
+
+// StopTimer handles stopping the user's running timer
+func (h *TimeEntryHandler) StopTimer(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.StopTimerCommand{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the command
+	entry, err := h.stopTimerHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to stop timer", "error", err)
+		return err
+	}
+
+	// Return the stopped time entry
+	return response.RespondWithOK(c, "Timer stopped successfully", entry)
+}
+
+// GetRunningTimer handles getting the user's running timer
+func (h *TimeEntryHandler) GetRunningTimer(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.GetRunningTimerQuery{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the query
+	entry, err := h.getRunningTimerHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to get running timer", "error", err)
+		return err
+	}
+
+	// Return the running time entry, which is null when no timer is running
+	return response.RespondWithOK(c, "Running timer retrieved successfully", entry)
+}
+
+// AddTimeEntry handles recording time spent on a todo without a timer
+func (h *TimeEntryHandler) AddTimeEntry(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Parse request body
+	var cmd command.AddTimeEntryCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the todo ID and user ID
+	cmd.TodoID = todoID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for add time entry", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	entry, err := h.addTimeEntryHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to add time entry", "error", err)
+		return err
+	}
+
+	// Return the time entry
+	return response.RespondWithCreated(c, "Time entry added successfully", entry)
+}
+
+// GetTodoTime handles getting the time tracked against a todo
+func (h *TimeEntryHandler) GetTodoTime(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.GetTodoTimeQuery{
+		UserID: userID.(uuid.UUID),
+		TodoID: todoID,
+	}
+
+	// Handle the query
+	summary, err := h.getTodoTimeHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to get todo time", "error", err)
+		return err
+	}
+
+	// Return the time summary
+	return response.RespondWithOK(c, "Todo time retrieved successfully", summary)
+}
+
+// ListTimeEntries handles listing the user's time entries within a date range
+func (h *TimeEntryHandler) ListTimeEntries(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse date range
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return response.RespondWithBadRequest(c, err.Error())
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.ListTimeEntriesQuery{
+		UserID: userID.(uuid.UUID),
+		From:   from,
+		To:     to,
+	}
+
+	// Handle the query
+	report, err := h.listTimeEntriesHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to list time entries", "error", err)
+		return err
+	}
+
+	// Return the time report
+	return response.RespondWithOK(c, "Time entries retrieved successfully", report)
+}
+
+// GetTimesheet handles exporting the user's timesheet as JSON or CSV
+func (h *TimeEntryHandler) GetTimesheet(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse date range
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return response.RespondWithBadRequest(c, err.Error())
+	}
+
+	// Parse export format
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return response.RespondWithBadRequest(c, "Invalid format, expected json or csv")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.GetTimesheetQuery{
+		UserID: userID.(uuid.UUID),
+		From:   from,
+		To:     to,
+	}
+
+	// Handle the query
+	rows, err := h.getTimesheetHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to get timesheet", "error", err)
+		return err
+	}
+
+	if format == "csv" {
+		return writeTimesheetCSV(c, from, to, rows)
+	}
+
+	// Return the timesheet
+	return response.RespondWithOK(c, "Timesheet retrieved successfully", rows)
+}
+
+// DeleteTimeEntry handles deleting a time entry
+func (h *TimeEntryHandler) DeleteTimeEntry(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse time entry ID
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid time entry ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.DeleteTimeEntryCommand{
+		UserID: userID.(uuid.UUID),
+		ID:     entryID,
+	}
+
+	// Handle the command
+	err = h.deleteTimeEntryHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to delete time entry", "error", err)
+		return err
+	}
+
+	// Return success with no content
+	return response.RespondWithNoContent(c)
+}
+
+// parseDateRange parses the from and to query parameters as inclusive YYYY-MM-DD dates
+// and returns the matching [from, to) range in UTC, defaulting to the last week
+func parseDateRange(c echo.Context) (time.Time, time.Time, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -(defaultReportDays - 1))
+	to := today
+
+	if fromStr := c.QueryParam("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid from date, expected YYYY-MM-DD")
+		}
+		from = parsed
+	}
+
+	if toStr := c.QueryParam("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("Invalid to date, expected YYYY-MM-DD")
+		}
+		to = parsed
+	}
+
+	// The to date is inclusive, so the range ends at the start of the next day
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// writeTimesheetCSV writes timesheet rows as a CSV attachment
+func writeTimesheetCSV(c echo.Context, from, to time.Time, rows []*model.TimesheetRow) error {
+	filename := fmt.Sprintf("timesheet_%s_%s.csv", from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02"))
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(c.Response())
+	if err := writer.Write([]string{"date", "todo_id", "todo_title", "seconds", "hours"}); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := []string{
+			row.Date,
+			row.TodoID.String(),
+			csvSafe(row.TodoTitle),
+			strconv.FormatInt(row.Seconds, 10),
+			strconv.FormatFloat(float64(row.Seconds)/3600, 'f', 2, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvSafe keeps spreadsheet applications from evaluating user-provided text as a formula
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/sh1ro/todo-api/internal/app/application/command"
 	"github.com/sh1ro/todo-api/internal/app/application/query"
-	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
 	"github.com/sh1ro/todo-api/pkg/logger"
 	"github.com/sh1ro/todo-api/pkg/response"
@@ -18,9 +17,13 @@ type TodoHandler struct {
 	createTodoHandler       *command.CreateTodoHandler
 	updateTodoHandler       *command.UpdateTodoHandler
 	deleteTodoHandler       *command.DeleteTodoHandler
+	moveTodoHandler         *command.MoveTodoHandler
+	snoozeTodoHandler       *command.SnoozeTodoHandler
+	unsnoozeTodoHandler     *command.UnsnoozeTodoHandler
 	getTodoHandler          *query.GetTodoHandler
 	listTodosHandler        *query.ListTodosHandler
 	getOverdueTodosHandler  *query.GetOverdueTodosHandler
+	getBoardHandler         *query.GetBoardHandler
 	validator               *validator.Validator
 }
 
@@ -29,9 +32,13 @@ func NewTodoHandler(
 	createTodoHandler *command.CreateTodoHandler,
 	updateTodoHandler *command.UpdateTodoHandler,
 	deleteTodoHandler *command.DeleteTodoHandler,
+	moveTodoHandler *command.MoveTodoHandler,
+	snoozeTodoHandler *command.SnoozeTodoHandler,
+	unsnoozeTodoHandler *command.UnsnoozeTodoHandler,
 	getTodoHandler *query.GetTodoHandler,
 	listTodosHandler *query.ListTodosHandler,
 	getOverdueTodosHandler *query.GetOverdueTodosHandler,
+	getBoardHandler *query.GetBoardHandler,
 	validator *validator.Validator,
 	logger *logger.Logger,
 ) *TodoHandler {
@@ -40,9 +47,13 @@ func NewTodoHandler(
 		createTodoHandler:      createTodoHandler,
 		updateTodoHandler:      updateTodoHandler,
 		deleteTodoHandler:      deleteTodoHandler,
+		moveTodoHandler:        moveTodoHandler,
+		snoozeTodoHandler:      snoozeTodoHandler,
+		unsnoozeTodoHandler:    unsnoozeTodoHandler,
 		getTodoHandler:         getTodoHandler,
 		listTodosHandler:       listTodosHandler,
 		getOverdueTodosHandler:  getOverdueTodosHandler,
+		getBoardHandler:        getBoardHandler,
 		validator:              validator,
 	}
 }
@@ -74,7 +85,7 @@ func (h *TodoHandler) CreateTodo(c echo.Context) error {
 	todo, err := h.createTodoHandler.Handle(c, cmd)
 	if err != nil {
 		log.Error("Failed to create todo", "error", err)
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Use the generic response helper for type safety
@@ -114,10 +125,7 @@ func (h *TodoHandler) GetTodo(c echo.Context) error {
 	todo, err := h.getTodoHandler.Handle(c, q)
 	if err != nil {
 		log.Error("Failed to get todo", "error", err)
-		if err.Error() == "todo not found" {
-			return response.RespondWithNotFound(c, "Todo not found")
-		}
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Return the todo
@@ -149,28 +157,28 @@ func (h *TodoHandler) ListTodos(c echo.Context) error {
 		return response.RespondWithBadRequest(c, "Invalid query parameters")
 	}
 
-	// Parse status filter
-	if statusStr := c.QueryParam("status"); statusStr != "" {
-		status := model.TodoStatus(statusStr)
-		q.Status = &status
+	// Empty filters filter nothing
+	if q.Status != nil && *q.Status == "" {
+		q.Status = nil
 	}
-
-	// Parse priority filter
-	if priorityStr := c.QueryParam("priority"); priorityStr != "" {
-		priority := model.TodoPriority(priorityStr)
-		q.Priority = &priority
+	if q.Priority != nil && *q.Priority == "" {
+		q.Priority = nil
+	}
+	if q.Search != nil && *q.Search == "" {
+		q.Search = nil
 	}
 
-	// Parse search filter
-	if search := c.QueryParam("search"); search != "" {
-		q.Search = &search
+	// Validate the query, so only known statuses, priorities and sort columns reach the repository
+	if errors := h.validator.Validate(q); errors != nil {
+		log.Error("Validation failed for list todos", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
 	}
 
 	// Handle the query
 	result, err := h.listTodosHandler.Handle(c, q)
 	if err != nil {
 		log.Error("Failed to list todos", "error", err)
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Return the todos
@@ -197,7 +205,7 @@ func (h *TodoHandler) GetOverdueTodos(c echo.Context) error {
 	todos, err := h.getOverdueTodosHandler.Handle(c, q)
 	if err != nil {
 		log.Error("Failed to get overdue todos", "error", err)
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Return the todos
@@ -247,10 +255,7 @@ func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 	todo, err := h.updateTodoHandler.Handle(c, cmd)
 	if err != nil {
 		log.Error("Failed to update todo", "error", err)
-		if err.Error() == "todo not found" {
-			return response.RespondWithNotFound(c, "Todo not found")
-		}
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Return the updated todo
@@ -290,13 +295,164 @@ func (h *TodoHandler) DeleteTodo(c echo.Context) error {
 	err = h.deleteTodoHandler.Handle(c, cmd)
 	if err != nil {
 		log.Error("Failed to delete todo", "error", err)
-		if err.Error() == "todo not found" {
-			return response.RespondWithNotFound(c, "Todo not found")
-		}
-		return response.RespondWithInternalError(c, err.Error())
+		return err
 	}
 
 	// Return success with no content
 	return response.RespondWithNoContent(c)
 }
 
+// MoveTodo handles moving a todo to a status column between two neighbours
+func (h *TodoHandler) MoveTodo(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get todo ID from URL
+	todoIDStr := c.Param("id")
+	if todoIDStr == "" {
+		return response.RespondWithBadRequest(c, "Todo ID is required")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(todoIDStr)
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Parse request body
+	var cmd command.MoveTodoCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the todo ID and user ID
+	cmd.TodoID = todoID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for move todo", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	todo, err := h.moveTodoHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to move todo", "error", err)
+		return err
+	}
+
+	// Return the moved todo
+	return response.RespondWithOK(c, "Todo moved successfully", todo)
+}
+
+// SnoozeTodo handles hiding a todo from lists until a snooze preset ends
+func (h *TodoHandler) SnoozeTodo(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Parse request body
+	var cmd command.SnoozeTodoCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the todo ID and user ID
+	cmd.TodoID = todoID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for snooze todo", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	todo, err := h.snoozeTodoHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to snooze todo", "error", err)
+		return err
+	}
+
+	// Return the snoozed todo
+	return response.RespondWithOK(c, "Todo snoozed successfully", todo)
+}
+
+// UnsnoozeTodo handles making a snoozed todo active again
+func (h *TodoHandler) UnsnoozeTodo(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse todo ID
+	todoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return response.RespondWithBadRequest(c, "Invalid todo ID format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.UnsnoozeTodoCommand{
+		UserID: userID.(uuid.UUID),
+		TodoID: todoID,
+	}
+
+	// Handle the command
+	todo, err := h.unsnoozeTodoHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to unsnooze todo", "error", err)
+		return err
+	}
+
+	// Return the todo
+	return response.RespondWithOK(c, "Todo unsnoozed successfully", todo)
+}
+
+// GetBoard handles getting the todo board grouped by status
+func (h *TodoHandler) GetBoard(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.GetBoardQuery{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the query
+	board, err := h.getBoardHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to get todo board", "error", err)
+		return err
+	}
+
+	// Return the board
+	return response.RespondWithOK(c, "Todo board retrieved successfully", board)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/application/command"
+	"github.com/sh1ro/todo-api/internal/app/application/query"
+	"github.com/sh1ro/todo-api/internal/app/interfaces/middleware"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/response"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// WorkflowHandler handles status workflow requests
+type WorkflowHandler struct {
+	BaseHandler
+	getWorkflowHandler    *query.GetWorkflowHandler
+	updateWorkflowHandler *command.UpdateWorkflowHandler
+	resetWorkflowHandler  *command.ResetWorkflowHandler
+	validator             *validator.Validator
+}
+
+// NewWorkflowHandler creates a new WorkflowHandler
+func NewWorkflowHandler(
+	getWorkflowHandler *query.GetWorkflowHandler,
+	updateWorkflowHandler *command.UpdateWorkflowHandler,
+	resetWorkflowHandler *command.ResetWorkflowHandler,
+	validator *validator.Validator,
+	logger *logger.Logger,
+) *WorkflowHandler {
+	return &WorkflowHandler{
+		BaseHandler:           NewBaseHandler(logger),
+		getWorkflowHandler:    getWorkflowHandler,
+		updateWorkflowHandler: updateWorkflowHandler,
+		resetWorkflowHandler:  resetWorkflowHandler,
+		validator:             validator,
+	}
+}
+
+// GetWorkflow handles getting the current user's workflow
+func (h *WorkflowHandler) GetWorkflow(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create query
+	q := query.GetWorkflowQuery{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the query
+	workflow, err := h.getWorkflowHandler.Handle(c, q)
+	if err != nil {
+		log.Error("Failed to get workflow", "error", err)
+		return err
+	}
+
+	// Return the workflow
+	return response.RespondWithOK(c, "Workflow retrieved successfully", workflow)
+}
+
+// UpdateWorkflow handles replacing the current user's workflow
+func (h *WorkflowHandler) UpdateWorkflow(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Parse request body
+	var cmd command.UpdateWorkflowCommand
+	if err := c.Bind(&cmd); err != nil {
+		return response.RespondWithBadRequest(c, "Invalid JSON format")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Set the user ID
+	cmd.UserID = userID.(uuid.UUID)
+
+	// Validate the command
+	if errors := h.validator.Validate(cmd); errors != nil {
+		log.Error("Validation failed for update workflow", "errors", errors)
+		return response.RespondWithValidationError(c, "Validation failed", errors)
+	}
+
+	// Handle the command
+	workflow, err := h.updateWorkflowHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to update workflow", "error", err)
+		return err
+	}
+
+	// Return the updated workflow
+	return response.RespondWithOK(c, "Workflow updated successfully", workflow)
+}
+
+// ResetWorkflow handles restoring the default workflow for the current user
+func (h *WorkflowHandler) ResetWorkflow(c echo.Context) error {
+	// Get user ID from context
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		return response.RespondWithUnauthorized(c, "User ID not found in context")
+	}
+
+	// Get request-specific logger
+	log := h.GetLogger(c)
+
+	// Create command
+	cmd := command.ResetWorkflowCommand{
+		UserID: userID.(uuid.UUID),
+	}
+
+	// Handle the command
+	workflow, err := h.resetWorkflowHandler.Handle(c, cmd)
+	if err != nil {
+		log.Error("Failed to reset workflow", "error", err)
+		return err
+	}
+
+	// Return the default workflow
+	return response.RespondWithOK(c, "Workflow reset successfully", workflow)
+}
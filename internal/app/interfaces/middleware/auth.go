@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
 	"github.com/sh1ro/todo-api/internal/app/domain/service"
 	"github.com/sh1ro/todo-api/pkg/logger"
 )
@@ -20,6 +22,15 @@ const (
 	
 	// ClaimsKey is the context key for JWT claims
 	ClaimsKey contextKey = "claims"
+
+	// TokenScopesKey is the context key for the scopes of a personal access token
+	TokenScopesKey contextKey = "token_scopes"
+
+	// UserRolesKey is the context key for the roles of the user
+	UserRolesKey contextKey = "user_roles"
+
+	// SessionIDKey is the context key for the session a JWT was issued for
+	SessionIDKey contextKey = "session_id"
 )
 
 // GetUserID retrieves the user ID from the context
@@ -34,21 +45,49 @@ func GetClaims(c echo.Context) (interface{}, bool) {
 	return claims, claims != nil
 }
 
-// AuthMiddleware is a middleware that checks for a valid JWT token
+// GetTokenScopes retrieves the scopes of the personal access token a request was authenticated with.
+// It reports false for requests authenticated with a JWT, which are not limited by scopes.
+func GetTokenScopes(c echo.Context) ([]model.TokenScope, bool) {
+	scopes, ok := c.Get(string(TokenScopesKey)).([]model.TokenScope)
+	return scopes, ok
+}
+
+// GetUserRoles retrieves the roles of the authenticated user from the context
+func GetUserRoles(c echo.Context) []string {
+	roles, _ := c.Get(string(UserRolesKey)).([]string)
+	return roles
+}
+
+// GetSessionID retrieves the session of the JWT a request was authenticated with.
+// It reports false for requests authenticated with a personal access token.
+func GetSessionID(c echo.Context) (uuid.UUID, bool) {
+	sessionID, ok := c.Get(string(SessionIDKey)).(uuid.UUID)
+	return sessionID, ok
+}
+
+// AuthMiddleware is a middleware that checks for a valid JWT or personal access token
 type AuthMiddleware struct {
-	authService *service.AuthService
-	logger      *logger.Logger
+	verifier           service.TokenVerifier
+	authService        *service.AuthService
+	accessTokenService *service.AccessTokenService
+	routeScopes        map[string]model.TokenScope
+	logger             *logger.Logger
 }
 
 // NewAuthMiddleware creates a new AuthMiddleware
-func NewAuthMiddleware(authService *service.AuthService, logger *logger.Logger) *AuthMiddleware {
+func NewAuthMiddleware(verifier service.TokenVerifier, authService *service.AuthService, accessTokenService *service.AccessTokenService, logger *logger.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService: authService,
-		logger:      logger,
+		verifier:           verifier,
+		authService:        authService,
+		accessTokenService: accessTokenService,
+		routeScopes:        make(map[string]model.TokenScope),
+		logger:             logger,
 	}
 }
 
-// Authenticate is a middleware that checks for a valid JWT token
+// Authenticate is a middleware that checks for a valid JWT or personal access token.
+// Personal access tokens are rejected unless the route declared the scope they need with RequireScope,
+// so routes are closed to them by default.
 func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -69,18 +108,47 @@ func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 			// Get the token
 			tokenString := parts[1]
 
-			// Validate the token
-			claims, err := m.authService.ValidateToken(tokenString)
+			// Personal access tokens are looked up instead of validated as JWTs
+			if model.IsPersonalAccessToken(tokenString) {
+				user, token, err := m.accessTokenService.Authenticate(c.Request().Context(), tokenString)
+				if err != nil {
+					if errors.Is(err, model.ErrUnauthorized) || errors.Is(err, model.ErrUserNotFound) {
+						return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
+					}
+					log.Error("Failed to authenticate personal access token", "error", err)
+					return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+				}
+
+				scope, scoped := m.routeScopes[routeKey(c.Request().Method, c.Path())]
+				if !scoped {
+					return echo.NewHTTPError(http.StatusForbidden, "Personal access tokens cannot access this endpoint")
+				}
+				if !hasScope(token.Scopes, scope) {
+					return echo.NewHTTPError(http.StatusForbidden, "Token is missing the "+string(scope)+" scope")
+				}
+
+				c.Set(string(UserIDKey), user.ID)
+				c.Set(string(UserRolesKey), user.Roles())
+				c.Set(string(TokenScopesKey), token.Scopes)
+
+				return next(c)
+			}
+
+			// Verify the token
+			claims, err := m.verifier.Verify(tokenString)
 			if err != nil {
 				log.Error("Invalid token", "error", err)
 				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
 			}
 
 			// Get the user from the token
-			user, err := m.authService.GetUserFromToken(c.Request().Context(), tokenString)
+			user, err := m.authService.SessionUser(c.Request().Context(), claims)
 			if err != nil {
-				if errors.Is(err, errors.New("user not found")) {
+				switch {
+				case errors.Is(err, model.ErrUserNotFound):
 					return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+				case errors.Is(err, model.ErrUnauthorized):
+					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token")
 				}
 				log.Error("Failed to get user from token", "error", err)
 				return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
@@ -88,9 +156,63 @@ func (m *AuthMiddleware) Authenticate() echo.MiddlewareFunc {
 
 			// Set the user ID and claims in the context
 			c.Set(string(UserIDKey), user.ID)
+			c.Set(string(UserRolesKey), user.Roles())
 			c.Set(string(ClaimsKey), claims)
+			c.Set(string(SessionIDKey), claims.SessionID)
 
 			return next(c)
 		}
 	}
 }
+
+// RequireScope declares the scope personal access tokens need for a route, which Authenticate checks
+// before anything else handles the request. Requests authenticated with a JWT have full access.
+// Routes have to be declared before the server starts.
+func (m *AuthMiddleware) RequireScope(route *echo.Route, scope model.TokenScope) {
+	m.routeScopes[routeKey(route.Method, route.Path)] = scope
+}
+
+// RequireSession is a middleware that rejects personal access tokens, so routes outside the token scopes,
+// such as account management, can only be used with a JWT
+func (m *AuthMiddleware) RequireSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := GetTokenScopes(c); ok {
+				return echo.NewHTTPError(http.StatusForbidden, "Personal access tokens cannot access this endpoint")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireRole is a middleware that only lets users with the given role through.
+// Roles are read from the user rather than the token, so a revoked role takes effect immediately.
+func (m *AuthMiddleware) RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, r := range GetUserRoles(c) {
+				if r == role {
+					return next(c)
+				}
+			}
+
+			return echo.NewHTTPError(http.StatusForbidden, "The "+role+" role is required")
+		}
+	}
+}
+
+// routeKey identifies a route by its method and path pattern
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// hasScope checks if a scope is in a list of scopes
+func hasScope(scopes []model.TokenScope, scope model.TokenScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/repository"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeAccessTokenRepository finds a single personal access token by its hash
+type fakeAccessTokenRepository struct {
+	repository.PersonalAccessTokenRepository
+	token *model.PersonalAccessToken
+}
+
+func (r *fakeAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	if tokenHash != r.token.TokenHash {
+		return nil, model.ErrAccessTokenNotFound
+	}
+	return r.token, nil
+}
+
+func (r *fakeAccessTokenRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return nil
+}
+
+// fakeUserRepository finds a single user by ID
+type fakeUserRepository struct {
+	repository.UserRepository
+	user *model.User
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	if id != r.user.ID {
+		return nil, model.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+func TestAuthenticatePersonalAccessTokenScopes(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Email: "user@example.com"}
+	token, raw, err := model.NewPersonalAccessToken(user.ID, "cli", []model.TokenScope{model.TokenScopeTodosRead}, nil)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	accessTokenService := service.NewAccessTokenService(&fakeAccessTokenRepository{token: token}, &fakeUserRepository{user: user}, log)
+	auth := NewAuthMiddleware(nil, nil, accessTokenService, log)
+
+	e := echo.New()
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	group := e.Group("", auth.Authenticate())
+	auth.RequireScope(group.GET("/todos", ok), model.TokenScopeTodosRead)
+	auth.RequireScope(group.POST("/todos", ok), model.TokenScopeTodosWrite)
+	group.GET("/users/me", ok)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected int
+	}{
+		{name: "granted scope", method: http.MethodGet, path: "/todos", expected: http.StatusOK},
+		{name: "missing scope", method: http.MethodPost, path: "/todos", expected: http.StatusForbidden},
+		{name: "route without scope", method: http.MethodGet, path: "/users/me", expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+raw)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// BodyLimit returns a middleware that rejects request bodies larger than maxBytes with
+// 413 Request Entity Too Large. It must run before the middlewares that read the body,
+// such as ValidateRequest and Idempotency, which on auth routes run before authentication.
+func BodyLimit(maxBytes int64) echo.MiddlewareFunc {
+	return middleware.BodyLimit(strconv.FormatInt(maxBytes, 10) + "B")
+}
+
+// readBody reads the whole request body and puts it back for the handler.
+// Bodies cut off by BodyLimit keep their 413 error.
+func readBody(req *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return nil, err
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
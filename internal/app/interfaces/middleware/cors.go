@@ -6,12 +6,23 @@ import (
 	"github.com/sh1ro/todo-api/pkg/config"
 )
 
+// exposedHeaders are the response headers browser clients may read
+var exposedHeaders = []string{
+	HeaderRateLimitLimit,
+	HeaderRateLimitRemaining,
+	HeaderRateLimitReset,
+	HeaderRateLimitPolicy,
+	echo.HeaderRetryAfter,
+	HeaderIdempotentReplayed,
+}
+
 // CORS returns a middleware that adds CORS headers to the response
 func CORS(cfg config.CORSConfig) echo.MiddlewareFunc {
 	return middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     cfg.AllowedOrigins,
 		AllowMethods:     cfg.AllowedMethods,
 		AllowHeaders:     cfg.AllowedHeaders,
+		ExposeHeaders:    exposedHeaders,
 		AllowCredentials: true,
 		MaxAge:           cfg.MaxAge,
 	})
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header clients send a key in to make a request safe to retry
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed marks responses replayed for a repeat of a request
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// Idempotency returns a middleware that runs POST and PATCH requests sent with an Idempotency-Key
+// header at most once per key and user. Repeats get the response of the first request replayed,
+// a 409 while it is still running, or a 422 if they are not the same request. Server errors and
+// responses that did not get to run the request, such as 401, 403 and 429, are not kept, so the
+// request can be retried with the same key. It must run after authentication.
+func Idempotency(idempotencyService *service.IdempotencyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if key == "" || (req.Method != http.MethodPost && req.Method != http.MethodPatch) {
+				return next(c)
+			}
+
+			userID, exists := GetUserID(c)
+			if !exists {
+				return next(c)
+			}
+
+			body, err := readBody(req)
+			if err != nil {
+				return err
+			}
+
+			fingerprint := model.FingerprintRequest(req.Method, req.URL.RequestURI(), body)
+			record, replay, err := idempotencyService.Begin(req.Context(), userID.(uuid.UUID), key, fingerprint)
+			if err != nil {
+				return err
+			}
+
+			if replay {
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				return c.Blob(record.StatusCode, record.ContentType, record.ResponseBody)
+			}
+
+			// Capture the response, including error responses written by the error handler
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			c.Response().Writer = recorder.ResponseWriter
+
+			status := c.Response().Status
+			if !c.Response().Committed || isRetryableStatus(status) {
+				_ = idempotencyService.Release(req.Context(), record)
+				return nil
+			}
+
+			_ = idempotencyService.Complete(req.Context(), record, status, c.Response().Header().Get(echo.HeaderContentType), recorder.body.Bytes())
+			return nil
+		}
+	}
+}
+
+// isRetryableStatus checks if a response is one a retry of the request may not get, because
+// the request failed on the server or was refused before it ran
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	default:
+		return status >= http.StatusInternalServerError
+	}
+}
+
+// responseRecorder copies the body of a response as it is written
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/internal/app/domain/model"
+	"github.com/sh1ro/todo-api/internal/app/domain/service"
+	"github.com/sh1ro/todo-api/pkg/logger"
+)
+
+// fakeIdempotencyKeyRepository keeps idempotency keys in memory
+type fakeIdempotencyKeyRepository struct {
+	keys map[string]*model.IdempotencyKey
+}
+
+func (r *fakeIdempotencyKeyRepository) Reserve(ctx context.Context, key *model.IdempotencyKey, staleBefore time.Time) (bool, error) {
+	if _, exists := r.keys[key.Key]; exists {
+		return false, nil
+	}
+	r.keys[key.Key] = key
+	return true, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) Get(ctx context.Context, userID uuid.UUID, key string) (*model.IdempotencyKey, error) {
+	record, exists := r.keys[key]
+	if !exists {
+		return nil, model.ErrIdempotencyKeyNotFound
+	}
+	return record, nil
+}
+
+func (r *fakeIdempotencyKeyRepository) Complete(ctx context.Context, key *model.IdempotencyKey) error {
+	return nil
+}
+
+func (r *fakeIdempotencyKeyRepository) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	delete(r.keys, key)
+	return nil
+}
+
+func (r *fakeIdempotencyKeyRepository) DeleteExpiredByUserID(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	return nil
+}
+
+func TestIdempotencyKeepsOnlyRequestsThatRan(t *testing.T) {
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	idempotencyService := service.NewIdempotencyService(&fakeIdempotencyKeyRepository{keys: map[string]*model.IdempotencyKey{}}, time.Hour, log)
+
+	// The route refuses the first request, like a missing scope, and runs the others
+	runs := 0
+	statuses := []int{http.StatusForbidden, http.StatusCreated}
+	userID := uuid.New()
+	e := echo.New()
+	e.POST("/todos", func(c echo.Context) error {
+		status := statuses[runs]
+		runs++
+		return c.String(status, http.StatusText(status))
+	}, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(UserIDKey), userID)
+			return next(c)
+		}
+	}, Idempotency(idempotencyService))
+
+	expected := []struct {
+		status   int
+		replayed bool
+	}{
+		{status: http.StatusForbidden},
+		{status: http.StatusCreated},
+		{status: http.StatusCreated, replayed: true},
+	}
+	for i, want := range expected {
+		req := httptest.NewRequest(http.MethodPost, "/todos", nil)
+		req.Header.Set(HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		replayed := rec.Header().Get(HeaderIdempotentReplayed) == "true"
+		if rec.Code != want.status || replayed != want.replayed {
+			t.Errorf("request %d: expected status %d and replayed %t, got %d and %t", i+1, want.status, want.replayed, rec.Code, replayed)
+		}
+	}
+	if runs != 2 {
+		t.Errorf("expected the request to run twice, got %d", runs)
+	}
+}
+
+func TestIdempotencyRejectsLargeBodies(t *testing.T) {
+	log := logger.NewLogger("error", "json").WithOutput(io.Discard)
+	keyRepo := &fakeIdempotencyKeyRepository{keys: map[string]*model.IdempotencyKey{}}
+	idempotencyService := service.NewIdempotencyService(keyRepo, time.Hour, log)
+
+	userID := uuid.New()
+	e := echo.New()
+	e.Use(BodyLimit(16))
+	e.POST("/todos", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(UserIDKey), userID)
+			return next(c)
+		}
+	}, Idempotency(idempotencyService))
+
+	tests := []struct {
+		name          string
+		contentLength int64
+	}{
+		{name: "declared length", contentLength: 32},
+		{name: "unknown length", contentLength: -1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(strings.Repeat("a", 32)))
+			req.ContentLength = tt.contentLength
+			req.Header.Set(HeaderIdempotencyKey, "key")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusRequestEntityTooLarge {
+				t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+			}
+			if len(keyRepo.keys) != 0 {
+				t.Errorf("expected no key to be reserved, got %d", len(keyRepo.keys))
+			}
+		})
+	}
+}
@@ -28,7 +28,8 @@ func Logger(log *logger.Logger) echo.MiddlewareFunc {
 				c.Error(err)
 			}
 
-			// Log request details after completion
+			// Log request details after completion, with the trace of the request
+			completedLogger := reqLogger.WithSpanContext(c.Request().Context())
 			latency := time.Since(start)
 			status := c.Response().Status
 			method := c.Request().Method
@@ -38,7 +39,7 @@ func Logger(log *logger.Logger) echo.MiddlewareFunc {
 			// Log at appropriate level based on status code
 			switch {
 			case status >= 500:
-				reqLogger.Error("Request completed",
+				completedLogger.Error("Request completed",
 					"status", status,
 					"method", method,
 					"path", path,
@@ -46,7 +47,7 @@ func Logger(log *logger.Logger) echo.MiddlewareFunc {
 					"latency", latency,
 				)
 			case status >= 400:
-				reqLogger.Warn("Request completed",
+				completedLogger.Warn("Request completed",
 					"status", status,
 					"method", method,
 					"path", path,
@@ -54,7 +55,7 @@ func Logger(log *logger.Logger) echo.MiddlewareFunc {
 					"latency", latency,
 				)
 			default:
-				reqLogger.Info("Request completed",
+				completedLogger.Info("Request completed",
 					"status", status,
 					"method", method,
 					"path", path,
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// ProblemDetails returns a middleware that chooses the format of error responses.
+// Errors are RFC 7807 problem details if the configured format is "problem", or if the client
+// accepts application/problem+json; otherwise they keep the standard error response.
+func ProblemDetails(format string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if format == "problem" || response.AcceptsProblemDetails(c.Request().Header.Get(echo.HeaderAccept)) {
+				response.UseProblemDetails(c)
+			}
+			return next(c)
+		}
+	}
+}
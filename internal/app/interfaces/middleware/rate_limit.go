@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/metrics"
+	"github.com/sh1ro/todo-api/pkg/ratelimit"
+)
+
+// Rate limit response headers
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimit returns a middleware that limits each client to the requests of the policy, keeping
+// their token buckets in the store. Clients are told their limit in RateLimit-* headers, and how
+// long to wait in a Retry-After header once they reach it. Authenticated users are limited by
+// user ID, if the middleware runs after authentication, and anyone else by client IP. Requests
+// are let through if the store fails, so an outage of the store does not take the API down.
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy, log *logger.Logger) echo.MiddlewareFunc {
+	policyHeader := strconv.Itoa(policy.Limit) + ";w=" + strconv.Itoa(int(policy.Window.Seconds()))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key, ok := rateLimitKey(c)
+			if !ok {
+				return echo.NewHTTPError(http.StatusForbidden, "Unable to identify client")
+			}
+
+			result, err := store.Take(c.Request().Context(), key, policy)
+			if err != nil {
+				log.Error("Failed to check rate limit", "policy", policy.Name, "error", err)
+				metrics.RecordRateLimit(policy.Name, metrics.RateLimitResultError)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, ceilSeconds(result.Reset))
+			header.Set(HeaderRateLimitPolicy, policyHeader)
+
+			if !result.Allowed {
+				metrics.RecordRateLimit(policy.Name, metrics.RateLimitResultLimited)
+				header.Set(echo.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests, please try again later")
+			}
+
+			metrics.RecordRateLimit(policy.Name, metrics.RateLimitResultAllowed)
+			return next(c)
+		}
+	}
+}
+
+// rateLimitKey identifies the client of a request by user ID, or by IP if no user is authenticated
+func rateLimitKey(c echo.Context) (string, bool) {
+	if userID, exists := GetUserID(c); exists {
+		if id, ok := userID.(uuid.UUID); ok {
+			return "user:" + id.String(), true
+		}
+	}
+
+	ip := c.RealIP()
+	if ip == "" {
+		return "", false
+	}
+	return "ip:" + ip, true
+}
+
+// ceilSeconds formats a duration as a whole number of seconds, rounded up
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor returns how the client IP of a request is found. Behind a reverse proxy such as the
+// bundled nginx, it is taken from the X-Forwarded-For header, skipping the addresses of trusted
+// proxies, so clients cannot pass themselves off as someone else. Without trusted proxies, it is
+// the address of the connection.
+func IPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -4,21 +4,32 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sh1ro/todo-api/pkg/logger"
+	"github.com/sh1ro/todo-api/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// RequestIDAttribute is the span attribute holding the request ID
+const RequestIDAttribute = "http.request.id"
+
 // RequestIDKey is the key used to store the request ID in the context
 const RequestIDKey = "request_id"
 
-// RequestID returns a middleware that adds a request ID to the context
+// RequestID returns a middleware that adds a request ID to the context. Requests without an
+// X-Request-ID header get the ID of their trace, so logs and traces of a request share one ID.
 func RequestID(log *logger.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// Check if request ID is already set in header
 			requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = tracing.TraceID(c.Request().Context())
+			}
 			if requestID == "" {
 				// Generate a new request ID
 				requestID = uuid.New().String()
 			}
+			trace.SpanFromContext(c.Request().Context()).SetAttributes(attribute.String(RequestIDAttribute, requestID))
 
 			// Set request ID in response header
 			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/openapi"
+	"github.com/sh1ro/todo-api/pkg/response"
+)
+
+// ValidateRequest returns a middleware that validates the path, query and JSON body of requests
+// against the operations of an OpenAPI document before the handler runs. Unknown query parameters
+// and body fields are rejected, and all errors are reported at once. Routes the document does not
+// describe are let through.
+func ValidateRequest(doc *openapi.Document) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			op := doc.Operation(req.Method, openapi.RoutePath(c.Path()))
+			if op == nil {
+				return next(c)
+			}
+
+			path := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				path[name] = c.ParamValues()[i]
+			}
+			errs := doc.ValidateParameters(op, path, c.QueryParams())
+
+			if op.RequestBody != nil && isJSON(req) {
+				body, err := readBody(req)
+				if err != nil {
+					return err
+				}
+
+				bodyErrs, err := doc.ValidateBody(op, body)
+				if errors.Is(err, openapi.ErrInvalidJSON) {
+					return response.RespondWithBadRequest(c, "Invalid JSON format")
+				}
+				errs = append(errs, bodyErrs...)
+			}
+
+			if len(errs) > 0 {
+				return response.RespondWithValidationError(c, "Validation failed", errs)
+			}
+			return next(c)
+		}
+	}
+}
+
+// isJSON reports whether a request has a JSON body, or no content type for handlers to bind it as JSON
+func isJSON(req *http.Request) bool {
+	contentType := req.Header.Get(echo.HeaderContentType)
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == echo.MIMEApplicationJSON
+}
@@ -0,0 +1,9 @@
+-- Migration Down
+
+-- Drop table
+DROP TABLE IF EXISTS mfa_recovery_codes;
+
+-- Drop columns
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_last_used_step;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_enabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_secret;
@@ -0,0 +1,17 @@
+-- Migration Up
+
+-- Add TOTP two-factor authentication to users. The secret is set when enrollment starts
+-- and 2FA is only enforced once mfa_enabled_at is set.
+ALTER TABLE users ADD COLUMN mfa_secret VARCHAR(64);
+ALTER TABLE users ADD COLUMN mfa_enabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN mfa_last_used_step BIGINT NOT NULL DEFAULT 0;
+
+-- Create recovery codes table, which only stores hashes of the codes
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_personal_access_tokens_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS personal_access_tokens;
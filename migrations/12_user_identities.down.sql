@@ -0,0 +1,6 @@
+-- Migration Down
+
+-- Drop tables
+DROP TABLE IF EXISTS oauth_states;
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP TABLE IF EXISTS user_identities;
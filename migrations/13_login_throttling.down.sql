@@ -0,0 +1,8 @@
+-- Migration Down
+
+-- Drop login throttles table
+DROP INDEX IF EXISTS idx_login_throttles_last_failed_at;
+DROP TABLE IF EXISTS login_throttles;
+
+-- Remove role from users
+ALTER TABLE users DROP COLUMN IF EXISTS role;
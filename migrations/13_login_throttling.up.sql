@@ -0,0 +1,15 @@
+-- Migration Up
+
+-- Add role to users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Create login throttles table, counting failed logins per email and per client IP
+CREATE TABLE IF NOT EXISTS login_throttles (
+    key VARCHAR(300) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index
+CREATE INDEX idx_login_throttles_last_failed_at ON login_throttles(last_failed_at);
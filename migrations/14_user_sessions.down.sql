@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_user_sessions_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS user_sessions;
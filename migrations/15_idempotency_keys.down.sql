@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+
+-- Drop table
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_mfa_challenges_expires_at;
+
+-- Drop table
+DROP TABLE IF EXISTS mfa_challenges;
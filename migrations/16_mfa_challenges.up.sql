@@ -0,0 +1,13 @@
+-- Migration Up
+
+-- Create MFA challenges table, counting the codes tried for each login waiting for its second factor
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index
+CREATE INDEX idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
@@ -0,0 +1,4 @@
+-- Migration Down
+
+-- Drop token versions
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
@@ -0,0 +1,6 @@
+-- Migration Up
+
+-- Count token revocations per user, so a revocation covers exactly the tokens issued before it.
+-- Users who revoked their tokens before start at 1, which revokes the tokens issued without a version.
+ALTER TABLE users ADD COLUMN token_version INTEGER NOT NULL DEFAULT 0;
+UPDATE users SET token_version = 1 WHERE tokens_revoked_at IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_todos_user_status_position;
+
+-- Drop column
+ALTER TABLE todos DROP COLUMN IF EXISTS position;
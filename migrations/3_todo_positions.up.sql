@@ -0,0 +1,16 @@
+-- Migration Up
+
+-- Add manual ordering for board columns
+ALTER TABLE todos ADD COLUMN position DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Spread existing todos out within each (user, status) column
+UPDATE todos
+SET position = ranked.rn * 1024
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, status ORDER BY created_at) AS rn
+    FROM todos
+) AS ranked
+WHERE todos.id = ranked.id;
+
+-- Create index for ordered column reads
+CREATE INDEX idx_todos_user_status_position ON todos(user_id, status, position);
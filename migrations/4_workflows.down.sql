@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_workflows_updated_at ON workflows;
+
+-- Drop table
+DROP TABLE IF EXISTS workflows;
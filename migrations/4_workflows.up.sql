@@ -0,0 +1,18 @@
+-- Migration Up
+
+-- Create workflows table
+CREATE TABLE IF NOT EXISTS workflows (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    statuses JSONB NOT NULL,
+    initial_status VARCHAR(20) NOT NULL,
+    done_statuses JSONB NOT NULL,
+    transitions JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create trigger for updating updated_at
+CREATE TRIGGER update_workflows_updated_at
+BEFORE UPDATE ON workflows
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_todo_dependencies_blocker_id;
+
+-- Drop table
+DROP TABLE IF EXISTS todo_dependencies;
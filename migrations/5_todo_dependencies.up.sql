@@ -0,0 +1,13 @@
+-- Migration Up
+
+-- Create todo dependencies table (todo_id is blocked by blocker_id)
+CREATE TABLE IF NOT EXISTS todo_dependencies (
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    blocker_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (todo_id, blocker_id),
+    CHECK (todo_id <> blocker_id)
+);
+
+-- Create index for reverse lookups
+CREATE INDEX idx_todo_dependencies_blocker_id ON todo_dependencies(blocker_id);
@@ -0,0 +1,15 @@
+-- Migration Down
+
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_time_entries_updated_at ON time_entries;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_time_entries_running;
+DROP INDEX IF EXISTS idx_time_entries_user_started_at;
+DROP INDEX IF EXISTS idx_time_entries_todo_id;
+
+-- Drop table
+DROP TABLE IF EXISTS time_entries;
+
+-- Drop column
+ALTER TABLE todos DROP COLUMN IF EXISTS estimate_minutes;
@@ -0,0 +1,31 @@
+-- Migration Up
+
+-- Add time estimates to todos
+ALTER TABLE todos ADD COLUMN estimate_minutes INTEGER CHECK (estimate_minutes >= 0);
+
+-- Create time entries table
+CREATE TABLE IF NOT EXISTS time_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    note TEXT NOT NULL DEFAULT '',
+    source VARCHAR(20) NOT NULL DEFAULT 'timer',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- Create indexes
+CREATE INDEX idx_time_entries_todo_id ON time_entries(todo_id);
+CREATE INDEX idx_time_entries_user_started_at ON time_entries(user_id, started_at);
+
+-- At most one running timer per user
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries(user_id) WHERE ended_at IS NULL;
+
+-- Create trigger for updating updated_at
+CREATE TRIGGER update_time_entries_updated_at
+BEFORE UPDATE ON time_entries
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
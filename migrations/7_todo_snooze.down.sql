@@ -0,0 +1,7 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_todos_snoozed_until;
+
+-- Drop column
+ALTER TABLE todos DROP COLUMN IF EXISTS snoozed_until;
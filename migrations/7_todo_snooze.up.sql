@@ -0,0 +1,7 @@
+-- Migration Up
+
+-- Add snooze date to todos
+ALTER TABLE todos ADD COLUMN snoozed_until TIMESTAMP WITH TIME ZONE;
+
+-- Create index for the snooze scheduler
+CREATE INDEX idx_todos_snoozed_until ON todos(snoozed_until) WHERE snoozed_until IS NOT NULL;
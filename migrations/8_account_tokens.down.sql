@@ -0,0 +1,11 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_user_tokens_user_purpose;
+
+-- Drop table
+DROP TABLE IF EXISTS user_tokens;
+
+-- Drop columns
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_revoked_at;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
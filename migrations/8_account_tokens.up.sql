@@ -0,0 +1,20 @@
+-- Migration Up
+
+-- Track email verification and token revocation on users
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN tokens_revoked_at TIMESTAMP WITH TIME ZONE;
+
+-- Create user tokens table, which only stores hashes of the tokens sent by email
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(30) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index
+CREATE INDEX idx_user_tokens_user_purpose ON user_tokens(user_id, purpose, created_at);
@@ -0,0 +1,9 @@
+-- Migration Down
+
+-- Drop index
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+
+-- Drop columns
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,39 @@
+// Package migrations holds the SQL migrations of the database schema, run by cmd/migrate
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// LatestVersion returns the version of the newest migration, which the schema has once all migrations ran
+func LatestVersion() (uint, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, name := range names {
+		prefix, _, found := strings.Cut(name, "_")
+		if !found {
+			return 0, fmt.Errorf("invalid migration file name: %s", name)
+		}
+
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name: %s", name)
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+
+	return latest, nil
+}
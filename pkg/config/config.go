@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -10,11 +11,23 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Env      string
-	Port     int
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	Env            string
+	Port           int
+	ErrorFormat    string
+	MaxBodyBytes   int64
+	TrustedProxies []*net.IPNet
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	CORS           CORSConfig
+	Scheduler      SchedulerConfig
+	Mail           MailConfig
+	Account        AccountConfig
+	Password       PasswordConfig
+	OAuth          OAuthConfig
+	Idempotency    IdempotencyConfig
+	RateLimit      RateLimitConfig
+	Health         HealthConfig
+	Tracing        TracingConfig
 }
 
 // DatabaseConfig holds database configuration
@@ -28,12 +41,22 @@ type DatabaseConfig struct {
 	MaxConnections        int
 	MaxIdleConnections    int
 	ConnectionMaxLifetime time.Duration
+	QueryTimeout          time.Duration
+	TxIsolation           string
+	TxMaxRetries          int
 }
 
-// JWTConfig holds JWT configuration
+// JWTConfig holds JWT configuration. Tokens are signed with the private key in SigningKeyFile,
+// or with the HS256 Secret if no key file is set.
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret               string
+	Expiration           time.Duration
+	Issuer               string
+	Audience             string
+	Leeway               time.Duration
+	SessionCacheTTL      time.Duration
+	SigningKeyFile       string
+	VerificationKeyFiles []string
 }
 
 // CORSConfig holds CORS configuration
@@ -44,6 +67,101 @@ type CORSConfig struct {
 	MaxAge         int
 }
 
+// SchedulerConfig holds background scheduler configuration
+type SchedulerConfig struct {
+	SnoozeInterval       time.Duration
+	AccountPurgeInterval time.Duration
+}
+
+// MailConfig holds outgoing mail configuration
+type MailConfig struct {
+	Driver   string
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// AccountConfig holds account verification, recovery, deletion, 2FA and login lockout configuration
+type AccountConfig struct {
+	AppURL               string
+	EmailVerificationTTL time.Duration
+	PasswordResetTTL     time.Duration
+	DeletionGracePeriod  time.Duration
+	MFAIssuer            string
+	LoginMaxFailures     int
+	LoginIPMaxFailures   int
+	LoginLockoutDuration time.Duration
+}
+
+// PasswordConfig holds the password policy and how passwords are hashed
+type PasswordConfig struct {
+	MinLength           int
+	MinCharacterClasses int
+	RejectPersonalInfo  bool
+	BreachedCheck       bool
+	BreachedPath        string
+	HashAlgorithm       string
+	BcryptCost          int
+	Argon2Memory        uint32
+	Argon2Iterations    uint32
+	Argon2Parallelism   uint8
+}
+
+// OAuthConfig holds external identity provider configuration. A provider is enabled when its client ID is set.
+type OAuthConfig struct {
+	CallbackURL        string
+	GoogleClientID     string
+	GoogleClientSecret string
+	GitHubClientID     string
+	GitHubClientSecret string
+	OIDCName           string
+	OIDCIssuerURL      string
+	OIDCClientID       string
+	OIDCClientSecret   string
+}
+
+// IdempotencyConfig holds how long idempotency keys and their responses are kept
+type IdempotencyConfig struct {
+	KeyTTL time.Duration
+}
+
+// RateLimitConfig holds the request rate limits of route groups and where their buckets are kept.
+// Auth routes are limited per client IP, and routes for signed in users per user.
+type RateLimitConfig struct {
+	Enabled      bool
+	Store        string
+	RedisURL     string
+	AuthRequests int
+	AuthWindow   time.Duration
+	APIRequests  int
+	APIWindow    time.Duration
+}
+
+// HealthConfig holds the health checks of the service and how it leaves traffic on shutdown
+type HealthConfig struct {
+	CheckTimeout  time.Duration
+	DiskPath      string
+	DiskMinFree   uint64
+	ShutdownDelay time.Duration
+}
+
+// TracingConfig holds where OpenTelemetry spans are exported to and which share of traces is kept.
+// Requests carrying a sampled traceparent header are always traced.
+type TracingConfig struct {
+	Exporter     string
+	OTLPEndpoint string
+	ServiceName  string
+	SampleRatio  float64
+}
+
+// defaultTrustedProxies are the loopback and private networks the bundled nginx runs in
+const defaultTrustedProxies = "127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7"
+
+// defaultJWTSecret is the placeholder JWT secret, which must not be used in production
+const defaultJWTSecret = "your_jwt_secret_key_here"
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	port, err := strconv.Atoi(getEnv("PORT", "8080"))
@@ -71,19 +189,284 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid DB_CONNECTION_MAX_LIFETIME: %w", err)
 	}
 
+	dbQueryTimeout, err := time.ParseDuration(getEnv("DB_QUERY_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_QUERY_TIMEOUT: %w", err)
+	}
+	if dbQueryTimeout < 0 {
+		return nil, fmt.Errorf("invalid DB_QUERY_TIMEOUT: must not be negative")
+	}
+
+	dbTxIsolation := getEnv("DB_TX_ISOLATION", "read_committed")
+	if dbTxIsolation != "read_committed" && dbTxIsolation != "repeatable_read" && dbTxIsolation != "serializable" {
+		return nil, fmt.Errorf("invalid DB_TX_ISOLATION: must be read_committed, repeatable_read or serializable")
+	}
+
+	dbTxMaxRetries, err := strconv.Atoi(getEnv("DB_TX_MAX_RETRIES", "3"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_TX_MAX_RETRIES: %w", err)
+	}
+	if dbTxMaxRetries < 0 {
+		return nil, fmt.Errorf("invalid DB_TX_MAX_RETRIES: must not be negative")
+	}
+
 	jwtExpiration, err := time.ParseDuration(getEnv("JWT_EXPIRATION", "24h"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT_EXPIRATION: %w", err)
 	}
 
+	jwtLeeway, err := time.ParseDuration(getEnv("JWT_LEEWAY", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_LEEWAY: %w", err)
+	}
+	if jwtLeeway < 0 {
+		return nil, fmt.Errorf("invalid JWT_LEEWAY: must not be negative")
+	}
+
+	sessionCacheTTL, err := time.ParseDuration(getEnv("SESSION_CACHE_TTL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SESSION_CACHE_TTL: %w", err)
+	}
+	if sessionCacheTTL < 0 {
+		return nil, fmt.Errorf("invalid SESSION_CACHE_TTL: must not be negative")
+	}
+
+	jwtSecret := getEnv("JWT_SECRET", defaultJWTSecret)
+	jwtSigningKeyFile := getEnv("JWT_SIGNING_KEY_FILE", "")
+	env := getEnv("ENV", "development")
+	if env == "production" && jwtSigningKeyFile == "" && jwtSecret == defaultJWTSecret {
+		return nil, fmt.Errorf("invalid JWT_SECRET: set JWT_SECRET or JWT_SIGNING_KEY_FILE in production")
+	}
+
+	errorFormat := getEnv("ERROR_FORMAT", "envelope")
+	if errorFormat != "envelope" && errorFormat != "problem" {
+		return nil, fmt.Errorf("invalid ERROR_FORMAT: must be envelope or problem")
+	}
+
+	maxBodyKB, err := strconv.ParseInt(getEnv("MAX_BODY_KB", "1024"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAX_BODY_KB: %w", err)
+	}
+	if maxBodyKB <= 0 {
+		return nil, fmt.Errorf("invalid MAX_BODY_KB: must be positive")
+	}
+
 	corsMaxAge, err := strconv.Atoi(getEnv("CORS_MAX_AGE", "300"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid CORS_MAX_AGE: %w", err)
 	}
 
+	snoozeInterval, err := time.ParseDuration(getEnv("SNOOZE_CHECK_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNOOZE_CHECK_INTERVAL: %w", err)
+	}
+	if snoozeInterval <= 0 {
+		return nil, fmt.Errorf("invalid SNOOZE_CHECK_INTERVAL: must be positive")
+	}
+
+	accountPurgeInterval, err := time.ParseDuration(getEnv("ACCOUNT_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_PURGE_INTERVAL: %w", err)
+	}
+	if accountPurgeInterval <= 0 {
+		return nil, fmt.Errorf("invalid ACCOUNT_PURGE_INTERVAL: must be positive")
+	}
+
+	smtpPort, err := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
+	}
+
+	// The log driver only logs emails, so users would never get their links in production
+	mailDriver := getEnv("MAIL_DRIVER", "log")
+	if env == "production" && mailDriver != "smtp" {
+		return nil, fmt.Errorf("invalid MAIL_DRIVER: must be smtp in production")
+	}
+
+	emailVerificationTTL, err := time.ParseDuration(getEnv("EMAIL_VERIFICATION_TTL", "48h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_VERIFICATION_TTL: %w", err)
+	}
+
+	passwordResetTTL, err := time.ParseDuration(getEnv("PASSWORD_RESET_TTL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_RESET_TTL: %w", err)
+	}
+
+	deletionGracePeriod, err := time.ParseDuration(getEnv("ACCOUNT_DELETION_GRACE_PERIOD", "720h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACCOUNT_DELETION_GRACE_PERIOD: %w", err)
+	}
+	if deletionGracePeriod < 0 {
+		return nil, fmt.Errorf("invalid ACCOUNT_DELETION_GRACE_PERIOD: must not be negative")
+	}
+
+	loginMaxFailures, err := strconv.Atoi(getEnv("LOGIN_MAX_FAILURES", "10"))
+	if err != nil || loginMaxFailures <= 0 {
+		return nil, fmt.Errorf("invalid LOGIN_MAX_FAILURES: must be a positive integer")
+	}
+
+	loginIPMaxFailures, err := strconv.Atoi(getEnv("LOGIN_IP_MAX_FAILURES", "100"))
+	if err != nil || loginIPMaxFailures <= 0 {
+		return nil, fmt.Errorf("invalid LOGIN_IP_MAX_FAILURES: must be a positive integer")
+	}
+
+	loginLockoutDuration, err := time.ParseDuration(getEnv("LOGIN_LOCKOUT_DURATION", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_DURATION: %w", err)
+	}
+	if loginLockoutDuration <= 0 {
+		return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_DURATION: must be positive")
+	}
+
+	passwordMinLength, err := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	if err != nil || passwordMinLength < 1 {
+		return nil, fmt.Errorf("invalid PASSWORD_MIN_LENGTH: must be a positive integer")
+	}
+
+	passwordMinClasses, err := strconv.Atoi(getEnv("PASSWORD_MIN_CHARACTER_CLASSES", "1"))
+	if err != nil || passwordMinClasses < 1 || passwordMinClasses > 4 {
+		return nil, fmt.Errorf("invalid PASSWORD_MIN_CHARACTER_CLASSES: must be between 1 and 4")
+	}
+
+	passwordRejectPersonalInfo, err := strconv.ParseBool(getEnv("PASSWORD_REJECT_PERSONAL_INFO", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_REJECT_PERSONAL_INFO: %w", err)
+	}
+
+	passwordBreachedCheck, err := strconv.ParseBool(getEnv("PASSWORD_BREACHED_CHECK", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_BREACHED_CHECK: %w", err)
+	}
+
+	passwordHashAlgorithm := getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt")
+	if passwordHashAlgorithm != "bcrypt" && passwordHashAlgorithm != "argon2id" {
+		return nil, fmt.Errorf("invalid PASSWORD_HASH_ALGORITHM: must be bcrypt or argon2id")
+	}
+
+	passwordBcryptCost, err := strconv.Atoi(getEnv("PASSWORD_BCRYPT_COST", "10"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_BCRYPT_COST: %w", err)
+	}
+
+	passwordArgon2Memory, err := strconv.ParseUint(getEnv("PASSWORD_ARGON2_MEMORY", "65536"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_ARGON2_MEMORY: %w", err)
+	}
+
+	passwordArgon2Iterations, err := strconv.ParseUint(getEnv("PASSWORD_ARGON2_ITERATIONS", "3"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_ARGON2_ITERATIONS: %w", err)
+	}
+
+	passwordArgon2Parallelism, err := strconv.ParseUint(getEnv("PASSWORD_ARGON2_PARALLELISM", "2"), 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_ARGON2_PARALLELISM: %w", err)
+	}
+
+	idempotencyKeyTTL, err := time.ParseDuration(getEnv("IDEMPOTENCY_KEY_TTL", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: %w", err)
+	}
+	if idempotencyKeyTTL <= 0 {
+		return nil, fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: must be positive")
+	}
+
+	trustedProxies, err := parseNetworks(getEnv("TRUSTED_PROXIES", defaultTrustedProxies))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
+	rateLimitEnabled, err := strconv.ParseBool(getEnv("RATE_LIMIT_ENABLED", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ENABLED: %w", err)
+	}
+
+	rateLimitStore := getEnv("RATE_LIMIT_STORE", "memory")
+	if rateLimitStore != "memory" && rateLimitStore != "redis" {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_STORE: must be memory or redis")
+	}
+
+	rateLimitAuthRequests, err := strconv.Atoi(getEnv("RATE_LIMIT_AUTH_REQUESTS", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_REQUESTS: %w", err)
+	}
+	if rateLimitAuthRequests <= 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_REQUESTS: must be positive")
+	}
+
+	rateLimitAuthWindow, err := time.ParseDuration(getEnv("RATE_LIMIT_AUTH_WINDOW", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_WINDOW: %w", err)
+	}
+	if rateLimitAuthWindow <= 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_WINDOW: must be positive")
+	}
+
+	rateLimitAPIRequests, err := strconv.Atoi(getEnv("RATE_LIMIT_API_REQUESTS", "300"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_API_REQUESTS: %w", err)
+	}
+	if rateLimitAPIRequests <= 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_API_REQUESTS: must be positive")
+	}
+
+	rateLimitAPIWindow, err := time.ParseDuration(getEnv("RATE_LIMIT_API_WINDOW", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_API_WINDOW: %w", err)
+	}
+	if rateLimitAPIWindow <= 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_API_WINDOW: must be positive")
+	}
+
+	healthCheckTimeout, err := time.ParseDuration(getEnv("HEALTH_CHECK_TIMEOUT", "2s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HEALTH_CHECK_TIMEOUT: %w", err)
+	}
+	if healthCheckTimeout <= 0 {
+		return nil, fmt.Errorf("invalid HEALTH_CHECK_TIMEOUT: must be positive")
+	}
+
+	healthDiskMinFreeMB, err := strconv.ParseUint(getEnv("HEALTH_DISK_MIN_FREE_MB", "512"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HEALTH_DISK_MIN_FREE_MB: %w", err)
+	}
+
+	shutdownDelay, err := time.ParseDuration(getEnv("SHUTDOWN_DELAY", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SHUTDOWN_DELAY: %w", err)
+	}
+	if shutdownDelay < 0 {
+		return nil, fmt.Errorf("invalid SHUTDOWN_DELAY: must not be negative")
+	}
+
+	tracingExporter := getEnv("TRACING_EXPORTER", "none")
+	if tracingExporter != "none" && tracingExporter != "stdout" && tracingExporter != "otlp" {
+		return nil, fmt.Errorf("invalid TRACING_EXPORTER: must be none, stdout or otlp")
+	}
+
+	tracingSampleRatio, err := strconv.ParseFloat(getEnv("TRACING_SAMPLE_RATIO", "1"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO: %w", err)
+	}
+	if tracingSampleRatio < 0 || tracingSampleRatio > 1 {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATIO: must be between 0 and 1")
+	}
+
+	appURL := getEnv("APP_URL", "http://localhost:3000")
+
+	oidcClientID := getEnv("OIDC_CLIENT_ID", "")
+	oidcIssuerURL := getEnv("OIDC_ISSUER_URL", "")
+	if oidcClientID != "" && oidcIssuerURL == "" {
+		return nil, fmt.Errorf("invalid OIDC_ISSUER_URL: required when OIDC_CLIENT_ID is set")
+	}
+
 	return &Config{
-		Env:  getEnv("ENV", "development"),
-		Port: port,
+		Env:            env,
+		Port:           port,
+		ErrorFormat:    errorFormat,
+		MaxBodyBytes:   maxBodyKB << 10,
+		TrustedProxies: trustedProxies,
 		Database: DatabaseConfig{
 			Host:                  getEnv("DB_HOST", "localhost"),
 			Port:                  dbPort,
@@ -94,17 +477,95 @@ func LoadConfig() (*Config, error) {
 			MaxConnections:        dbMaxConn,
 			MaxIdleConnections:    dbMaxIdleConn,
 			ConnectionMaxLifetime: dbConnMaxLifetime,
+			QueryTimeout:          dbQueryTimeout,
+			TxIsolation:           dbTxIsolation,
+			TxMaxRetries:          dbTxMaxRetries,
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your_jwt_secret_key_here"),
-			Expiration: jwtExpiration,
+			Secret:               jwtSecret,
+			Expiration:           jwtExpiration,
+			Issuer:               getEnv("JWT_ISSUER", "todo-api"),
+			Audience:             getEnv("JWT_AUDIENCE", "todo-api"),
+			Leeway:               jwtLeeway,
+			SessionCacheTTL:      sessionCacheTTL,
+			SigningKeyFile:       jwtSigningKeyFile,
+			VerificationKeyFiles: splitList(getEnv("JWT_VERIFICATION_KEY_FILES", "")),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "*"), ","),
-			AllowedMethods: strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"), ","),
-			AllowedHeaders: strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Authorization,Content-Type"), ","),
+			AllowedMethods: strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"), ","),
+			AllowedHeaders: strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,Idempotency-Key"), ","),
 			MaxAge:         corsMaxAge,
 		},
+		Scheduler: SchedulerConfig{
+			SnoozeInterval:       snoozeInterval,
+			AccountPurgeInterval: accountPurgeInterval,
+		},
+		Mail: MailConfig{
+			Driver:   mailDriver,
+			Host:     getEnv("SMTP_HOST", "localhost"),
+			Port:     smtpPort,
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("MAIL_FROM", "Todo API <no-reply@localhost>"),
+		},
+		Account: AccountConfig{
+			AppURL:               appURL,
+			EmailVerificationTTL: emailVerificationTTL,
+			PasswordResetTTL:     passwordResetTTL,
+			DeletionGracePeriod:  deletionGracePeriod,
+			MFAIssuer:            getEnv("MFA_ISSUER", "Todo API"),
+			LoginMaxFailures:     loginMaxFailures,
+			LoginIPMaxFailures:   loginIPMaxFailures,
+			LoginLockoutDuration: loginLockoutDuration,
+		},
+		Password: PasswordConfig{
+			MinLength:           passwordMinLength,
+			MinCharacterClasses: passwordMinClasses,
+			RejectPersonalInfo:  passwordRejectPersonalInfo,
+			BreachedCheck:       passwordBreachedCheck,
+			BreachedPath:        getEnv("PASSWORD_BREACHED_PATH", ""),
+			HashAlgorithm:       passwordHashAlgorithm,
+			BcryptCost:          passwordBcryptCost,
+			Argon2Memory:        uint32(passwordArgon2Memory),
+			Argon2Iterations:    uint32(passwordArgon2Iterations),
+			Argon2Parallelism:   uint8(passwordArgon2Parallelism),
+		},
+		OAuth: OAuthConfig{
+			CallbackURL:        strings.TrimSuffix(getEnv("OAUTH_CALLBACK_URL", appURL+"/oauth/callback"), "/"),
+			GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
+			GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+			GitHubClientID:     getEnv("GITHUB_CLIENT_ID", ""),
+			GitHubClientSecret: getEnv("GITHUB_CLIENT_SECRET", ""),
+			OIDCName:           getEnv("OIDC_PROVIDER_NAME", "oidc"),
+			OIDCIssuerURL:      oidcIssuerURL,
+			OIDCClientID:       oidcClientID,
+			OIDCClientSecret:   getEnv("OIDC_CLIENT_SECRET", ""),
+		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL: idempotencyKeyTTL,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:      rateLimitEnabled,
+			Store:        rateLimitStore,
+			RedisURL:     getEnv("REDIS_URL", "redis://localhost:6379/0"),
+			AuthRequests: rateLimitAuthRequests,
+			AuthWindow:   rateLimitAuthWindow,
+			APIRequests:  rateLimitAPIRequests,
+			APIWindow:    rateLimitAPIWindow,
+		},
+		Health: HealthConfig{
+			CheckTimeout:  healthCheckTimeout,
+			DiskPath:      getEnv("HEALTH_DISK_PATH", "."),
+			DiskMinFree:   healthDiskMinFreeMB << 20,
+			ShutdownDelay: shutdownDelay,
+		},
+		Tracing: TracingConfig{
+			Exporter:     tracingExporter,
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "http://localhost:4318"),
+			ServiceName:  getEnv("TRACING_SERVICE_NAME", "todo-api"),
+			SampleRatio:  tracingSampleRatio,
+		},
 	}, nil
 }
 
@@ -116,3 +577,46 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseNetworks parses a comma separated list of CIDR ranges and IP addresses.
+// "none" is an empty list.
+func parseNetworks(value string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+	if value == "none" {
+		return networks, nil
+	}
+
+	for _, item := range splitList(value) {
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", item)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// VersionFunc returns the migration version of a database schema and whether a migration failed halfway
+type VersionFunc func(ctx context.Context) (uint, bool, error)
+
+// PingCheck returns a check that fails when ping does, such as a database ping
+func PingCheck(ping func(ctx context.Context) error) Check {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		if err := ping(ctx); err != nil {
+			return nil, fmt.Errorf("ping failed: %w", err)
+		}
+		return nil, nil
+	}
+}
+
+// MigrationCheck returns a check that fails when the schema is older than the expected version,
+// or a migration failed halfway. A newer schema only degrades the service, as it is expected
+// while a new release is rolled out.
+func MigrationCheck(version VersionFunc, expected uint) Check {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		current, dirty, err := version(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get migration version: %w", err)
+		}
+
+		details := map[string]interface{}{
+			"version":          current,
+			"expected_version": expected,
+			"dirty":            dirty,
+		}
+
+		switch {
+		case dirty:
+			return details, fmt.Errorf("migration %d failed halfway", current)
+		case current < expected:
+			return details, fmt.Errorf("schema version %d is older than %d", current, expected)
+		case current > expected:
+			return details, fmt.Errorf("%w: schema version %d is newer than %d", ErrDegraded, current, expected)
+		}
+
+		return details, nil
+	}
+}
+
+// DiskCheck returns a check that fails when the file system holding path has less than minFree bytes free
+func DiskCheck(path string, minFree uint64) Check {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		free, total, err := diskSpace(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get free disk space: %w", err)
+		}
+
+		details := map[string]interface{}{
+			"path":           path,
+			"free_bytes":     free,
+			"total_bytes":    total,
+			"min_free_bytes": minFree,
+		}
+		if free < minFree {
+			return details, fmt.Errorf("%d bytes free, below the minimum of %d", free, minFree)
+		}
+
+		return details, nil
+	}
+}
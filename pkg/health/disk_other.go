@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package health
+
+import "errors"
+
+// diskSpace is not supported on this platform
+func diskSpace(path string) (uint64, uint64, error) {
+	return 0, 0, errors.New("disk space checks are not supported on this platform")
+}
@@ -0,0 +1,16 @@
+//go:build linux || darwin
+
+package health
+
+import "syscall"
+
+// diskSpace returns the free and total bytes of the file system holding path
+func diskSpace(path string) (uint64, uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return stat.Bavail * blockSize, stat.Blocks * blockSize, nil
+}
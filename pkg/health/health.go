@@ -0,0 +1,183 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status is the health of the service or one of its checks
+type Status string
+
+const (
+	// StatusUp means everything works
+	StatusUp Status = "up"
+
+	// StatusDegraded means a non-critical check failed, so the service works with limitations
+	StatusDegraded Status = "degraded"
+
+	// StatusDown means a critical check failed or the service is shutting down
+	StatusDown Status = "down"
+)
+
+// ShutdownCheckName is the name of the check that fails while the service is shutting down
+const ShutdownCheckName = "shutdown"
+
+// ErrDegraded is wrapped by errors of checks that found a problem the service can work with,
+// so even a critical check only degrades the service
+var ErrDegraded = errors.New("degraded")
+
+// Check checks a dependency of the service, returning details to report and an error if it is unhealthy
+type Check func(ctx context.Context) (map[string]interface{}, error)
+
+// CheckResult is the outcome of a check
+type CheckResult struct {
+	Status    Status                 `json:"status"`
+	Critical  bool                   `json:"critical"`
+	LatencyMS float64                `json:"latency_ms"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the health of the service and its checks
+type Report struct {
+	Status Status                 `json:"status"`
+	Time   time.Time              `json:"time"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// registeredCheck is a check and whether the service is down without it
+type registeredCheck struct {
+	name     string
+	check    Check
+	critical bool
+}
+
+// Registry runs the registered checks of the service
+type Registry struct {
+	mu           sync.RWMutex
+	checks       []registeredCheck
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry creates a new registry whose checks each get at most timeout to finish
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{
+		timeout: timeout,
+	}
+}
+
+// Critical registers a check the service cannot work without, so it is down if the check fails
+func (r *Registry) Critical(name string, check Check) {
+	r.register(name, check, true)
+}
+
+// Optional registers a check the service can work without, so it is degraded if the check fails
+func (r *Registry) Optional(name string, check Check) {
+	r.register(name, check, false)
+}
+
+// register adds a check to a copy of the checks, so running checks are not affected
+func (r *Registry) register(name string, check Check, critical bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checks := append([]registeredCheck{}, r.checks...)
+	checks = append(checks, registeredCheck{name: name, check: check, critical: critical})
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+	r.checks = checks
+}
+
+// Shutdown marks the service as shutting down, so it is no longer ready
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+}
+
+// Live reports whether the process is running. It runs no checks, so a broken dependency does
+// not get a working process restarted.
+func (r *Registry) Live() Report {
+	return Report{
+		Status: StatusUp,
+		Time:   time.Now().UTC(),
+	}
+}
+
+// Ready runs all checks at once and reports whether the service can take traffic
+func (r *Registry) Ready(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := r.checks
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check registeredCheck) {
+			defer wg.Done()
+			results[i] = r.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Time:   time.Now().UTC(),
+		Checks: make(map[string]CheckResult, len(checks)+1),
+	}
+	for i, check := range checks {
+		report.Checks[check.name] = results[i]
+		report.Status = worse(report.Status, results[i])
+	}
+
+	if r.shuttingDown.Load() {
+		shutdown := CheckResult{Status: StatusDown, Critical: true, Error: "service is shutting down"}
+		report.Checks[ShutdownCheckName] = shutdown
+		report.Status = worse(report.Status, shutdown)
+	}
+
+	return report
+}
+
+// run runs a check within the timeout of the registry
+func (r *Registry) run(ctx context.Context, check registeredCheck) CheckResult {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	details, err := check.check(ctx)
+	result := CheckResult{
+		Status:    StatusUp,
+		Critical:  check.critical,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		Details:   details,
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		result.Status = StatusDown
+		if !check.critical || errors.Is(err, ErrDegraded) {
+			result.Status = StatusDegraded
+		}
+	}
+
+	return result
+}
+
+// worse returns the status of a report once a check result is added to it
+func worse(status Status, result CheckResult) Status {
+	switch {
+	case status == StatusDown || result.Status == StatusDown:
+		return StatusDown
+	case status == StatusDegraded || result.Status == StatusDegraded:
+		return StatusDegraded
+	default:
+		return StatusUp
+	}
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func up(ctx context.Context) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func failing(ctx context.Context) (map[string]interface{}, error) {
+	return nil, errors.New("failed")
+}
+
+func TestRegistryReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		critical Check
+		optional Check
+		expected Status
+	}{
+		{name: "all up", critical: up, optional: up, expected: StatusUp},
+		{name: "optional check failed", critical: up, optional: failing, expected: StatusDegraded},
+		{name: "critical check failed", critical: failing, optional: up, expected: StatusDown},
+		{name: "all failed", critical: failing, optional: failing, expected: StatusDown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(time.Second)
+			registry.Critical("critical", tt.critical)
+			registry.Optional("optional", tt.optional)
+
+			report := registry.Ready(context.Background())
+			if report.Status != tt.expected {
+				t.Errorf("expected status %s, got %s", tt.expected, report.Status)
+			}
+			if len(report.Checks) != 2 {
+				t.Fatalf("expected 2 checks, got %d", len(report.Checks))
+			}
+			if !report.Checks["critical"].Critical || report.Checks["optional"].Critical {
+				t.Error("expected checks to report whether they are critical")
+			}
+		})
+	}
+}
+
+func TestRegistryTimeout(t *testing.T) {
+	registry := NewRegistry(10 * time.Millisecond)
+	registry.Critical("slow", func(ctx context.Context) (map[string]interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	report := registry.Ready(context.Background())
+	if report.Status != StatusDown || report.Checks["slow"].Error == "" {
+		t.Errorf("expected a check that timed out to fail, got %+v", report)
+	}
+}
+
+func TestRegistryShutdown(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Critical("database", up)
+
+	if report := registry.Ready(context.Background()); report.Status != StatusUp {
+		t.Fatalf("expected the service to be up, got %s", report.Status)
+	}
+
+	registry.Shutdown()
+
+	report := registry.Ready(context.Background())
+	if report.Status != StatusDown || report.Checks[ShutdownCheckName].Status != StatusDown {
+		t.Errorf("expected the service not to be ready while shutting down, got %+v", report)
+	}
+	if live := registry.Live(); live.Status != StatusUp {
+		t.Errorf("expected the service to stay live while shutting down, got %s", live.Status)
+	}
+}
+
+func TestMigrationCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  uint
+		dirty    bool
+		err      error
+		expected Status
+	}{
+		{name: "expected version", version: 15, expected: StatusUp},
+		{name: "older schema", version: 14, expected: StatusDown},
+		{name: "newer schema", version: 16, expected: StatusDegraded},
+		{name: "dirty schema", version: 15, dirty: true, expected: StatusDown},
+		{name: "unknown version", err: errors.New("connection refused"), expected: StatusDown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(time.Second)
+			registry.Critical("migrations", MigrationCheck(func(ctx context.Context) (uint, bool, error) {
+				return tt.version, tt.dirty, tt.err
+			}, 15))
+
+			report := registry.Ready(context.Background())
+			if report.Status != tt.expected {
+				t.Errorf("expected status %s, got %s: %s", tt.expected, report.Status, report.Checks["migrations"].Error)
+			}
+		})
+	}
+}
+
+func TestDiskCheck(t *testing.T) {
+	details, err := DiskCheck(t.TempDir(), 0)(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if details["total_bytes"].(uint64) == 0 {
+		t.Errorf("expected the size of the file system, got %v", details)
+	}
+
+	if _, err := DiskCheck(t.TempDir(), ^uint64(0))(context.Background()); err == nil {
+		t.Error("expected an error when less than the minimum is free")
+	}
+}
@@ -0,0 +1,247 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms, as used in the alg header of a JWT
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minRSABits is the smallest RSA key accepted
+const minRSABits = 2048
+
+var (
+	// ErrUnsupportedKey is returned for key types and curves without a supported algorithm
+	ErrUnsupportedKey = errors.New("unsupported key type")
+
+	// ErrUnknownKey is returned when a token's kid does not match any key in the set
+	ErrUnknownKey = errors.New("unknown signing key")
+
+	// ErrAlgorithmMismatch is returned when a token's alg does not match the algorithm of its key
+	ErrAlgorithmMismatch = errors.New("signing algorithm does not match key")
+)
+
+// Key is a key tokens are signed or verified with. Verification-only keys have no private part.
+type Key struct {
+	ID        string
+	Algorithm string
+	private   crypto.PrivateKey
+	public    crypto.PublicKey
+}
+
+// NewHMACKey creates an HS256 key from a shared secret. HMAC keys have no ID and are never published.
+func NewHMACKey(secret []byte) *Key {
+	return &Key{Algorithm: AlgorithmHS256, private: secret, public: secret}
+}
+
+// NewKey creates a key from an RSA, P-256 ECDSA or Ed25519 private or public key.
+// The algorithm follows from the key type and the ID is its RFC 7638 thumbprint.
+func NewKey(key interface{}) (*Key, error) {
+	k := &Key{}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		k.private, k.public = key, &key.PublicKey
+	case *ecdsa.PrivateKey:
+		k.private, k.public = key, &key.PublicKey
+	case ed25519.PrivateKey:
+		k.private, k.public = key, key.Public()
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		k.public = key
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("rsa key must have at least %d bits", minRSABits)
+		}
+		k.Algorithm = AlgorithmRS256
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedKey
+		}
+		k.Algorithm = AlgorithmES256
+	case ed25519.PublicKey:
+		k.Algorithm = AlgorithmEdDSA
+	}
+
+	thumbprint, err := (&jose.JSONWebKey{Key: k.public}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	k.ID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	return k, nil
+}
+
+// ParsePEM parses a PEM encoded private key (PKCS #8, PKCS #1 or SEC 1) or public key (PKIX)
+func ParsePEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(key)
+}
+
+// LoadFile loads a PEM encoded key from a file
+func LoadFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+// CanSign checks if the key has a private part
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// method returns the JWT signing method of the key's algorithm
+func (k *Key) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// KeySet signs tokens with one key and verifies them with any of its keys. Keeping retired
+// and upcoming keys in the set next to the signing key lets keys be rotated without
+// invalidating tokens in flight or surprising verifiers that cache the published keys.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+}
+
+// NewKeySet creates a key set that signs with the given key and also accepts the verification keys
+func NewKeySet(signing *Key, verification ...*Key) (*KeySet, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, errors.New("signing key must be a private key")
+	}
+
+	keys := map[string]*Key{signing.ID: signing}
+	for _, key := range verification {
+		if key.Algorithm == AlgorithmHS256 || signing.Algorithm == AlgorithmHS256 {
+			return nil, errors.New("hmac keys cannot be combined with other keys")
+		}
+		if _, exists := keys[key.ID]; !exists {
+			keys[key.ID] = key
+		}
+	}
+
+	return &KeySet{signing: signing, keys: keys}, nil
+}
+
+// SigningKey returns the key new tokens are signed with
+func (s *KeySet) SigningKey() *Key {
+	return s.signing
+}
+
+// Sign signs claims with the signing key, naming it in the kid header
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.method(), claims)
+	if s.signing.ID != "" {
+		token.Header["kid"] = s.signing.ID
+	}
+	return token.SignedString(s.signing.private)
+}
+
+// Algorithms returns the algorithms of the keys in the set, to pin parsing to
+func (s *KeySet) Algorithms() []string {
+	seen := map[string]bool{}
+	algorithms := []string{}
+	for _, key := range s.keys {
+		if !seen[key.Algorithm] {
+			seen[key.Algorithm] = true
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	sort.Strings(algorithms)
+	return algorithms
+}
+
+// Keyfunc looks up the key a token names in its kid header. The token's alg must be the
+// algorithm of that key, so a public key can never be used as an HMAC secret.
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, ErrAlgorithmMismatch
+	}
+
+	return key.public, nil
+}
+
+// JWKS returns the public keys of the set as a JSON Web Key Set, for others to verify tokens with.
+// HMAC keys are secret and never included.
+func (s *KeySet) JWKS() jose.JSONWebKeySet {
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	for _, key := range s.keys {
+		if key.Algorithm == AlgorithmHS256 {
+			continue
+		}
+		set.Keys = append(set.Keys, jose.JSONWebKey{
+			Key:       key.public,
+			KeyID:     key.ID,
+			Algorithm: key.Algorithm,
+			Use:       "sig",
+		})
+	}
+
+	// Keep the output stable for caches
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].KeyID < set.Keys[j].KeyID
+	})
+
+	return set
+}
@@ -0,0 +1,185 @@
+package jwtkeys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestKey(t *testing.T, algorithm string) *Key {
+	t.Helper()
+
+	var (
+		private interface{}
+		err     error
+	)
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	key, err := NewKey(private)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+func testClaims() jwt.Claims {
+	return jwt.RegisteredClaims{
+		Subject:   "user",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func parse(set *KeySet, token string) error {
+	_, err := jwt.Parse(token, set.Keyfunc, jwt.WithValidMethods(set.Algorithms()))
+	return err
+}
+
+func TestKeySetSignAndVerify(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA} {
+		algorithm := algorithm
+		t.Run(algorithm, func(t *testing.T) {
+			key := newTestKey(t, algorithm)
+			if key.Algorithm != algorithm {
+				t.Fatalf("expected %s, got %s", algorithm, key.Algorithm)
+			}
+
+			set, err := NewKeySet(key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token, err := set.Sign(testClaims())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := parse(set, token); err != nil {
+				t.Errorf("expected the token to verify, got %v", err)
+			}
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	oldKey := newTestKey(t, AlgorithmRS256)
+	newKey := newTestKey(t, AlgorithmEdDSA)
+
+	oldSet, _ := NewKeySet(oldKey)
+	oldToken, _ := oldSet.Sign(testClaims())
+
+	// The old key is retired to a verification key once the new key signs
+	rotated, err := NewKeySet(newKey, publicOnly(t, oldKey))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := parse(rotated, oldToken); err != nil {
+		t.Errorf("expected tokens of the retired key to verify, got %v", err)
+	}
+
+	newToken, _ := rotated.Sign(testClaims())
+	if err := parse(oldSet, newToken); err == nil {
+		t.Error("expected a set without the new key to reject its tokens")
+	}
+
+	if keys := rotated.JWKS().Keys; len(keys) != 2 {
+		t.Errorf("expected both keys to be published, got %d", len(keys))
+	}
+}
+
+func TestKeySetRejectsAlgorithmConfusion(t *testing.T) {
+	key := newTestKey(t, AlgorithmRS256)
+	set, _ := NewKeySet(key)
+
+	// An HS256 token keyed with the published public key must not verify
+	publicDER, err := x509.MarshalPKIXPublicKey(key.public)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	forged.Header["kid"] = key.ID
+	token, _ := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+
+	if err := parse(set, token); err == nil {
+		t.Error("expected an HS256 token to be rejected by an RS256 key set")
+	}
+
+	// Neither can a token without a kid
+	anonymous := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims())
+	token, _ = anonymous.SignedString(key.private)
+	if err := parse(set, token); err == nil {
+		t.Error("expected a token without a kid to be rejected")
+	}
+}
+
+func TestParsePEM(t *testing.T) {
+	private, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(private)
+
+	key, err := ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !key.CanSign() || key.Algorithm != AlgorithmES256 {
+		t.Errorf("unexpected key: %s, can sign %v", key.Algorithm, key.CanSign())
+	}
+
+	publicDER, _ := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	public, err := ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if public.CanSign() || public.ID != key.ID {
+		t.Error("expected the public key to share the private key's ID without signing")
+	}
+
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if _, err := NewKey(p384); err == nil {
+		t.Error("expected P-384 keys to be rejected")
+	}
+}
+
+func TestJWKSExcludesHMACKeys(t *testing.T) {
+	set, err := NewKeySet(NewHMACKey([]byte("secret")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := set.JWKS().Keys; len(keys) != 0 {
+		t.Errorf("expected no published keys, got %d", len(keys))
+	}
+
+	token, _ := set.Sign(testClaims())
+	if err := parse(set, token); err != nil {
+		t.Errorf("expected the HS256 token to verify, got %v", err)
+	}
+}
+
+// publicOnly returns the verification-only half of a key
+func publicOnly(t *testing.T, key *Key) *Key {
+	t.Helper()
+
+	public, err := NewKey(key.public)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return public
+}
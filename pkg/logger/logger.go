@@ -1,11 +1,13 @@
 package logger
 
 import (
+	"context"
 	"io"
 	"os"
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -13,6 +15,12 @@ import (
 // RequestIDKey is the key used for request ID in the logger
 const RequestIDKey = "request_id"
 
+// TraceIDKey and SpanIDKey are the keys used for the trace and span IDs in the logger
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
 // Logger is a wrapper around zap.Logger
 type Logger struct {
 	*zap.Logger
@@ -116,7 +124,24 @@ func (l *Logger) WithRequestID(requestID string) *Logger {
 	return l.WithField(RequestIDKey, requestID)
 }
 
-// FromContext creates a logger from an Echo context with the request ID
+// WithSpanContext returns a new logger with the trace and span IDs of the span in the context,
+// or the logger itself if the context has no span
+func (l *Logger) WithSpanContext(ctx context.Context) *Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return l
+	}
+	zapLogger := l.Logger.With(
+		zap.String(TraceIDKey, spanContext.TraceID().String()),
+		zap.String(SpanIDKey, spanContext.SpanID().String()),
+	)
+	return &Logger{
+		Logger: zapLogger,
+		sugar:  zapLogger.Sugar(),
+	}
+}
+
+// FromContext creates a logger from an Echo context with the request ID and the trace and span IDs
 func FromContext(c echo.Context) *Logger {
 	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
 	if requestID == "" {
@@ -133,7 +158,7 @@ func FromContext(c echo.Context) *Logger {
 		logger = NewLogger("info", "console")
 	}
 	
-	return logger.WithRequestID(requestID)
+	return logger.WithRequestID(requestID).WithSpanContext(c.Request().Context())
 }
 
 // WithFields returns a new logger with the given fields
@@ -65,8 +65,64 @@ var (
 		},
 		[]string{"operation", "entity"},
 	)
+
+	// LoginAttemptsTotal counts password logins by result: success, failure or throttled
+	LoginAttemptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_login_attempts_total",
+			Help: "Total number of password login attempts, partitioned by result",
+		},
+		[]string{"result"},
+	)
+
+	// LoginLockoutsTotal counts temporary login lockouts by scope: email or ip
+	LoginLockoutsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_login_lockouts_total",
+			Help: "Total number of temporary login lockouts, partitioned by scope",
+		},
+		[]string{"scope"},
+	)
+
+	// RateLimitRequestsTotal counts rate limited requests by policy and result: allowed, limited or error
+	RateLimitRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_rate_limit_requests_total",
+			Help: "Total number of requests checked against a rate limit, partitioned by policy and result",
+		},
+		[]string{"policy", "result"},
+	)
+)
+
+// Login attempt results
+const (
+	LoginResultSuccess   = "success"
+	LoginResultFailure   = "failure"
+	LoginResultThrottled = "throttled"
 )
 
+// Rate limit results
+const (
+	RateLimitResultAllowed = "allowed"
+	RateLimitResultLimited = "limited"
+	RateLimitResultError   = "error"
+)
+
+// RecordLoginAttempt counts a password login attempt with the given result
+func RecordLoginAttempt(result string) {
+	LoginAttemptsTotal.WithLabelValues(result).Inc()
+}
+
+// RecordLoginLockout counts a temporary lockout of an email or ip
+func RecordLoginLockout(scope string) {
+	LoginLockoutsTotal.WithLabelValues(scope).Inc()
+}
+
+// RecordRateLimit counts a request checked against the rate limit policy with the given result
+func RecordRateLimit(policy, result string) {
+	RateLimitRequestsTotal.WithLabelValues(policy, result).Inc()
+}
+
 // MetricsMiddleware returns a middleware that collects metrics for HTTP requests
 func MetricsMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -0,0 +1,350 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schemas builds the schemas of Go types from their json and validate tags. Named structs become
+// components of the document and are referenced. A property is required when its validate tag
+// requires it.
+type Schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+// NewSchemas creates a new Schemas adding components to the document
+func NewSchemas(doc *Document) *Schemas {
+	return &Schemas{
+		components: doc.Components.Schemas,
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// For returns the schema of the type of v
+func (s *Schemas) For(v interface{}) *Schema {
+	return s.schema(reflect.TypeOf(v))
+}
+
+// Parameters returns the fields of the struct type of v as parameters in the given location,
+// such as query parameters of a query
+func (s *Schemas) Parameters(v interface{}, in string) []Parameter {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	object := s.object(t)
+	parameters := make([]Parameter, 0, len(object.Properties))
+	for _, name := range sortedNames(object.Properties) {
+		property := object.Properties[name]
+		parameters = append(parameters, Parameter{
+			Name:        name,
+			In:          in,
+			Description: property.Description,
+			Required:    slices.Contains(object.Required, name),
+			Schema:      property,
+		})
+	}
+	return parameters
+}
+
+// schema returns the schema of a type
+func (s *Schemas) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(s.schema(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: float(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + s.component(t)}
+	default:
+		// Interfaces can hold any value
+		return &Schema{}
+	}
+}
+
+// component adds a named struct to the components once, returning its name
+func (s *Schemas) component(t reflect.Type) string {
+	if name, exists := s.names[t]; exists {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := s.components[name]; taken {
+		// Another package has a type of the same name
+		name = exported(path.Base(t.PkgPath())) + name
+	}
+
+	// Register the name first, so recursive types reference it
+	s.names[t] = name
+	s.components[name] = &Schema{}
+	*s.components[name] = *s.object(t)
+	return name
+}
+
+// object returns the schema of the fields of a struct
+func (s *Schemas) object(t reflect.Type) *Schema {
+	object := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitted := jsonName(field)
+		if omitted {
+			continue
+		}
+
+		// Fields of embedded structs are fields of the struct
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := s.object(embedded)
+				for property, schema := range inner.Properties {
+					object.Properties[property] = schema
+				}
+				object.Required = append(object.Required, inner.Required...)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.schema(field.Type)
+		if applyRules(property, field.Tag.Get("validate"), t) {
+			object.Required = append(object.Required, name)
+		}
+		object.Properties[name] = property
+	}
+
+	return object
+}
+
+// applyRules adds the constraints of validate rules to a schema, reporting whether the value is required
+func applyRules(schema *Schema, tag string, parent reflect.Type) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			// The rest of the rules apply to the items
+			if schema.Items != nil {
+				applyRules(schema.Items, strings.Join(rules[i+1:], ","), parent)
+			}
+			return required
+		case "required":
+			required = true
+		case "min", "gte":
+			setMinimum(schema, param)
+		case "max", "lte":
+			setMaximum(schema, param)
+		case "len":
+			setMinimum(schema, param)
+			setMaximum(schema, param)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, enumValue(schema, value))
+			}
+			// JSON Schema checks null against the enum too
+			if t, ok := schema.Type.([]string); ok && slices.Contains(t, "null") {
+				schema.Enum = append(schema.Enum, nil)
+			}
+		case "email":
+			schema.Format = "email"
+		case "url", "uri":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "ip":
+			describe(schema, "An IPv4 or IPv6 address.")
+		case "numeric":
+			schema.Pattern = "^[0-9]+$"
+		case "password":
+			describe(schema, "Must meet the password policy.")
+		case "required_without":
+			describe(schema, fmt.Sprintf("Required if %s is not set.", fieldJSONName(parent, param)))
+		case "required_if":
+			field, value, _ := strings.Cut(param, " ")
+			describe(schema, fmt.Sprintf("Required if %s is %s.", fieldJSONName(parent, field), value))
+		}
+	}
+
+	return required
+}
+
+// setMinimum sets the minimum length, value or number of items of a schema
+func setMinimum(schema *Schema, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch baseType(schema) {
+	case "string":
+		schema.MinLength = integer(n)
+	case "array":
+		schema.MinItems = integer(n)
+	case "integer", "number":
+		schema.Minimum = float(n)
+	}
+}
+
+// setMaximum sets the maximum length, value or number of items of a schema
+func setMaximum(schema *Schema, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch baseType(schema) {
+	case "string":
+		schema.MaxLength = integer(n)
+	case "array":
+		schema.MaxItems = integer(n)
+	case "integer", "number":
+		schema.Maximum = float(n)
+	}
+}
+
+// enumValue converts a oneof value to the type of a schema
+func enumValue(schema *Schema, value string) interface{} {
+	switch baseType(schema) {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+// nullable allows null for a schema. References are left as they are, as nothing may be added to them.
+func nullable(schema *Schema) *Schema {
+	if t, ok := schema.Type.(string); ok {
+		schema.Type = []string{t, "null"}
+	}
+	return schema
+}
+
+// baseType returns the type of a schema that is not null
+func baseType(schema *Schema) string {
+	switch t := schema.Type.(type) {
+	case string:
+		return t
+	case []string:
+		return t[0]
+	default:
+		return ""
+	}
+}
+
+// describe appends a sentence to the description of a schema
+func describe(schema *Schema, sentence string) {
+	if schema.Description != "" {
+		schema.Description += " "
+	}
+	schema.Description += sentence
+}
+
+// jsonName returns the name of a field in JSON, and whether it is left out
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// fieldJSONName returns the JSON name of a field of a struct
+func fieldJSONName(t reflect.Type, fieldName string) string {
+	if field, exists := t.FieldByName(fieldName); exists {
+		if name, _ := jsonName(field); name != "" {
+			return name
+		}
+	}
+	return fieldName
+}
+
+// exported capitalizes a name
+func exported(name string) string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return name
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// integer returns a pointer to a whole number
+func integer(n float64) *int {
+	i := int(n)
+	return &i
+}
+
+// float returns a pointer to a number
+func float(n float64) *float64 {
+	return &n
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type base struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type node struct {
+	Name     string `json:"name"`
+	Children []node `json:"children"`
+}
+
+type createCommand struct {
+	base
+	UserID   uuid.UUID  `json:"-"`
+	Title    string     `json:"title" validate:"required,min=1,max=255"`
+	Priority string     `json:"priority" validate:"required,oneof=low medium high"`
+	Email    string     `json:"email" validate:"omitempty,email"`
+	Estimate *int       `json:"estimate" validate:"omitempty,min=0"`
+	Tags     []string   `json:"tags" validate:"max=5,dive,min=1,max=20"`
+	Due      *time.Time `json:"due"`
+	Tree     node       `json:"tree"`
+}
+
+func TestSchemasFor(t *testing.T) {
+	doc := NewDocument(Info{Title: "test", Version: "v1"})
+	schemas := NewSchemas(doc)
+
+	ref := schemas.For(createCommand{})
+	if ref.Ref != "#/components/schemas/createCommand" {
+		t.Fatalf("expected a reference to the component, got %+v", ref)
+	}
+
+	schema := doc.Components.Schemas["createCommand"]
+	if _, exists := schema.Properties["UserID"]; exists {
+		t.Error("expected fields tagged json:\"-\" to be left out")
+	}
+	if _, exists := schema.Properties["id"]; !exists {
+		t.Error("expected the fields of embedded structs")
+	}
+	if !slices.Equal(schema.Required, []string{"title", "priority"}) {
+		t.Errorf("expected title and priority to be required, got %v", schema.Required)
+	}
+
+	title := schema.Properties["title"]
+	if *title.MinLength != 1 || *title.MaxLength != 255 {
+		t.Errorf("expected the length of title to be limited, got %+v", title)
+	}
+	if priority := schema.Properties["priority"]; len(priority.Enum) != 3 {
+		t.Errorf("expected the values of priority, got %+v", priority)
+	}
+	if email := schema.Properties["email"]; email.Format != "email" {
+		t.Errorf("expected the email format, got %+v", email)
+	}
+	if estimate := schema.Properties["estimate"]; !slices.Equal(estimate.Type.([]string), []string{"integer", "null"}) || *estimate.Minimum != 0 {
+		t.Errorf("expected a nullable integer of at least 0, got %+v", estimate)
+	}
+
+	tags := schema.Properties["tags"]
+	if *tags.MaxItems != 5 || *tags.Items.MaxLength != 20 {
+		t.Errorf("expected rules after dive to apply to the items, got %+v", tags)
+	}
+	if due := schema.Properties["due"]; due.Format != "date-time" {
+		t.Errorf("expected a date-time, got %+v", due)
+	}
+
+	tree := doc.Components.Schemas["node"]
+	if tree == nil || tree.Properties["children"].Items.Ref != "#/components/schemas/node" {
+		t.Errorf("expected recursive types to reference themselves, got %+v", tree)
+	}
+}
+
+func TestSchemasParameters(t *testing.T) {
+	type listQuery struct {
+		Page   int     `json:"page" validate:"min=1"`
+		Status *string `json:"status"`
+	}
+
+	params := NewSchemas(NewDocument(Info{})).Parameters(listQuery{}, "query")
+	if len(params) != 2 || params[0].Name != "page" || params[1].Name != "status" {
+		t.Fatalf("expected the fields in order, got %+v", params)
+	}
+	if params[0].In != "query" || *params[0].Schema.Minimum != 1 {
+		t.Errorf("expected a query parameter of at least 1, got %+v", params[0])
+	}
+}
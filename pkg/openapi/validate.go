@@ -0,0 +1,386 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+// ErrInvalidJSON is returned for bodies that are not valid JSON
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+// ValidateParameters validates the path and query parameters of a request to an operation.
+// Query parameters the operation does not document are rejected.
+func (d *Document) ValidateParameters(op *Operation, path map[string]string, query url.Values) []validator.ValidationError {
+	var errs []validator.ValidationError
+
+	known := make(map[string]bool)
+	for _, param := range op.Parameters {
+		var raw string
+		var present bool
+		switch param.In {
+		case "path":
+			raw, present = path[param.Name]
+		case "query":
+			known[param.Name] = true
+			if values, exists := query[param.Name]; exists && len(values) > 0 {
+				raw, present = values[0], true
+			}
+		default:
+			continue
+		}
+
+		if !present || raw == "" {
+			if param.Required {
+				errs = append(errs, fieldError(param.Name, "is required"))
+			}
+			continue
+		}
+
+		value, err := d.parseParameter(param.Schema, raw)
+		if err != nil {
+			errs = append(errs, fieldError(param.Name, typeMessage(d.resolve(param.Schema))))
+			continue
+		}
+		errs = append(errs, d.ValidateValue(param.Schema, param.Name, value)...)
+	}
+
+	for _, name := range sortedNames(query) {
+		if !known[name] {
+			errs = append(errs, fieldError(name, "is not a known parameter"))
+		}
+	}
+
+	return errs
+}
+
+// ValidateBody decodes a JSON request body and validates it against the schema of the operation.
+// An empty body is validated as an empty object. It returns ErrInvalidJSON if the body cannot be decoded.
+func (d *Document) ValidateBody(op *Operation, body []byte) ([]validator.ValidationError, error) {
+	if op.RequestBody == nil {
+		return nil, nil
+	}
+	media, exists := op.RequestBody.Content["application/json"]
+	if !exists || media.Schema == nil {
+		return nil, nil
+	}
+
+	var value interface{} = map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf("%w: unexpected data after the body", ErrInvalidJSON)
+		}
+	}
+
+	return d.ValidateValue(media.Schema, "", value), nil
+}
+
+// ValidateValue validates a value decoded from JSON, with numbers as json.Number, against a schema.
+// Objects with properties allow no other fields, unless the schema has additional properties.
+// Errors name fields by their path from field, such as "columns[0].name".
+func (d *Document) ValidateValue(schema *Schema, field string, value interface{}) []validator.ValidationError {
+	schema = d.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	var errs []validator.ValidationError
+	for _, part := range schema.AllOf {
+		errs = append(errs, d.ValidateValue(part, field, value)...)
+	}
+
+	types := schemaTypes(schema)
+	if value == nil {
+		if len(types) > 0 && !slices.Contains(types, "null") {
+			errs = append(errs, fieldError(field, typeMessage(schema)))
+		}
+		return errs
+	}
+	if len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return hasType(value, t) }) {
+		return append(errs, fieldError(field, typeMessage(schema)))
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(allowed interface{}) bool { return enumEqual(allowed, value) }) {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must be one of [%s]", enumList(schema.Enum))))
+	}
+
+	switch v := value.(type) {
+	case string:
+		errs = append(errs, validateString(schema, field, v)...)
+	case json.Number:
+		errs = append(errs, validateNumber(schema, field, v)...)
+	case []interface{}:
+		errs = append(errs, d.validateArray(schema, field, v)...)
+	case map[string]interface{}:
+		errs = append(errs, d.validateObject(schema, field, v)...)
+	}
+
+	return errs
+}
+
+// validateObject validates the properties of an object
+func (d *Document) validateObject(schema *Schema, field string, object map[string]interface{}) []validator.ValidationError {
+	var errs []validator.ValidationError
+
+	for _, name := range schema.Required {
+		if _, exists := object[name]; !exists {
+			errs = append(errs, fieldError(join(field, name), "is required"))
+		}
+	}
+
+	for _, name := range sortedNames(object) {
+		property, exists := schema.Properties[name]
+		switch {
+		case exists:
+			errs = append(errs, d.ValidateValue(property, join(field, name), object[name])...)
+		case schema.AdditionalProperties != nil:
+			errs = append(errs, d.ValidateValue(schema.AdditionalProperties, join(field, name), object[name])...)
+		case schema.Properties != nil:
+			errs = append(errs, fieldError(join(field, name), "is not a known field"))
+		}
+	}
+
+	return errs
+}
+
+// validateArray validates the number of items of an array and each item
+func (d *Document) validateArray(schema *Schema, field string, items []interface{}) []validator.ValidationError {
+	var errs []validator.ValidationError
+
+	if schema.MinItems != nil && len(items) < *schema.MinItems {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must contain at least %d items", *schema.MinItems)))
+	}
+	if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must contain at most %d items", *schema.MaxItems)))
+	}
+
+	if schema.Items != nil {
+		for i, item := range items {
+			errs = append(errs, d.ValidateValue(schema.Items, fmt.Sprintf("%s[%d]", field, i), item)...)
+		}
+	}
+
+	return errs
+}
+
+// validateString validates the length, format and pattern of a string
+func validateString(schema *Schema, field, s string) []validator.ValidationError {
+	var errs []validator.ValidationError
+
+	length := utf8.RuneCountInString(s)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must be at least %d characters long", *schema.MinLength)))
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must be at most %d characters long", *schema.MaxLength)))
+	}
+
+	if message := formatMessage(schema.Format, s); message != "" {
+		errs = append(errs, fieldError(field, message))
+	}
+
+	if schema.Pattern != "" {
+		if pattern, err := regexp.Compile(schema.Pattern); err == nil && !pattern.MatchString(s) {
+			errs = append(errs, fieldError(field, "has an invalid format"))
+		}
+	}
+
+	return errs
+}
+
+// validateNumber validates the range of a number
+func validateNumber(schema *Schema, field string, n json.Number) []validator.ValidationError {
+	var errs []validator.ValidationError
+
+	value, err := n.Float64()
+	if err != nil {
+		return []validator.ValidationError{fieldError(field, typeMessage(schema))}
+	}
+
+	if schema.Minimum != nil && value < *schema.Minimum {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must be %s or greater", formatNumber(*schema.Minimum))))
+	}
+	if schema.Maximum != nil && value > *schema.Maximum {
+		errs = append(errs, fieldError(field, fmt.Sprintf("must be %s or less", formatNumber(*schema.Maximum))))
+	}
+
+	return errs
+}
+
+// formatMessage checks a string against a format, returning why it does not match
+func formatMessage(format, s string) string {
+	switch format {
+	case "uuid":
+		if _, err := uuid.Parse(s); err != nil {
+			return "must be a valid UUID"
+		}
+	case "email":
+		if _, err := mail.ParseAddress(s); err != nil {
+			return "must be a valid email address"
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be a date and time in RFC 3339 format"
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return "must be a date in YYYY-MM-DD format"
+		}
+	case "uri":
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" {
+			return "must be a valid URL"
+		}
+	}
+	return ""
+}
+
+// parseParameter converts the raw value of a parameter to the JSON type of its schema
+func (d *Document) parseParameter(schema *Schema, raw string) (interface{}, error) {
+	switch baseType(d.resolve(schema)) {
+	case "integer":
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, err
+		}
+		return json.Number(raw), nil
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, err
+		}
+		return json.Number(raw), nil
+	case "boolean":
+		return strconv.ParseBool(raw)
+	default:
+		return raw, nil
+	}
+}
+
+// resolve follows a reference to a component schema
+func (d *Document) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// schemaTypes returns the types a schema allows, or none if it allows any
+func schemaTypes(schema *Schema) []string {
+	switch t := schema.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	default:
+		return nil
+	}
+}
+
+// hasType reports whether a value decoded from JSON has a JSON Schema type
+func hasType(value interface{}, t string) bool {
+	switch v := value.(type) {
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case json.Number:
+		if t == "integer" {
+			_, err := strconv.ParseInt(v.String(), 10, 64)
+			return err == nil
+		}
+		return t == "number"
+	case []interface{}:
+		return t == "array"
+	case map[string]interface{}:
+		return t == "object"
+	default:
+		return false
+	}
+}
+
+// typeMessage says which type a value must have
+func typeMessage(schema *Schema) string {
+	switch baseType(schema) {
+	case "string":
+		return "must be a string"
+	case "integer":
+		return "must be an integer"
+	case "number":
+		return "must be a number"
+	case "boolean":
+		return "must be true or false"
+	case "array":
+		return "must be an array"
+	case "object":
+		return "must be an object"
+	default:
+		return "has an invalid value"
+	}
+}
+
+// enumEqual compares an enum value with a value decoded from JSON
+func enumEqual(allowed, value interface{}) bool {
+	if n, ok := value.(json.Number); ok {
+		return fmt.Sprint(allowed) == n.String()
+	}
+	return allowed == value
+}
+
+// enumList lists the values of an enum, leaving out null
+func enumList(enum []interface{}) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		if value != nil {
+			values = append(values, fmt.Sprint(value))
+		}
+	}
+	return strings.Join(values, " ")
+}
+
+// formatNumber formats a limit without trailing zeros
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// fieldError creates a validation error for a field, with the field named in the message
+func fieldError(field, message string) validator.ValidationError {
+	name := field
+	if name == "" {
+		name = "body"
+	}
+	return validator.ValidationError{Field: name, Message: name + " " + message}
+}
+
+// join appends a property name to the path of a field
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+// sortedNames returns the keys of a map in order
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
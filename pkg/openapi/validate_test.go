@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+func newTestDocument() (*Document, *Operation) {
+	doc := NewDocument(Info{Title: "test", Version: "v1"})
+	schemas := NewSchemas(doc)
+
+	op := &Operation{
+		Parameters: append([]Parameter{
+			{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string", Format: "uuid"}},
+		}, schemas.Parameters(struct {
+			Page   int     `json:"page" validate:"min=1"`
+			Status *string `json:"status" validate:"omitempty,oneof=pending completed"`
+			Done   bool    `json:"done"`
+		}{}, "query")...),
+		RequestBody: &RequestBody{Required: true, Content: map[string]*MediaType{
+			"application/json": {Schema: schemas.For(createCommand{})},
+		}},
+	}
+	doc.AddOperation("PUT", "/todos/{id}", op)
+	return doc, op
+}
+
+// messages maps validation errors by field
+func messages(errs []validator.ValidationError) map[string]string {
+	m := make(map[string]string, len(errs))
+	for _, err := range errs {
+		m[err.Field] = err.Message
+	}
+	return m
+}
+
+func TestValidateParameters(t *testing.T) {
+	doc, op := newTestDocument()
+	id := map[string]string{"id": "7d444840-9dc0-11d1-b245-5ffdce74fad2"}
+
+	tests := []struct {
+		name  string
+		path  map[string]string
+		query string
+		want  map[string]string
+	}{
+		{name: "valid", path: id, query: "page=2&status=pending&done=true", want: map[string]string{}},
+		{name: "no query", path: id, want: map[string]string{}},
+		{name: "invalid path parameter", path: map[string]string{"id": "abc"}, want: map[string]string{"id": "id must be a valid UUID"}},
+		{name: "unknown enum value", path: id, query: "status=garbage", want: map[string]string{"status": "status must be one of [pending completed]"}},
+		{name: "below minimum", path: id, query: "page=0", want: map[string]string{"page": "page must be 1 or greater"}},
+		{name: "wrong type", path: id, query: "page=two&done=maybe", want: map[string]string{"page": "page must be an integer", "done": "done must be true or false"}},
+		{name: "unknown parameter", path: id, query: "sort=title", want: map[string]string{"sort": "sort is not a known parameter"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			got := messages(doc.ValidateParameters(op, tt.path, query))
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for field, message := range tt.want {
+				if got[field] != message {
+					t.Errorf("expected %s: %q, got %q", field, message, got[field])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	doc, op := newTestDocument()
+
+	tests := []struct {
+		name string
+		body string
+		want map[string]string
+	}{
+		{name: "valid", body: `{"title":"Buy milk","priority":"low","tags":["home"],"estimate":null,"tree":{"name":"a","children":[]}}`, want: map[string]string{}},
+		{name: "empty body", body: ``, want: map[string]string{"title": "title is required", "priority": "priority is required"}},
+		{name: "unknown field", body: `{"title":"Buy milk","priority":"low","color":"red"}`, want: map[string]string{"color": "color is not a known field"}},
+		{name: "bad enum", body: `{"title":"Buy milk","priority":"urgent"}`, want: map[string]string{"priority": "priority must be one of [low medium high]"}},
+		{name: "wrong types", body: `{"title":1,"priority":"low","estimate":1.5}`, want: map[string]string{"title": "title must be a string", "estimate": "estimate must be an integer"}},
+		{name: "nested errors", body: `{"title":"Buy milk","priority":"low","tags":["ok",""],"tree":{"name":"a","children":[{"size":1}]}}`, want: map[string]string{"tags[1]": "tags[1] must be at least 1 characters long", "tree.children[0].size": "tree.children[0].size is not a known field"}},
+		{name: "not an object", body: `[]`, want: map[string]string{"body": "body must be an object"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := doc.ValidateBody(op, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := messages(errs)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for field, message := range tt.want {
+				if got[field] != message {
+					t.Errorf("expected %s: %q, got %q", field, message, got[field])
+				}
+			}
+		})
+	}
+
+	if _, err := doc.ValidateBody(op, []byte(`{"title":`)); !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("expected ErrInvalidJSON, got %v", err)
+	}
+}
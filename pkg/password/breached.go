@@ -0,0 +1,193 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// prefixLength is the number of leading hex characters of a SHA-1 hash a range is looked up by
+	prefixLength = 5
+
+	// suffixLength is the number of hex characters of a SHA-1 hash left after the prefix
+	suffixLength = 40 - prefixLength
+)
+
+// bundledBreached lists the SHA-1 hashes of the most common breached passwords
+//
+//go:embed breached.txt
+var bundledBreached string
+
+// RangeSource looks up the hashes of breached passwords by the first five characters of their SHA-1 hash.
+// Only the prefix of a password's hash is handed to the source, so the source never learns the password,
+// as with the k-anonymity range API of Have I Been Pwned.
+type RangeSource interface {
+	// Range returns the uppercase hex suffixes of the breached hashes starting with the prefix
+	Range(prefix string) ([]string, error)
+}
+
+// BreachChecker checks passwords against a list of breached passwords
+type BreachChecker struct {
+	source RangeSource
+}
+
+// NewBreachChecker creates a new breach checker that looks up hash ranges in the source
+func NewBreachChecker(source RangeSource) *BreachChecker {
+	return &BreachChecker{source: source}
+}
+
+// IsBreached checks if a password is in the breached list
+func (c *BreachChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := c.source.Range(hash[:prefixLength])
+	if err != nil {
+		return false, err
+	}
+
+	found := 0
+	for _, suffix := range suffixes {
+		found |= subtle.ConstantTimeCompare([]byte(suffix), []byte(hash[prefixLength:]))
+	}
+	return found == 1, nil
+}
+
+// MemorySource is a RangeSource holding a list of breached hashes in memory
+type MemorySource struct {
+	ranges map[string][]string
+}
+
+// Range returns the suffixes of the breached hashes starting with the prefix
+func (s *MemorySource) Range(prefix string) ([]string, error) {
+	return s.ranges[strings.ToUpper(prefix)], nil
+}
+
+// BundledSource returns a source of the common breached passwords bundled with the package
+func BundledSource() *MemorySource {
+	// The bundled list is checked by the tests, so it always parses
+	source, err := ReadSource(strings.NewReader(bundledBreached))
+	if err != nil {
+		panic(err)
+	}
+	return source
+}
+
+// ReadSource reads a list of breached hashes with one uppercase or lowercase hex SHA-1 hash per line,
+// optionally followed by a colon and a count as in the Have I Been Pwned downloads. Empty lines
+// and lines starting with # are skipped.
+func ReadSource(r io.Reader) (*MemorySource, error) {
+	source := &MemorySource{ranges: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash := strings.ToUpper(strings.SplitN(text, ":", 2)[0])
+		if !isHexHash(hash) {
+			return nil, fmt.Errorf("line %d: not a SHA-1 hash", line)
+		}
+
+		prefix := hash[:prefixLength]
+		source.ranges[prefix] = append(source.ranges[prefix], hash[prefixLength:])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// DirSource is a RangeSource reading ranges on demand from a directory with one file per prefix,
+// named after the prefix, in the format of the Have I Been Pwned range API: one SUFFIX:COUNT per line.
+// This is the layout of the official range downloader, so the full list can be used offline.
+type DirSource struct {
+	dir string
+}
+
+// NewDirSource creates a new source reading ranges from a directory
+func NewDirSource(dir string) *DirSource {
+	return &DirSource{dir: dir}
+}
+
+// Range returns the suffixes in the file of the prefix; a missing file is an empty range
+func (s *DirSource) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+	if len(prefix) != prefixLength || !isHex(prefix) {
+		return nil, errors.New("invalid hash prefix")
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, prefix))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var suffixes []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		suffix := strings.ToUpper(strings.TrimSpace(strings.SplitN(scanner.Text(), ":", 2)[0]))
+		if len(suffix) == suffixLength {
+			suffixes = append(suffixes, suffix)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return suffixes, nil
+}
+
+// LoadSource loads breached hashes from a path: a directory of range files, or a file of hashes
+func LoadSource(path string) (RangeSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return NewDirSource(path), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadSource(file)
+}
+
+// isHexHash checks if s is a hex encoded SHA-1 hash
+func isHexHash(s string) bool {
+	return len(s) == 40 && isHex(s)
+}
+
+// isHex checks if s only has uppercase hex digits
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
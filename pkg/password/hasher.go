@@ -0,0 +1,199 @@
+// Package password hashes passwords and checks them against a password policy.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Supported hashing algorithms
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+const (
+	// argon2SaltLength is the number of random bytes in an argon2id salt
+	argon2SaltLength = 16
+
+	// argon2KeyLength is the number of bytes of an argon2id hash
+	argon2KeyLength = 32
+
+	// argon2Prefix starts every argon2id hash in the PHC string format
+	argon2Prefix = "$argon2id$"
+
+	// dummyPassword is hashed once to check passwords against when there is no user
+	dummyPassword = "dummy password for timing"
+)
+
+// ErrUnknownAlgorithm is returned for hashing algorithms other than bcrypt and argon2id
+var ErrUnknownAlgorithm = errors.New("unknown password hashing algorithm")
+
+// Argon2Params are the cost parameters of argon2id hashes
+type Argon2Params struct {
+	Memory      uint32 // in KiB
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// HasherOptions configures how new password hashes are made
+type HasherOptions struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// DefaultHasherOptions returns bcrypt with its default cost, and the argon2id parameters
+// recommended by OWASP for when argon2id is chosen
+func DefaultHasherOptions() HasherOptions {
+	return HasherOptions{
+		Algorithm:  AlgorithmBcrypt,
+		BcryptCost: bcrypt.DefaultCost,
+		Argon2: Argon2Params{
+			Memory:      64 * 1024,
+			Iterations:  3,
+			Parallelism: 2,
+		},
+	}
+}
+
+// Hasher hashes passwords with the configured algorithm and verifies hashes of any supported algorithm,
+// so the algorithm or its cost can change while old hashes keep working until they are rehashed
+type Hasher struct {
+	opts HasherOptions
+
+	dummyOnce sync.Once
+	dummyHash string
+}
+
+// NewHasher creates a new hasher
+func NewHasher(opts HasherOptions) (*Hasher, error) {
+	switch opts.Algorithm {
+	case AlgorithmBcrypt:
+		if opts.BcryptCost < bcrypt.MinCost || opts.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case AlgorithmArgon2id:
+		if opts.Argon2.Memory < 8*uint32(opts.Argon2.Parallelism) || opts.Argon2.Iterations < 1 || opts.Argon2.Parallelism < 1 {
+			return nil, errors.New("argon2id needs at least one iteration and thread, and 8 KiB of memory per thread")
+		}
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+
+	return &Hasher{opts: opts}, nil
+}
+
+// Hash hashes a password with the configured algorithm
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.opts.Algorithm == AlgorithmArgon2id {
+		return hashArgon2(password, h.opts.Argon2)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.opts.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify checks if a password matches a bcrypt or argon2id hash
+func (h *Hasher) Verify(hash, password string) bool {
+	if strings.HasPrefix(hash, argon2Prefix) {
+		return verifyArgon2(hash, password)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// NeedsRehash checks if a hash was made with another algorithm or cost than the configured one
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if h.opts.Algorithm == AlgorithmArgon2id {
+		params, _, _, err := decodeArgon2(hash)
+		return err != nil || params != h.opts.Argon2
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.opts.BcryptCost
+}
+
+// VerifyDummy spends the time of verifying a password without a hash to verify it against,
+// so a login with an unknown email takes as long as one with a wrong password
+func (h *Hasher) VerifyDummy(password string) {
+	h.dummyOnce.Do(func() {
+		// Hashing only fails if the system has no randomness; the dummy check is then skipped
+		h.dummyHash, _ = h.Hash(dummyPassword)
+	})
+	if h.dummyHash != "" {
+		h.Verify(h.dummyHash, password)
+	}
+}
+
+// hashArgon2 hashes a password with argon2id in the PHC string format
+func hashArgon2(password string, params Argon2Params) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyArgon2 checks a password against an argon2id hash in constant time
+func verifyArgon2(hash, password string) bool {
+	params, salt, key, err := decodeArgon2(hash)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// decodeArgon2 parses an argon2id hash in the PHC string format
+func decodeArgon2(hash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errors.New("unsupported argon2id version")
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, errors.New("invalid argon2id parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errors.New("invalid argon2id salt")
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errors.New("invalid argon2id hash")
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,224 @@
+package password
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2 keeps argon2id tests quick
+var fastArgon2 = Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func newTestHasher(t *testing.T, opts HasherOptions) *Hasher {
+	t.Helper()
+	hasher, err := NewHasher(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return hasher
+}
+
+func TestHasherHashAndVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		opts HasherOptions
+	}{
+		{"bcrypt", HasherOptions{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost}},
+		{"argon2id", HasherOptions{Algorithm: AlgorithmArgon2id, Argon2: fastArgon2}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			hasher := newTestHasher(t, tt.opts)
+
+			hash, err := hasher.Hash("correct horse")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !hasher.Verify(hash, "correct horse") {
+				t.Error("expected the password to match its hash")
+			}
+			if hasher.Verify(hash, "wrong horse") {
+				t.Error("expected another password not to match")
+			}
+			if hasher.NeedsRehash(hash) {
+				t.Error("expected a fresh hash not to need a rehash")
+			}
+
+			other, _ := hasher.Hash("correct horse")
+			if other == hash {
+				t.Error("expected hashes to be salted")
+			}
+		})
+	}
+}
+
+func TestHasherNeedsRehash(t *testing.T) {
+	bcryptHasher := newTestHasher(t, HasherOptions{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	strongerBcrypt := newTestHasher(t, HasherOptions{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+	argon2Hasher := newTestHasher(t, HasherOptions{Algorithm: AlgorithmArgon2id, Argon2: fastArgon2})
+	strongerArgon2 := newTestHasher(t, HasherOptions{Algorithm: AlgorithmArgon2id, Argon2: Argon2Params{Memory: 128, Iterations: 1, Parallelism: 1}})
+
+	bcryptHash, _ := bcryptHasher.Hash("correct horse")
+	argon2Hash, _ := argon2Hasher.Hash("correct horse")
+
+	if !strongerBcrypt.NeedsRehash(bcryptHash) {
+		t.Error("expected a bcrypt hash with a lower cost to need a rehash")
+	}
+	if !argon2Hasher.NeedsRehash(bcryptHash) {
+		t.Error("expected a bcrypt hash to need a rehash when argon2id is configured")
+	}
+	if !bcryptHasher.NeedsRehash(argon2Hash) {
+		t.Error("expected an argon2id hash to need a rehash when bcrypt is configured")
+	}
+	if !strongerArgon2.NeedsRehash(argon2Hash) {
+		t.Error("expected an argon2id hash with other parameters to need a rehash")
+	}
+
+	// Hashes of either algorithm keep verifying after a switch
+	if !argon2Hasher.Verify(bcryptHash, "correct horse") || !bcryptHasher.Verify(argon2Hash, "correct horse") {
+		t.Error("expected hashes of the previous algorithm to verify")
+	}
+}
+
+func TestNewHasherRejectsInvalidOptions(t *testing.T) {
+	tests := []HasherOptions{
+		{Algorithm: "md5"},
+		{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MaxCost + 1},
+		{Algorithm: AlgorithmArgon2id, Argon2: Argon2Params{Memory: 64, Iterations: 0, Parallelism: 1}},
+		{Algorithm: AlgorithmArgon2id, Argon2: Argon2Params{Memory: 8, Iterations: 1, Parallelism: 2}},
+	}
+
+	for _, opts := range tests {
+		if _, err := NewHasher(opts); err == nil {
+			t.Errorf("expected options %+v to be rejected", opts)
+		}
+	}
+}
+
+func TestVerifyArgon2RejectsMalformedHashes(t *testing.T) {
+	hasher := newTestHasher(t, HasherOptions{Algorithm: AlgorithmArgon2id, Argon2: fastArgon2})
+
+	for _, hash := range []string{
+		"$argon2id$",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$",
+		"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdA$aGFzaA",
+	} {
+		if hasher.Verify(hash, "correct horse") {
+			t.Errorf("expected %q not to verify", hash)
+		}
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	policy := &Policy{
+		MinLength:           10,
+		MinCharacterClasses: 3,
+		RejectPersonalInfo:  true,
+		Breached:            NewBreachChecker(BundledSource()),
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     string
+	}{
+		{"valid", "Tr0ubadour&3", ""},
+		{"too short", "Ab1!", "must be at least 10 characters long"},
+		{"too long", strings.Repeat("Ab1!", 19), "must be at most 72 bytes long"},
+		{"too few classes", "onlylowercase", "must mix at least 3 of lowercase letters, uppercase letters, digits and symbols"},
+		{"email", "Jane.doe@example.com1", "must not contain your email or name"},
+		{"email local part", "xJANE.DOE99x", "must not contain your email or name"},
+		{"name", "MyNameIsSmith1", "must not contain your email or name"},
+		{"breached", "Password123", "has appeared in a data breach, choose a different one"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password, "jane.doe@example.com", "Jo Smith")
+
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("expected the password to pass, got %v", err)
+				}
+				return
+			}
+
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("expected a policy error, got %v", err)
+			}
+			if policyErr.Message != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, policyErr.Message)
+			}
+		})
+	}
+}
+
+func TestPolicyIgnoresShortPersonalValues(t *testing.T) {
+	policy := &Policy{MinLength: 8, RejectPersonalInfo: true}
+
+	// "Jo" is too short to ban without ruling out many passwords
+	if err := policy.Check("jokingly-long", "Jo"); err != nil {
+		t.Errorf("expected the password to pass, got %v", err)
+	}
+}
+
+func TestBundledSourceParses(t *testing.T) {
+	source, err := ReadSource(strings.NewReader(bundledBreached))
+	if err != nil {
+		t.Fatalf("expected the bundled list to parse: %v", err)
+	}
+	if len(source.ranges) == 0 {
+		t.Fatal("expected the bundled list not to be empty")
+	}
+
+	checker := NewBreachChecker(source)
+	for _, password := range []string{"password", "123456", "qwerty123"} {
+		if breached, _ := checker.IsBreached(password); !breached {
+			t.Errorf("expected %q to be breached", password)
+		}
+	}
+	if breached, _ := checker.IsBreached("Tr0ubadour&3"); breached {
+		t.Error("expected an uncommon password not to be breached")
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	if err := os.WriteFile(filepath.Join(dir, "5BAA6"), []byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n0018A45C4D1DEF81644B54AB7F969B88D65:3\r\n"), 0o600); err != nil {
+		t.Fatalf("failed to write range: %v", err)
+	}
+
+	source, err := LoadSource(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checker := NewBreachChecker(source)
+
+	if breached, err := checker.IsBreached("password"); err != nil || !breached {
+		t.Errorf("expected the password to be found in its range, got %v %v", breached, err)
+	}
+	if breached, err := checker.IsBreached("Tr0ubadour&3"); err != nil || breached {
+		t.Errorf("expected a missing range to be empty, got %v %v", breached, err)
+	}
+	if _, err := source.Range("../etc"); err == nil {
+		t.Error("expected an invalid prefix to be rejected")
+	}
+}
+
+func TestReadSourceRejectsInvalidLines(t *testing.T) {
+	if _, err := ReadSource(strings.NewReader("# comment\n\nnot a hash\n")); err == nil {
+		t.Error("expected an invalid line to be rejected")
+	}
+}
@@ -0,0 +1,118 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxBytes is the longest password accepted, the most bcrypt can hash
+	MaxBytes = 72
+
+	// minPersonalLength is the length personal values need before they are banned from passwords,
+	// so short names and email parts do not rule out too many passwords
+	minPersonalLength = 3
+)
+
+// PolicyError describes the rule a password breaks
+type PolicyError struct {
+	// Message completes a sentence about the password, e.g. "must be at least 8 characters long"
+	Message string
+}
+
+// Error implements the error interface
+func (e *PolicyError) Error() string {
+	return "password " + e.Message
+}
+
+// Policy is the set of rules passwords have to follow
+type Policy struct {
+	// MinLength is the least number of characters in a password
+	MinLength int
+
+	// MinCharacterClasses is the least number of character classes, out of lowercase letters,
+	// uppercase letters, digits and symbols, a password has to mix
+	MinCharacterClasses int
+
+	// RejectPersonalInfo bans passwords containing the user's email or name
+	RejectPersonalInfo bool
+
+	// Breached bans passwords found in data breaches, if set
+	Breached *BreachChecker
+}
+
+// Check returns a PolicyError for the first rule a password breaks. Personal values, such as
+// the user's email and name, are banned from the password if the policy rejects personal info.
+// Other errors come from looking the password up in the breached list.
+func (p *Policy) Check(password string, personal ...string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return &PolicyError{Message: fmt.Sprintf("must be at least %d characters long", p.MinLength)}
+	}
+
+	if len(password) > MaxBytes {
+		return &PolicyError{Message: fmt.Sprintf("must be at most %d bytes long", MaxBytes)}
+	}
+
+	if characterClasses(password) < p.MinCharacterClasses {
+		return &PolicyError{Message: fmt.Sprintf("must mix at least %d of lowercase letters, uppercase letters, digits and symbols", p.MinCharacterClasses)}
+	}
+
+	if p.RejectPersonalInfo && containsPersonalInfo(password, personal) {
+		return &PolicyError{Message: "must not contain your email or name"}
+	}
+
+	if p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			return &PolicyError{Message: "has appeared in a data breach, choose a different one"}
+		}
+	}
+
+	return nil
+}
+
+// characterClasses counts the character classes in a password
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// containsPersonalInfo checks if a password contains any of the personal values. Emails are also
+// checked by their local part and names by each of their words.
+func containsPersonalInfo(password string, personal []string) bool {
+	password = strings.ToLower(password)
+
+	for _, value := range personal {
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		parts := strings.Fields(value)
+		if local, _, ok := strings.Cut(value, "@"); ok {
+			parts = append(parts, local)
+		}
+
+		for _, part := range parts {
+			if utf8.RuneCountInString(part) >= minPersonalLength && strings.Contains(password, part) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops the buckets of idle clients
+const sweepInterval = time.Minute
+
+// bucket is a client's token bucket
+type bucket struct {
+	tokens  float64
+	last    time.Time
+	expires time.Time
+}
+
+// MemoryStore keeps buckets in memory, so each instance of the API limits its clients on its own
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take takes a request from the key's bucket of the policy
+func (s *MemoryStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	id := policy.Name + ":" + key
+	b, exists := s.buckets[id]
+	if !exists {
+		b = &bucket{tokens: float64(policy.Limit), last: now}
+		s.buckets[id] = b
+	}
+
+	tokens, result := take(policy, b.tokens, b.last, now)
+	b.tokens = tokens
+	b.last = now
+	// A bucket that is full again is the same as a new one, so it can be dropped
+	b.expires = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// Close releases the buckets of the store
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buckets = make(map[string]*bucket)
+	return nil
+}
+
+// sweep drops full buckets, at most once per sweep interval
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for id, b := range s.buckets {
+		if !now.Before(b.expires) {
+			delete(s.buckets, id)
+		}
+	}
+}
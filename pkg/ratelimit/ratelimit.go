@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Policy is a token bucket holding up to Limit requests, refilled evenly over Window
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// NewPolicy creates a new named policy
+func NewPolicy(name string, limit int, window time.Duration) Policy {
+	return Policy{
+		Name:   name,
+		Limit:  limit,
+		Window: window,
+	}
+}
+
+// rate returns the number of requests refilled per second
+func (p Policy) rate() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+// Result is the outcome of taking a request from a bucket
+type Result struct {
+	Allowed bool
+	Limit   int
+
+	// Remaining is the number of requests left in the bucket
+	Remaining int
+
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+
+	// RetryAfter is how long until the next request is allowed, if this one was not
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets of rate limited clients
+type Store interface {
+	// Take takes a request from the key's bucket of the policy
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+
+	// Close releases the resources of the store
+	Close() error
+}
+
+// NewStore creates the store selected by the driver: memory, or redis at redisURL
+func NewStore(driver, redisURL string) (Store, error) {
+	switch driver {
+	case "memory", "":
+		return NewMemoryStore(), nil
+	case "redis":
+		return NewRedisStoreFromURL(redisURL)
+	default:
+		return nil, fmt.Errorf("unknown rate limit store: %s", driver)
+	}
+}
+
+// take takes a request from a bucket that held tokens at last, returning the tokens left at now
+func take(policy Policy, tokens float64, last, now time.Time) (float64, Result) {
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens = math.Min(float64(policy.Limit), tokens+elapsed*policy.rate())
+	}
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return tokens, newResult(policy, tokens, allowed)
+}
+
+// newResult describes a bucket of the policy left with the given tokens
+func newResult(policy Policy, tokens float64, allowed bool) Result {
+	rate := policy.rate()
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     policy.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(policy.Limit) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	return result
+}
+
+// seconds converts a number of seconds to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
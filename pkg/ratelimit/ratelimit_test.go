@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestTake(t *testing.T) {
+	policy := NewPolicy("test", 2, 10*time.Second)
+	now := time.Now()
+
+	tokens, result := take(policy, 2, now, now)
+	if !result.Allowed || result.Remaining != 1 || result.Reset != 5*time.Second {
+		t.Errorf("unexpected first result: %+v", result)
+	}
+
+	tokens, result = take(policy, tokens, now, now)
+	if !result.Allowed || result.Remaining != 0 || result.Reset != 10*time.Second {
+		t.Errorf("unexpected second result: %+v", result)
+	}
+
+	tokens, result = take(policy, tokens, now, now)
+	if result.Allowed || result.Remaining != 0 || result.RetryAfter != 5*time.Second {
+		t.Errorf("expected the third request to be denied for 5s, got %+v", result)
+	}
+
+	// Half of the window refills one request
+	_, result = take(policy, tokens, now, now.Add(5*time.Second))
+	if !result.Allowed || result.Remaining != 0 {
+		t.Errorf("expected a request to be refilled, got %+v", result)
+	}
+
+	// The bucket does not hold more than the limit
+	_, result = take(policy, 0, now, now.Add(time.Hour))
+	if !result.Allowed || result.Remaining != 1 {
+		t.Errorf("expected a full bucket, got %+v", result)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	testStore(t, store, func(d time.Duration) { now = now.Add(d) })
+
+	// Full buckets are swept
+	now = now.Add(time.Hour)
+	if _, err := store.Take(context.Background(), "other", NewPolicy("test", 1, time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.buckets) != 1 {
+		t.Errorf("expected idle buckets to be swept, got %d buckets", len(store.buckets))
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	now := time.Now()
+	server.SetTime(now)
+
+	store := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	defer store.Close()
+
+	testStore(t, store, func(d time.Duration) {
+		now = now.Add(d)
+		server.SetTime(now)
+	})
+
+	if ttl := server.TTL(keyPrefix + "test:client"); ttl <= 0 {
+		t.Errorf("expected the bucket to expire, got ttl %v", ttl)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := NewStore("memory", ""); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewStore("redis", "not a url"); err == nil {
+		t.Error("expected an error for an invalid redis url")
+	}
+	if _, err := NewStore("memcached", ""); err == nil {
+		t.Error("expected an error for an unknown store")
+	}
+}
+
+// testStore checks a store limits clients to their policy, advancing its clock with advance
+func testStore(t *testing.T, store Store, advance func(time.Duration)) {
+	t.Helper()
+
+	ctx := context.Background()
+	policy := NewPolicy("test", 3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		result, err := store.Take(ctx, "client", policy)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Allowed || result.Remaining != 2-i {
+			t.Fatalf("expected request %d to be allowed, got %+v", i+1, result)
+		}
+	}
+
+	result, err := store.Take(ctx, "client", policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Allowed || result.RetryAfter <= 0 || result.RetryAfter > 20*time.Second {
+		t.Errorf("expected the fourth request to be denied, got %+v", result)
+	}
+
+	// Other clients and policies have their own buckets
+	if result, _ := store.Take(ctx, "other", policy); !result.Allowed {
+		t.Error("expected another client to be allowed")
+	}
+	if result, _ := store.Take(ctx, "client", NewPolicy("strict", 1, time.Minute)); !result.Allowed {
+		t.Error("expected another policy to be allowed")
+	}
+
+	advance(20 * time.Second)
+	if result, _ := store.Take(ctx, "client", policy); !result.Allowed {
+		t.Errorf("expected a request to be refilled, got %+v", result)
+	}
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix prefixes the Redis keys of buckets
+const keyPrefix = "ratelimit:"
+
+// takeScript takes a request from a bucket in one step, so concurrent requests of a client on
+// several instances of the API cannot take the same token. It uses the Redis clock, so the
+// instances do not need to agree on the time. A bucket expires once it is full again.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(bucket[1]) or capacity
+local last = tonumber(bucket[2]) or now
+
+if now > last then
+	tokens = math.min(capacity, tokens + (now - last) * rate)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1)
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps buckets in Redis, so all instances of the API share the limits of a client
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a new RedisStore
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+// NewRedisStoreFromURL creates a new RedisStore connected to the Redis server at the URL
+func NewRedisStoreFromURL(url string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	return NewRedisStore(redis.NewClient(opts)), nil
+}
+
+// Take takes a request from the key's bucket of the policy
+func (s *RedisStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	values, err := takeScript.Run(ctx, s.client, []string{keyPrefix + policy.Name + ":" + key}, policy.Limit, policy.rate()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("failed to take rate limit token: unexpected reply %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	reply, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(reply, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	return newResult(policy, tokens, allowed == 1), nil
+}
+
+// Close closes the connection to Redis
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
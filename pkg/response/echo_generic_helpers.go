@@ -30,8 +30,13 @@ func RespondWithGenericSuccess[T any](c echo.Context, statusCode int, message st
 	return c.JSON(statusCode, resp)
 }
 
-// RespondWithGenericError sends a generic error response
+// RespondWithGenericError sends a generic error response.
+// As problem details, the data is the details extension member.
 func RespondWithGenericError[T any](c echo.Context, statusCode int, message string, data T) error {
+	if WantsProblemDetails(c) {
+		return RespondWithProblem(c, NewProblem(statusCode, message).WithExtension("details", data))
+	}
+
 	requestID := getRequestID(c)
 	var resp GenericErrorResponse[T]
 
@@ -51,8 +51,12 @@ func RespondWithPaginated(c echo.Context, statusCode int, message string, data i
 	return c.JSON(statusCode, resp)
 }
 
-// RespondWithError sends an error response
+// RespondWithError sends an error response, as problem details if the request wants them
 func RespondWithError(c echo.Context, statusCode int, message string) error {
+	if WantsProblemDetails(c) {
+		return RespondWithProblem(c, NewProblem(statusCode, message))
+	}
+
 	requestID := getRequestID(c)
 	var resp ErrorResponse
 
@@ -65,8 +69,35 @@ func RespondWithError(c echo.Context, statusCode int, message string) error {
 	return c.JSON(statusCode, resp)
 }
 
-// RespondWithValidationError sends a validation error response
+// RespondWithErrorCode sends an error response with a machine-readable error code, as problem details if the request wants them
+func RespondWithErrorCode(c echo.Context, statusCode int, errorCode, message string) error {
+	if WantsProblemDetails(c) {
+		problem := NewProblem(statusCode, message)
+		problem.ErrorCode = errorCode
+		return RespondWithProblem(c, problem)
+	}
+
+	requestID := getRequestID(c)
+	var resp ErrorResponse
+
+	if requestID != "" {
+		resp = NewErrorWithRequestID(message, statusCode, requestID)
+	} else {
+		resp = NewError(message, statusCode)
+	}
+
+	return c.JSON(statusCode, resp.WithErrorCode(errorCode))
+}
+
+// RespondWithValidationError sends a validation error response.
+// As problem details, the validation errors are the invalid-params extension member.
 func RespondWithValidationError(c echo.Context, message string, errors []validator.ValidationError) error {
+	if WantsProblemDetails(c) {
+		problem := NewProblem(http.StatusBadRequest, message).WithInvalidParams(errors)
+		problem.ErrorCode = ErrorCodeValidationFailed
+		return RespondWithProblem(c, problem)
+	}
+
 	requestID := getRequestID(c)
 
 	// Convert validation errors to map
@@ -120,6 +151,16 @@ func RespondWithNotFound(c echo.Context, message string) error {
 	return RespondWithError(c, http.StatusNotFound, message)
 }
 
+// RespondWithConflict sends a conflict response
+func RespondWithConflict(c echo.Context, message string) error {
+	return RespondWithError(c, http.StatusConflict, message)
+}
+
+// RespondWithUnprocessableEntity sends an unprocessable entity response
+func RespondWithUnprocessableEntity(c echo.Context, message string) error {
+	return RespondWithError(c, http.StatusUnprocessableEntity, message)
+}
+
 // RespondWithInternalError sends an internal server error response
 func RespondWithInternalError(c echo.Context, message string) error {
 	return RespondWithError(c, http.StatusInternalServerError, message)
@@ -0,0 +1,147 @@
+package response
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+const (
+	// ProblemContentType is the media type of RFC 7807 problem details
+	ProblemContentType = "application/problem+json"
+
+	// ProblemTypeBlank is the problem type of problems that need no more explanation than their status
+	ProblemTypeBlank = "about:blank"
+
+	// problemDetailsKey is the context key that makes error responses problem details
+	problemDetailsKey = "problem_details"
+)
+
+// Problem is an RFC 7807 problem details response.
+// Members other than the standard ones are extension members.
+type Problem struct {
+	Type          string                 `json:"type"`                     // URI reference identifying the problem type
+	Title         string                 `json:"title"`                    // Short summary of the problem type
+	Status        int                    `json:"status"`                   // HTTP status code
+	Detail        string                 `json:"detail,omitempty"`         // Explanation of this occurrence of the problem
+	Instance      string                 `json:"instance,omitempty"`       // URI reference identifying this occurrence
+	ErrorCode     string                 `json:"error_code,omitempty"`     // Stable machine-readable error code
+	RequestID     string                 `json:"request_id,omitempty"`     // Request ID for tracing
+	InvalidParams []InvalidParam         `json:"invalid-params,omitempty"` // Request parameters that failed validation
+	Extensions    map[string]interface{} `json:"-"`                        // Other extension members
+}
+
+// InvalidParam is a request parameter that failed validation
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem creates new problem details for a status, with the status text as title
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:      ProblemTypeBlank,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		ErrorCode: StatusErrorCode(status),
+	}
+}
+
+// WithExtension adds an extension member to the problem details
+func (p Problem) WithExtension(name string, value interface{}) Problem {
+	extensions := make(map[string]interface{}, len(p.Extensions)+1)
+	for k, v := range p.Extensions {
+		extensions[k] = v
+	}
+	extensions[name] = value
+	p.Extensions = extensions
+	return p
+}
+
+// WithInvalidParams adds the fields that failed validation to the problem details
+func (p Problem) WithInvalidParams(errors []validator.ValidationError) Problem {
+	p.InvalidParams = make([]InvalidParam, 0, len(errors))
+	for _, err := range errors {
+		p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: err.Field, Reason: err.Message})
+	}
+	return p
+}
+
+// MarshalJSON writes the extension members next to the standard ones
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]json.RawMessage, len(p.Extensions))
+	for name, value := range p.Extensions {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		members[name] = raw
+	}
+
+	// The standard members win over extensions of the same name
+	var standard map[string]json.RawMessage
+	if err := json.Unmarshal(data, &standard); err != nil {
+		return nil, err
+	}
+	for name, value := range standard {
+		members[name] = value
+	}
+
+	return json.Marshal(members)
+}
+
+// UseProblemDetails makes the error responses to a request problem details
+func UseProblemDetails(c echo.Context) {
+	c.Set(problemDetailsKey, true)
+}
+
+// WantsProblemDetails checks if the error responses to a request are problem details
+func WantsProblemDetails(c echo.Context) bool {
+	wants, _ := c.Get(problemDetailsKey).(bool)
+	return wants
+}
+
+// AcceptsProblemDetails checks if an Accept header asks for problem details
+func AcceptsProblemDetails(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if weight, err := strconv.ParseFloat(q, 64); err != nil || weight <= 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// RespondWithProblem sends problem details, with the request path as instance and the request ID as member
+func RespondWithProblem(c echo.Context, problem Problem) error {
+	if problem.Instance == "" {
+		problem.Instance = c.Request().URL.Path
+	}
+	if problem.RequestID == "" {
+		problem.RequestID = getRequestID(c)
+	}
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return c.Blob(problem.Status, ProblemContentType, data)
+}
@@ -0,0 +1,125 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sh1ro/todo-api/pkg/validator"
+)
+
+func TestAcceptsProblemDetails(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "application/json", want: false},
+		{accept: "*/*", want: false},
+		{accept: "application/problem+json", want: true},
+		{accept: "application/json, application/problem+json;q=0.9", want: true},
+		{accept: "application/problem+json; q=0", want: false},
+		{accept: "Application/Problem+JSON", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := AcceptsProblemDetails(tt.accept); got != tt.want {
+			t.Errorf("AcceptsProblemDetails(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestRespondWithProblem(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+	UseProblemDetails(c)
+
+	err := RespondWithValidationError(c, "Validation failed", []validator.ValidationError{
+		{Field: "title", Message: "title is required"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get(echo.HeaderContentType); ct != ProblemContentType {
+		t.Fatalf("expected content type %q, got %q", ProblemContentType, ct)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"type":       ProblemTypeBlank,
+		"title":      "Bad Request",
+		"status":     float64(http.StatusBadRequest),
+		"detail":     "Validation failed",
+		"instance":   "/api/v1/todos",
+		"error_code": ErrorCodeValidationFailed,
+		"request_id": "req-1",
+	}
+	for name, want := range expected {
+		if body[name] != want {
+			t.Errorf("expected %s to be %v, got %v", name, want, body[name])
+		}
+	}
+
+	params, _ := body["invalid-params"].([]interface{})
+	if len(params) != 1 {
+		t.Fatalf("expected 1 invalid param, got %v", body["invalid-params"])
+	}
+	if param := params[0].(map[string]interface{}); param["name"] != "title" || param["reason"] != "title is required" {
+		t.Fatalf("unexpected invalid param %v", param)
+	}
+}
+
+func TestProblemExtensions(t *testing.T) {
+	problem := NewProblem(http.StatusConflict, "Todo is blocked").
+		WithExtension("blocker_ids", []string{"a"}).
+		WithExtension("status", "ignored")
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ids, _ := body["blocker_ids"].([]interface{}); len(ids) != 1 || ids[0] != "a" {
+		t.Fatalf("expected the blocker_ids extension, got %v", body["blocker_ids"])
+	}
+	// Extensions cannot replace standard members
+	if body["status"] != float64(http.StatusConflict) {
+		t.Fatalf("expected status %d, got %v", http.StatusConflict, body["status"])
+	}
+}
+
+func TestRespondWithErrorKeepsEnvelope(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	if err := RespondWithNotFound(c, "Todo not found"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != StatusError || resp.Code != http.StatusNotFound || resp.ErrorCode != "not_found" {
+		t.Fatalf("unexpected error response %+v", resp)
+	}
+}
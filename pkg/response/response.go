@@ -2,6 +2,7 @@ package response
 
 import (
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -17,6 +18,9 @@ const (
 	StatusWarning Status = "warning"
 )
 
+// ErrorCodeValidationFailed is the error code of responses to requests that fail validation
+const ErrorCodeValidationFailed = "validation_failed"
+
 // Response is a generic API response structure
 type Response struct {
 	Status    Status      `json:"status"`              // Status of the response (success, error, warning)
@@ -43,9 +47,10 @@ type PaginatedResponse struct {
 // ErrorResponse is a detailed error response
 type ErrorResponse struct {
 	Response
-	Code    int                    `json:"code"`              // HTTP status code or application-specific error code
-	Details map[string]interface{} `json:"details,omitempty"` // Additional error details
-	Errors  map[string]string      `json:"errors,omitempty"`  // Validation errors by field
+	Code      int                    `json:"code"`                 // HTTP status code or application-specific error code
+	ErrorCode string                 `json:"error_code,omitempty"` // Stable machine-readable error code, such as "todo_not_found"
+	Details   map[string]interface{} `json:"details,omitempty"`    // Additional error details
+	Errors    map[string]string      `json:"errors,omitempty"`     // Validation errors by field
 }
 
 // NewSuccess creates a new success response
@@ -90,7 +95,7 @@ func NewPaginatedWithRequestID(message string, data interface{}, totalCount, pag
 	return resp
 }
 
-// NewError creates a new error response
+// NewError creates a new error response, with the error code of its HTTP status
 func NewError(message string, code int) ErrorResponse {
 	return ErrorResponse{
 		Response: Response{
@@ -98,7 +103,8 @@ func NewError(message string, code int) ErrorResponse {
 			Message:   message,
 			Timestamp: timeNow(),
 		},
-		Code: code,
+		Code:      code,
+		ErrorCode: StatusErrorCode(code),
 	}
 }
 
@@ -117,8 +123,9 @@ func NewValidationError(message string, errors map[string]string) ErrorResponse
 			Message:   message,
 			Timestamp: timeNow(),
 		},
-		Code:   http.StatusBadRequest,
-		Errors: errors,
+		Code:      http.StatusBadRequest,
+		ErrorCode: ErrorCodeValidationFailed,
+		Errors:    errors,
 	}
 }
 
@@ -135,12 +142,27 @@ func (e ErrorResponse) WithDetails(details map[string]interface{}) ErrorResponse
 	return e
 }
 
+// WithErrorCode sets the machine-readable error code of an error response
+func (e ErrorResponse) WithErrorCode(errorCode string) ErrorResponse {
+	e.ErrorCode = errorCode
+	return e
+}
+
 // WithErrors adds validation errors to an error response
 func (e ErrorResponse) WithErrors(errors map[string]string) ErrorResponse {
 	e.Errors = errors
 	return e
 }
 
+// StatusErrorCode derives an error code from an HTTP status, such as "not_found" for 404
+func StatusErrorCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ToLower(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
+
 // Helper function to get current time (extracted for testing)
 func timeNow() time.Time {
 	return time.Now().UTC()
@@ -0,0 +1,84 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of the RFC 6238 test vectors
+var rfcSecret = []byte("12345678901234567890")
+
+func TestHOTPRFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		got := hotp(rfcSecret, uint64(tt.unix/Period), 8)
+		if got != tt.want {
+			t.Errorf("at %d: expected %s, got %s", tt.unix, tt.want, got)
+		}
+	}
+}
+
+func TestCodeAndValidate(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString(rfcSecret)
+	now := time.Unix(1111111109, 0)
+
+	code, err := Code(secret, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != "081804" {
+		t.Errorf("expected the last six digits of the RFC vector, got %s", code)
+	}
+
+	step, ok := Validate(secret, code, now, 1)
+	if !ok || step != Step(now) {
+		t.Errorf("expected the code to match step %d, got %d %v", Step(now), step, ok)
+	}
+
+	if _, ok := Validate(secret, code, now.Add(Period*time.Second), 1); !ok {
+		t.Error("expected the previous step to be accepted within the skew")
+	}
+	if _, ok := Validate(secret, code, now.Add(2*Period*time.Second), 1); ok {
+		t.Error("expected a step outside the skew to be rejected")
+	}
+	if _, ok := Validate(secret, "000000", now, 1); ok {
+		t.Error("expected a wrong code to be rejected")
+	}
+	if _, ok := Validate(strings.ToLower(secret), code, now, 0); !ok {
+		t.Error("expected secrets to be case insensitive")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil || len(key) != SecretSize {
+		t.Errorf("expected a %d byte secret, got %d (%v)", SecretSize, len(key), err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Todo API", "jane@example.com", "JBSWY3DPEHPK3PXP")
+
+	want := "otpauth://totp/Todo%20API:jane@example.com?algorithm=SHA1&digits=6&issuer=Todo+API&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("expected %s, got %s", want, uri)
+	}
+}
@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SanitizeSQL replaces the string and number literals of a SQL statement with ?, drops its
+// comments and collapses its whitespace, so spans show the shape of the statement without
+// values. Placeholders such as $1 are kept.
+func SanitizeSQL(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	runes := []rune(query)
+	space := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			// Line comment
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			space = true
+			continue
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// Block comment
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i++
+			space = true
+			continue
+		}
+
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+
+		switch {
+		case r == '\'':
+			// String literal, where '' is an escaped quote
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			b.WriteByte('?')
+		case unicode.IsDigit(r) && !partOfWord(runes, i):
+			// Number literal
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+			b.WriteByte('?')
+		case r == '$' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			// Placeholder
+			b.WriteRune(r)
+			for i+1 < len(runes) && unicode.IsDigit(runes[i+1]) {
+				i++
+				b.WriteRune(runes[i])
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// SQLOperation returns the operation of a SQL statement, such as SELECT, in upper case
+func SQLOperation(query string) string {
+	fields := strings.Fields(SanitizeSQL(query))
+	for _, field := range fields {
+		// Skip the parentheses of statements such as (SELECT ...) UNION (SELECT ...)
+		if operation := strings.Trim(field, "("); operation != "" {
+			return strings.ToUpper(operation)
+		}
+	}
+	return ""
+}
+
+// partOfWord reports whether the rune at i continues an identifier, such as the 2 of col2
+func partOfWord(runes []rune, i int) bool {
+	if i == 0 {
+		return false
+	}
+	prev := runes[i-1]
+	return unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '_' || prev == '$'
+}
@@ -0,0 +1,148 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer spans of the service are started with
+const TracerName = "github.com/sh1ro/todo-api"
+
+// Exporters spans can be sent to
+const (
+	// ExporterNone keeps no spans, but still gives requests trace IDs and propagates them
+	ExporterNone = "none"
+
+	// ExporterStdout writes spans as JSON, for local testing
+	ExporterStdout = "stdout"
+
+	// ExporterOTLP sends spans to an OpenTelemetry collector over OTLP/HTTP
+	ExporterOTLP = "otlp"
+)
+
+// Options configures a Provider
+type Options struct {
+	// Exporter is ExporterNone, ExporterStdout or ExporterOTLP
+	Exporter string
+
+	// Endpoint is the URL of the OTLP/HTTP receiver, such as http://localhost:4318
+	Endpoint string
+
+	// ServiceName and ServiceVersion describe the service in every span
+	ServiceName    string
+	ServiceVersion string
+
+	// SampleRatio is the share of new traces that are kept, from 0 to 1. Traces started by a
+	// caller keep the caller's decision.
+	SampleRatio float64
+
+	// Output is where the stdout exporter writes, os.Stdout by default
+	Output io.Writer
+}
+
+// Provider creates the spans of the service and exports them
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewProvider creates a new Provider and installs it, with W3C trace context and baggage
+// propagation, as the global tracer provider
+func NewProvider(ctx context.Context, opts Options) (*Provider, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	}
+
+	switch opts.Exporter {
+	case ExporterNone, "":
+	case ExporterStdout:
+		output := opts.Output
+		if output == nil {
+			output = os.Stdout
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(output))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		// Write each span as it ends, so it shows up next to the logs of its request
+		providerOpts = append(providerOpts, sdktrace.WithSyncer(exporter))
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %s", opts.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return &Provider{provider: provider}, nil
+}
+
+// Shutdown exports the spans still buffered and stops the provider
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.provider.Shutdown(ctx)
+}
+
+// Start starts a span as a child of the span of the context, if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends a span, recording err as its error if it is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartEcho starts a span as a child of the span of an Echo request and makes it the span of the
+// request, so work done with the request's context is part of it. The returned function ends the
+// span, recording err as its error if it is not nil, and gives the request its span back.
+func StartEcho(c echo.Context, name string, attrs ...attribute.KeyValue) func(err error) {
+	req := c.Request()
+	ctx, span := Start(req.Context(), name, attrs...)
+	c.SetRequest(req.WithContext(ctx))
+
+	return func(err error) {
+		End(span, err)
+		c.SetRequest(c.Request().WithContext(req.Context()))
+	}
+}
+
+// TraceID returns the ID of the trace of the context, or an empty string if it has none
+func TraceID(ctx context.Context) string {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "placeholders",
+			query:    "SELECT id FROM todos WHERE user_id = $1 AND status = $2",
+			expected: "SELECT id FROM todos WHERE user_id = $1 AND status = $2",
+		},
+		{
+			name:     "string literals",
+			query:    "SELECT id FROM users WHERE email = 'a@example.com' OR name = 'O''Brien'",
+			expected: "SELECT id FROM users WHERE email = ? OR name = ?",
+		},
+		{
+			name:     "number literals",
+			query:    "SELECT id FROM todos WHERE position > 1.5 LIMIT 200",
+			expected: "SELECT id FROM todos WHERE position > ? LIMIT ?",
+		},
+		{
+			name:     "identifiers with digits",
+			query:    "SELECT col2 FROM t1",
+			expected: "SELECT col2 FROM t1",
+		},
+		{
+			name:     "comments and whitespace",
+			query:    "\n\t-- find todos\n\tSELECT id /* all of them */\n\tFROM todos\n",
+			expected: "SELECT id FROM todos",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeSQL(tt.query); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQLOperation(t *testing.T) {
+	tests := map[string]string{
+		"select id from todos":                         "SELECT",
+		"  -- comment\n INSERT INTO todos VALUES ($1)": "INSERT",
+		"(SELECT 1) UNION (SELECT 2)":                  "SELECT",
+		"":                                             "",
+	}
+
+	for query, expected := range tests {
+		if got := SQLOperation(query); got != expected {
+			t.Errorf("expected operation %q of %q, got %q", expected, query, got)
+		}
+	}
+}
+
+func TestStartEcho(t *testing.T) {
+	var output bytes.Buffer
+	provider, err := NewProvider(context.Background(), Options{
+		Exporter:    ExporterStdout,
+		ServiceName: "test",
+		SampleRatio: 1,
+		Output:      &output,
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	defer provider.Shutdown(context.Background())
+
+	// Continue the trace of a caller
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	parent := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+	req = req.WithContext(parent)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	end := StartEcho(c, "Handler.Handle")
+	if TraceID(c.Request().Context()) != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the span to continue the trace, got trace ID %q", TraceID(c.Request().Context()))
+	}
+	if c.Request().Context() == parent {
+		t.Error("expected the request to carry the new span")
+	}
+
+	end(errors.New("failed"))
+	if c.Request().Context() != parent {
+		t.Error("expected the request to get its span back")
+	}
+
+	var span struct {
+		Name   string
+		Status struct{ Code string }
+		Parent struct{ SpanID string }
+	}
+	if err := json.NewDecoder(strings.NewReader(output.String())).Decode(&span); err != nil {
+		t.Fatalf("failed to decode exported span: %v", err)
+	}
+	if span.Name != "Handler.Handle" || span.Status.Code != "Error" || span.Parent.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("unexpected span %+v", span)
+	}
+}
+
+func TestNewProviderUnsupportedExporter(t *testing.T) {
+	if _, err := NewProvider(context.Background(), Options{Exporter: "zipkin"}); err == nil {
+		t.Error("expected an error for an unsupported exporter")
+	}
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/sh1ro/todo-api/pkg/password"
+)
+
+// PasswordTag is the validation tag checking a field against the password policy
+const PasswordTag = "password"
+
+// RegisterPasswordPolicy registers the password tag, which checks a field against the policy.
+// The tag parameter names sibling fields holding personal info the password must not contain,
+// e.g. `validate:"required,password=Email Fullname"`.
+//
+// The policy is checked without personal info to phrase the message, so a password that only
+// breaks the personal info rule is reported as such. Errors looking up the breached list let the
+// password through here; the services check the policy again and report them.
+func (v *Validator) RegisterPasswordPolicy(policy *password.Policy) error {
+	err := v.validate.RegisterValidation(PasswordTag, func(fl validator.FieldLevel) bool {
+		var policyErr *password.PolicyError
+		err := policy.Check(fl.Field().String(), personalInfo(fl)...)
+		return !errors.As(err, &policyErr)
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.validate.RegisterTranslation(PasswordTag, v.trans, func(ut ut.Translator) error {
+		return ut.Add(PasswordTag, "{0} {1}", true)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		message := "must not contain your email or name"
+
+		var policyErr *password.PolicyError
+		if value, ok := fe.Value().(string); ok && errors.As(policy.Check(value), &policyErr) {
+			message = policyErr.Message
+		}
+
+		t, _ := ut.T(PasswordTag, fe.Field(), message)
+		return t
+	})
+}
+
+// personalInfo collects the values of the sibling fields named by the tag parameter
+func personalInfo(fl validator.FieldLevel) []string {
+	parent := fl.Parent()
+	if parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var values []string
+	for _, name := range strings.Fields(fl.Param()) {
+		field := parent.FieldByName(name)
+		if field.IsValid() && field.Kind() == reflect.String {
+			values = append(values, field.String())
+		}
+	}
+	return values
+}
@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/sh1ro/todo-api/pkg/password"
+)
+
+type registerRequest struct {
+	Fullname string `json:"fullname"`
+	Email    string `json:"email"`
+	Password string `json:"password" validate:"required,password=Email Fullname"`
+}
+
+func TestPasswordTag(t *testing.T) {
+	v := NewValidator()
+	policy := &password.Policy{
+		MinLength:           10,
+		MinCharacterClasses: 2,
+		RejectPersonalInfo:  true,
+		Breached:            password.NewBreachChecker(password.BundledSource()),
+	}
+	if err := v.RegisterPasswordPolicy(policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		want     string
+	}{
+		{"valid", "Tr0ubadour&3", ""},
+		{"too short", "Short1", "password must be at least 10 characters long"},
+		{"too few classes", "lowercaseonly", "password must mix at least 2 of lowercase letters, uppercase letters, digits and symbols"},
+		{"personal info", "JaneDoe-rocks-1", "password must not contain your email or name"},
+		{"breached", "password1234", "password has appeared in a data breach, choose a different one"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			errors := v.Validate(registerRequest{Fullname: "Jane Doe", Email: "jane@example.com", Password: tt.password})
+
+			if tt.want == "" {
+				if errors != nil {
+					t.Fatalf("expected no errors, got %v", errors)
+				}
+				return
+			}
+
+			if len(errors) != 1 || errors[0].Field != "password" || errors[0].Message != tt.want {
+				t.Errorf("expected %q, got %v", tt.want, errors)
+			}
+		})
+	}
+}